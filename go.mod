@@ -3,17 +3,17 @@ module github.com/ecsd-matthew-song/tflint-ruleset-matt-custom
 go 1.18
 
 require (
+	github.com/agext/levenshtein v1.2.3
+	github.com/google/go-cmp v0.5.8
 	github.com/hashicorp/hcl/v2 v2.13.0
 	github.com/terraform-linters/tflint-plugin-sdk v0.11.0
 	github.com/zclconf/go-cty v1.10.0
 )
 
 require (
-	github.com/agext/levenshtein v1.2.3 // indirect
 	github.com/apparentlymart/go-textseg/v13 v13.0.0 // indirect
 	github.com/fatih/color v1.13.0 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
-	github.com/google/go-cmp v0.5.8 // indirect
 	github.com/hashicorp/go-hclog v1.2.0 // indirect
 	github.com/hashicorp/go-plugin v1.4.3 // indirect
 	github.com/hashicorp/yamux v0.0.0-20181012175058-2f1d1f20f75d // indirect
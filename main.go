@@ -1,20 +1,29 @@
 package main
 
 import (
-	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
-	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"fmt"
+	"os"
+
 	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/rules"
+	"github.com/terraform-linters/tflint-plugin-sdk/plugin"
 )
 
 func main() {
+	if dir := os.Getenv(rules.BootstrapEnv); dir != "" {
+		if err := rules.Bootstrap(dir, os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if os.Getenv(rules.SelfTestEnv) != "" {
+		if !rules.SelfTest(rules.NewRuleSet(), os.Stdout) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
-		RuleSet: &tflint.BuiltinRuleSet{
-			Name:    "matt-custom",
-			Version: "0.1.0",
-			Rules: []tflint.Rule{
-				rules.NewAzurermResourceMissingTagsRule(),
-				rules.NewAzurermStorageAccountInvalidAccountTierRule(),
-			},
-		},
+		RuleSet: rules.NewRuleSet(),
 	})
-}
\ No newline at end of file
+}
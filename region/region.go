@@ -0,0 +1,67 @@
+// Package region is a catalog of Azure regions, loaded from regions.json.
+// Update the data file when Azure opens new regions; the rules checking locations pick them up automatically.
+package region
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Region describes an Azure region
+type Region struct {
+	// DisplayName is the name shown in the portal, such as "West Europe", which Terraform also accepts as a location
+	DisplayName string `json:"display_name"`
+	// ShortCode is the abbreviation commonly embedded in resource names, such as "weu"
+	ShortCode string `json:"short_code"`
+	// PairedRegion is the region Azure pairs with this one for disaster recovery, or empty if it has no pair.
+	// Pairs are not always symmetric, e.g. West US 3 is paired with East US, which is paired with West US.
+	PairedRegion string `json:"paired_region,omitempty"`
+	// AvailabilityZones is whether the region has availability zones, which zone-redundant services need
+	AvailabilityZones bool `json:"availability_zones"`
+}
+
+//go:embed regions.json
+var data []byte
+
+var catalog map[string]Region
+
+func init() {
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		panic(fmt.Sprintf("failed to load region catalog: %s", err))
+	}
+}
+
+// Names returns the names of all regions in the catalog, such as "westeurope", sorted
+func Names() []string {
+	names := make([]string, 0, len(catalog))
+	for name := range catalog {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the region of a location, given either as a name like "westeurope" or a display name
+// like "West Europe", the way the azurerm provider normalizes locations
+func Lookup(location string) (string, Region, bool) {
+	name := Normalize(location)
+	region, ok := catalog[name]
+	return name, region, ok
+}
+
+// Normalize returns the name of a location given as a display name, e.g. "westeurope" for "West Europe"
+func Normalize(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+// ShortCodes returns the short codes of all regions by region name
+func ShortCodes() map[string]string {
+	codes := make(map[string]string, len(catalog))
+	for name, region := range catalog {
+		codes[name] = region.ShortCode
+	}
+	return codes
+}
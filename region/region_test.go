@@ -0,0 +1,44 @@
+package region
+
+import "testing"
+
+func Test_Catalog(t *testing.T) {
+	names := Names()
+	if len(names) == 0 {
+		t.Fatal("Expected regions, got none")
+	}
+
+	seen := map[string]string{}
+	for name, code := range ShortCodes() {
+		if code == "" {
+			t.Fatalf("Expected a short code for `%s`, got none", name)
+		}
+		if other, exists := seen[code]; exists {
+			t.Fatalf("Duplicate short code `%s` for `%s` and `%s`", code, name, other)
+		}
+		seen[code] = name
+	}
+
+	for _, name := range names {
+		_, region, _ := Lookup(name)
+		if region.PairedRegion == "" {
+			continue
+		}
+		if _, _, ok := Lookup(region.PairedRegion); !ok || region.PairedRegion == name {
+			t.Fatalf("Expected the paired region `%s` of `%s` to be another region in the catalog", region.PairedRegion, name)
+		}
+	}
+}
+
+func Test_Lookup(t *testing.T) {
+	name, region, ok := Lookup("West Europe")
+	if !ok || name != "westeurope" || region.ShortCode != "weu" {
+		t.Fatalf("Expected `West Europe` to be westeurope with the short code weu, got %s %#v", name, region)
+	}
+	if _, region, _ := Lookup("westcentralus"); region.AvailabilityZones {
+		t.Fatal("Expected westcentralus to have no availability zones")
+	}
+	if _, _, ok := Lookup("moonbase1"); ok {
+		t.Fatal("Expected an unknown location not to be found")
+	}
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermAcrRetentionAndTrustPolicyRule checks that Premium container registries purge untagged manifests and optionally enable content trust
+type AzurermAcrRetentionAndTrustPolicyRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermAcrRetentionAndTrustPolicyRuleConfig struct {
+	RequireContentTrust bool  `hclext:"require_content_trust,optional"`
+	Enforce             *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermAcrRetentionAndTrustPolicyRule returns new rule with default attributes
+func NewAzurermAcrRetentionAndTrustPolicyRule() *AzurermAcrRetentionAndTrustPolicyRule {
+	return &AzurermAcrRetentionAndTrustPolicyRule{
+		resourceType: "azurerm_container_registry",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermAcrRetentionAndTrustPolicyRule) Name() string {
+	return "azurerm_acr_retention_and_trust_policy"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermAcrRetentionAndTrustPolicyRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermAcrRetentionAndTrustPolicyRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermAcrRetentionAndTrustPolicyRule) Link() string {
+	return ""
+}
+
+// Check checks Premium registries configure a retention policy, and a trust policy when required.
+// Both the policy blocks of azurerm 3.x and the attributes replacing them in 4.x are supported.
+func (r *AzurermAcrRetentionAndTrustPolicyRule) Check(runner tflint.Runner) error {
+	config := azurermAcrRetentionAndTrustPolicyRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	policy := &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "enabled"}}}
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "sku"},
+			{Name: "retention_policy_in_days"},
+			{Name: "trust_policy_enabled"},
+		},
+		Blocks: []hclext.BlockSchema{
+			{Type: "retention_policy", Body: policy},
+			{Type: "trust_policy", Body: policy},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		attribute, exists := resource.Body.Attributes["sku"]
+		if !exists {
+			continue
+		}
+
+		var sku string
+		err := runner.EvaluateExpr(attribute.Expr, &sku, nil)
+		err = runner.EnsureNoError(err, func() error {
+			// Retention and trust policies are only available on Premium registries
+			if !strings.EqualFold(sku, "Premium") {
+				return nil
+			}
+			address := resource.Labels[0] + "." + resource.Labels[1]
+
+			if _, exists := resource.Body.Attributes["retention_policy_in_days"]; !exists {
+				if err := r.checkPolicyBlock(runner, resource, "retention_policy", address, "untagged manifests are never purged"); err != nil {
+					return err
+				}
+			}
+
+			if !config.RequireContentTrust {
+				return nil
+			}
+			if attribute, exists := resource.Body.Attributes["trust_policy_enabled"]; exists {
+				return r.checkEnabled(runner, attribute, "`trust_policy_enabled` should be true")
+			}
+			return r.checkPolicyBlock(runner, resource, "trust_policy", address, "content trust is not enforced")
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkPolicyBlock checks the policy block of a 3.x registry exists and is enabled
+func (r *AzurermAcrRetentionAndTrustPolicyRule) checkPolicyBlock(runner tflint.Runner, resource *hclext.Block, blockType string, address string, consequence string) error {
+	blocks := resource.Body.Blocks.ByType()[blockType]
+	if len(blocks) == 0 {
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is a Premium registry without a `%s`, so %s", address, blockType, consequence),
+			resource.DefRange,
+		)
+		return nil
+	}
+
+	for _, block := range blocks {
+		attribute, exists := block.Body.Attributes["enabled"]
+		if !exists {
+			runner.EmitIssue(r, fmt.Sprintf("`%s` does not set `enabled = true`", blockType), block.DefRange)
+			continue
+		}
+		if err := r.checkEnabled(runner, attribute, fmt.Sprintf("`%s.enabled` should be true", blockType)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEnabled reports the attribute when it evaluates to false
+func (r *AzurermAcrRetentionAndTrustPolicyRule) checkEnabled(runner tflint.Runner, attribute *hclext.Attribute, message string) error {
+	var enabled bool
+	err := evaluateBool(runner, attribute.Expr, &enabled)
+	return runner.EnsureNoError(err, func() error {
+		if !enabled {
+			runner.EmitIssue(r, message, attribute.Expr.Range())
+		}
+		return nil
+	})
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermAcrRetentionAndTrustPolicy(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "azurerm 3.x blocks",
+			Content: `
+resource "azurerm_container_registry" "basic" {
+  sku = "Basic"
+}
+
+resource "azurerm_container_registry" "premium" {
+  sku = "Premium"
+
+  trust_policy {
+    enabled = false
+  }
+}`,
+			Config: `
+rule "azurerm_acr_retention_and_trust_policy" {
+  enabled               = true
+  require_content_trust = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAcrRetentionAndTrustPolicyRule(),
+					Message: "`azurerm_container_registry.premium` is a Premium registry without a `retention_policy`, so untagged manifests are never purged",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 48},
+					},
+				},
+				{
+					Rule:    NewAzurermAcrRetentionAndTrustPolicyRule(),
+					Message: "`trust_policy.enabled` should be true",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 15},
+						End:      hcl.Pos{Line: 10, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "azurerm 4.x attributes",
+			Content: `
+resource "azurerm_container_registry" "premium" {
+  sku                      = "Premium"
+  retention_policy_in_days = 7
+  trust_policy_enabled     = false
+}`,
+			Config: `
+rule "azurerm_acr_retention_and_trust_policy" {
+  enabled               = true
+  require_content_trust = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAcrRetentionAndTrustPolicyRule(),
+					Message: "`trust_policy_enabled` should be true",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 30},
+						End:      hcl.Pos{Line: 5, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "Content trust not required",
+			Content: `
+resource "azurerm_container_registry" "premium" {
+  sku = "Premium"
+
+  retention_policy {
+    days    = 7
+    enabled = true
+  }
+}`,
+			Config: `
+rule "azurerm_acr_retention_and_trust_policy" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermAcrRetentionAndTrustPolicyRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
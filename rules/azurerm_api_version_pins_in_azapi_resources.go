@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// azapiResourceTypes are the azapi resource types with a `type` of the form `<resource type>@<API version>`
+var azapiResourceTypes = []string{"azapi_resource", "azapi_resource_action", "azapi_update_resource"}
+
+// azapiType matches an azapi `type`, such as "Microsoft.Storage/storageAccounts/blobServices@2023-01-01",
+// capturing the resource type, the date of the API version and its pre-release suffix, such as "-preview"
+var azapiType = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9]*(?:\.[A-Za-z0-9]+)+(?:/[A-Za-z0-9]+)+)@(\d{4}-\d{2}-\d{2})(-[a-z]+)?$`)
+
+// AzurermApiVersionPinsInAzapiResourcesRule checks that azapi resources pin a valid, stable API version
+// and embed a valid JSON body
+type AzurermApiVersionPinsInAzapiResourcesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermApiVersionPinsInAzapiResourcesRuleConfig struct {
+	// AllowPreview allows pre-release API versions, such as "2023-01-01-preview"
+	AllowPreview bool  `hclext:"allow_preview,optional"`
+	Enforce      *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermApiVersionPinsInAzapiResourcesRule returns new rule with default attributes
+func NewAzurermApiVersionPinsInAzapiResourcesRule() *AzurermApiVersionPinsInAzapiResourcesRule {
+	return &AzurermApiVersionPinsInAzapiResourcesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermApiVersionPinsInAzapiResourcesRule) Name() string {
+	return "azurerm_api_version_pins_in_azapi_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermApiVersionPinsInAzapiResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermApiVersionPinsInAzapiResourcesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermApiVersionPinsInAzapiResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks the `type` of azapi resources names a resource type and an API version dated no later than today,
+// which is not a pre-release unless allowed, and that a `body` given as a string is valid JSON.
+// The API is only called on apply, so a mistyped version or a broken body is otherwise not caught by a plan.
+func (r *AzurermApiVersionPinsInAzapiResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermApiVersionPinsInAzapiResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, resourceType := range azapiResourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "type"}, {Name: "body"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if attribute, exists := resource.Body.Attributes["type"]; exists {
+				var value string
+				err := runner.EvaluateExpr(attribute.Expr, &value, nil)
+				err = runner.EnsureNoError(err, func() error {
+					if problem := apiVersionProblem(value, config.AllowPreview); problem != "" {
+						return runner.EmitIssue(r, fmt.Sprintf("`%s.%s` type \"%s\" %s", resource.Labels[0], resource.Labels[1], value, problem), attribute.Expr.Range())
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			// Since azapi v2 the body is usually an object, which Terraform encodes itself, so only strings are parsed
+			if attribute, exists := resource.Body.Attributes["body"]; exists {
+				var body cty.Value
+				err := runner.EvaluateExpr(attribute.Expr, &body, &tflint.EvaluateExprOption{WantType: &cty.DynamicPseudoType})
+				err = runner.EnsureNoError(err, func() error {
+					if !body.Type().Equals(cty.String) || !body.IsKnown() || body.IsNull() {
+						return nil
+					}
+					var document interface{}
+					if err := json.Unmarshal([]byte(body.AsString()), &document); err != nil {
+						return runner.EmitIssue(r, fmt.Sprintf("`body` of `%s.%s` is not valid JSON: %s", resource.Labels[0], resource.Labels[1], err), attribute.Expr.Range())
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// apiVersionProblem describes what is wrong with an azapi `type`, or returns an empty string
+func apiVersionProblem(value string, allowPreview bool) string {
+	match := azapiType.FindStringSubmatch(value)
+	if match == nil {
+		return "must be in the form `<resource provider>/<resource type>@<API version>`, such as \"Microsoft.Storage/storageAccounts@2023-01-01\""
+	}
+
+	date, err := time.Parse("2006-01-02", match[2])
+	if err != nil {
+		return fmt.Sprintf("has the API version \"%s\", which is not a valid date", match[2]+match[3])
+	}
+	if date.After(now()) {
+		return fmt.Sprintf("has the API version \"%s\", which is dated in the future", match[2]+match[3])
+	}
+	if match[3] != "" && !allowPreview {
+		return fmt.Sprintf("has the pre-release API version \"%s\", set `allow_preview = true` to allow it", match[2]+match[3])
+	}
+	return ""
+}
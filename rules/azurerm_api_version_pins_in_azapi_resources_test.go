@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermApiVersionPinsInAzapiResources(t *testing.T) {
+	defer func(original func() time.Time) { now = original }(now)
+	now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Invalid API versions and bodies",
+			Content: `
+resource "azapi_resource" "container_app" {
+  type = "Microsoft.App/containerApps@2023-05-02-preview"
+  body = { properties = {} }
+}
+
+resource "azapi_resource" "account" {
+  type = "Microsoft.Storage/storageAccounts"
+  body = "{\"kind\": \"StorageV2\",}"
+}
+
+resource "azapi_update_resource" "site" {
+  type = "Microsoft.Web/sites@2023-13-01"
+  body = {
+    properties = { httpsOnly = true }
+  }
+}
+
+resource "azapi_resource_action" "restart" {
+  type = "Microsoft.Web/sites@2025-01-01"
+}
+
+resource "azapi_resource" "vault" {
+  type = "Microsoft.KeyVault/vaults@2023-07-01"
+  body = "{\"properties\": {\"enableRbacAuthorization\": true}}"
+}`,
+			Config: `
+rule "azurerm_api_version_pins_in_azapi_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermApiVersionPinsInAzapiResourcesRule(),
+					Message: "`azapi_resource.container_app` type \"Microsoft.App/containerApps@2023-05-02-preview\" has the pre-release API version \"2023-05-02-preview\", set `allow_preview = true` to allow it",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 58},
+					},
+				},
+				{
+					Rule:    NewAzurermApiVersionPinsInAzapiResourcesRule(),
+					Message: "`azapi_resource.account` type \"Microsoft.Storage/storageAccounts\" must be in the form `<resource provider>/<resource type>@<API version>`, such as \"Microsoft.Storage/storageAccounts@2023-01-01\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 10},
+						End:      hcl.Pos{Line: 8, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermApiVersionPinsInAzapiResourcesRule(),
+					Message: "`body` of `azapi_resource.account` is not valid JSON: invalid character '}' looking for beginning of object key string",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 10},
+						End:      hcl.Pos{Line: 9, Column: 38},
+					},
+				},
+				{
+					Rule:    NewAzurermApiVersionPinsInAzapiResourcesRule(),
+					Message: "`azapi_resource_action.restart` type \"Microsoft.Web/sites@2025-01-01\" has the API version \"2025-01-01\", which is dated in the future",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 20, Column: 10},
+						End:      hcl.Pos{Line: 20, Column: 42},
+					},
+				},
+				{
+					Rule:    NewAzurermApiVersionPinsInAzapiResourcesRule(),
+					Message: "`azapi_update_resource.site` type \"Microsoft.Web/sites@2023-13-01\" has the API version \"2023-13-01\", which is not a valid date",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 13, Column: 10},
+						End:      hcl.Pos{Line: 13, Column: 42},
+					},
+				},
+			},
+		},
+		{
+			Name: "Allow preview",
+			Content: `
+resource "azapi_resource" "container_app" {
+  type = "Microsoft.App/containerApps@2023-05-02-preview"
+}`,
+			Config: `
+rule "azurerm_api_version_pins_in_azapi_resources" {
+  enabled       = true
+  allow_preview = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermApiVersionPinsInAzapiResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
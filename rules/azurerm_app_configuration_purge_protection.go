@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermAppConfigurationPurgeProtectionRule checks that Standard app configuration stores are protected
+type AzurermAppConfigurationPurgeProtectionRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermAppConfigurationPurgeProtectionRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermAppConfigurationPurgeProtectionRule returns new rule with default attributes
+func NewAzurermAppConfigurationPurgeProtectionRule() *AzurermAppConfigurationPurgeProtectionRule {
+	return &AzurermAppConfigurationPurgeProtectionRule{
+		resourceType: "azurerm_app_configuration",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermAppConfigurationPurgeProtectionRule) Name() string {
+	return "azurerm_app_configuration_purge_protection"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermAppConfigurationPurgeProtectionRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermAppConfigurationPurgeProtectionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermAppConfigurationPurgeProtectionRule) Link() string {
+	return ""
+}
+
+// Check checks Standard app configuration stores without purge protection do not allow local authentication
+func (r *AzurermAppConfigurationPurgeProtectionRule) Check(runner tflint.Runner) error {
+	config := azurermAppConfigurationPurgeProtectionRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "sku"},
+			{Name: "purge_protection_enabled"},
+			{Name: "local_auth_enabled"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		attribute, exists := resource.Body.Attributes["sku"]
+		if !exists {
+			// The default SKU is free, which does not support purge protection
+			continue
+		}
+
+		var sku string
+		err := runner.EvaluateExpr(attribute.Expr, &sku, nil)
+		err = runner.EnsureNoError(err, func() error {
+			if !strings.EqualFold(sku, "standard") {
+				return nil
+			}
+
+			// Both settings default to an unprotected store
+			return evaluateOptionalBool(runner, resource.Body, "purge_protection_enabled", false, func(purgeProtection bool) error {
+				return evaluateOptionalBool(runner, resource.Body, "local_auth_enabled", true, func(localAuth bool) error {
+					if !purgeProtection && localAuth {
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` uses the Standard SKU without `purge_protection_enabled` and with local authentication enabled", resource.Labels[0], resource.Labels[1]),
+							resource.DefRange,
+						)
+					}
+					return nil
+				})
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
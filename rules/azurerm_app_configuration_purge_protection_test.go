@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermAppConfigurationPurgeProtection(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Standard SKU with defaults",
+			Content: `
+resource "azurerm_app_configuration" "main" {
+  sku = "standard"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAppConfigurationPurgeProtectionRule(),
+					Message: "`azurerm_app_configuration.main` uses the Standard SKU without `purge_protection_enabled` and with local authentication enabled",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 44},
+					},
+				},
+			},
+		},
+		{
+			Name: "Purge protection enabled",
+			Content: `
+resource "azurerm_app_configuration" "main" {
+  sku                      = "standard"
+  purge_protection_enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Local authentication disabled",
+			Content: `
+resource "azurerm_app_configuration" "main" {
+  sku                = "standard"
+  local_auth_enabled = false
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Free SKU",
+			Content: `
+resource "azurerm_app_configuration" "main" {
+  sku = "free"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "No SKU",
+			Content: `
+resource "azurerm_app_configuration" "main" {
+  name = "appcs-main"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_app_configuration_purge_protection" {
+  enabled = true
+}`
+	rule := NewAzurermAppConfigurationPurgeProtectionRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
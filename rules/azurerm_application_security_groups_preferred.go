@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermApplicationSecurityGroupsPreferredRule checks that NSG rules do not list many ad-hoc IP prefixes
+type AzurermApplicationSecurityGroupsPreferredRule struct {
+	tflint.DefaultRule
+
+	ruleType  string
+	groupType string
+	// Attributes listing the prefixes, along with the attribute referencing application security groups instead
+	prefixAttributes map[string]string
+}
+
+type azurermApplicationSecurityGroupsPreferredRuleConfig struct {
+	MaxAddressPrefixes *int  `hclext:"max_address_prefixes,optional"`
+	Enforce            *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermApplicationSecurityGroupsPreferredRule returns new rule with default attributes
+func NewAzurermApplicationSecurityGroupsPreferredRule() *AzurermApplicationSecurityGroupsPreferredRule {
+	return &AzurermApplicationSecurityGroupsPreferredRule{
+		ruleType:  "azurerm_network_security_rule",
+		groupType: "azurerm_network_security_group",
+		prefixAttributes: map[string]string{
+			"source_address_prefixes":      "source_application_security_group_ids",
+			"destination_address_prefixes": "destination_application_security_group_ids",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermApplicationSecurityGroupsPreferredRule) Name() string {
+	return "azurerm_application_security_groups_preferred"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermApplicationSecurityGroupsPreferredRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermApplicationSecurityGroupsPreferredRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *AzurermApplicationSecurityGroupsPreferredRule) Link() string {
+	return ""
+}
+
+// Check checks standalone and inline NSG rules do not list more address prefixes than allowed
+func (r *AzurermApplicationSecurityGroupsPreferredRule) Check(runner tflint.Runner) error {
+	config := azurermApplicationSecurityGroupsPreferredRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	maxPrefixes := 5
+	if config.MaxAddressPrefixes != nil {
+		maxPrefixes = *config.MaxAddressPrefixes
+	}
+
+	schema := &hclext.BodySchema{}
+	for attribute := range r.prefixAttributes {
+		schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: attribute})
+	}
+
+	rules, err := runner.GetResourceContent(r.ruleType, schema, nil)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules.Blocks {
+		if err := r.checkPrefixes(runner, rule.Body, fmt.Sprintf("`%s.%s`", rule.Labels[0], rule.Labels[1]), maxPrefixes); err != nil {
+			return err
+		}
+	}
+
+	groups, err := runner.GetResourceContent(r.groupType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "security_rule", Body: schema}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups.Blocks {
+		for _, rule := range group.Body.Blocks {
+			if err := r.checkPrefixes(runner, rule.Body, fmt.Sprintf("A `security_rule` of `%s.%s`", group.Labels[0], group.Labels[1]), maxPrefixes); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkPrefixes reports prefix lists of the rule body that are longer than allowed
+func (r *AzurermApplicationSecurityGroupsPreferredRule) checkPrefixes(runner tflint.Runner, body *hclext.BodyContent, subject string, maxPrefixes int) error {
+	for _, name := range sortedKeys(r.prefixAttributes) {
+		attribute, exists := body.Attributes[name]
+		if !exists {
+			continue
+		}
+
+		emit := func(count int) {
+			if count <= maxPrefixes {
+				return
+			}
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("%s lists %d prefixes in `%s` (max %d), group the hosts with application security groups and use `%s` instead", subject, count, name, maxPrefixes, r.prefixAttributes[name]),
+				attribute.Expr.Range(),
+			)
+		}
+
+		// Count the elements of list literals statically, so that references to other resources do not prevent the check
+		if elements, diags := hcl.ExprList(attribute.Expr); !diags.HasErrors() {
+			emit(len(elements))
+			continue
+		}
+
+		var prefixes []string
+		err := runner.EvaluateExpr(attribute.Expr, &prefixes, nil)
+		err = runner.EnsureNoError(err, func() error {
+			emit(len(prefixes))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermApplicationSecurityGroupsPreferred(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Standalone rule over the default limit",
+			Content: `
+resource "azurerm_network_security_rule" "web" {
+  source_address_prefixes      = ["10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.5", "10.0.0.6"]
+  destination_address_prefixes = ["10.1.0.0/24"]
+}`,
+			Config: `
+rule "azurerm_application_security_groups_preferred" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermApplicationSecurityGroupsPreferredRule(),
+					Message: "`azurerm_network_security_rule.web` lists 6 prefixes in `source_address_prefixes` (max 5), group the hosts with application security groups and use `source_application_security_group_ids` instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 34},
+						End:      hcl.Pos{Line: 3, Column: 106},
+					},
+				},
+			},
+		},
+		{
+			Name: "Inline rule over the configured limit",
+			Content: `
+resource "azurerm_network_security_group" "web" {
+  security_rule {
+    destination_address_prefixes = [var.frontend_ip, var.backend_ip, "10.1.0.3"]
+  }
+}`,
+			Config: `
+rule "azurerm_application_security_groups_preferred" {
+  enabled              = true
+  max_address_prefixes = 2
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermApplicationSecurityGroupsPreferredRule(),
+					Message: "A `security_rule` of `azurerm_network_security_group.web` lists 3 prefixes in `destination_address_prefixes` (max 2), group the hosts with application security groups and use `destination_application_security_group_ids` instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 36},
+						End:      hcl.Pos{Line: 4, Column: 81},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermApplicationSecurityGroupsPreferredRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
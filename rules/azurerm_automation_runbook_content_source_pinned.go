@@ -0,0 +1,142 @@
+package rules
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermAutomationRunbookContentSourcePinnedRule checks that runbooks are published from immutable content
+type AzurermAutomationRunbookContentSourcePinnedRule struct {
+	tflint.DefaultRule
+}
+
+type azurermAutomationRunbookContentSourcePinnedRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// pinnedRef matches git refs that do not move: commit hashes and release tags such as `v1.2.0` or `2024.01`
+var pinnedRef = regexp.MustCompile(`^([0-9a-fA-F]{7,40}|v?[0-9]+(\.[0-9]+)+([-+][0-9A-Za-z.-]+)?)$`)
+
+// NewAzurermAutomationRunbookContentSourcePinnedRule returns new rule with default attributes
+func NewAzurermAutomationRunbookContentSourcePinnedRule() *AzurermAutomationRunbookContentSourcePinnedRule {
+	return &AzurermAutomationRunbookContentSourcePinnedRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermAutomationRunbookContentSourcePinnedRule) Name() string {
+	return "azurerm_automation_runbook_content_source_pinned"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermAutomationRunbookContentSourcePinnedRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermAutomationRunbookContentSourcePinnedRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermAutomationRunbookContentSourcePinnedRule) Link() string {
+	return ""
+}
+
+// Check checks that `publish_content_link.uri` of automation runbooks points at a commit hash or release tag
+// rather than a branch, which anyone able to push to it can change, unless the content is pinned by a `hash` block
+func (r *AzurermAutomationRunbookContentSourcePinnedRule) Check(runner tflint.Runner) error {
+	config := azurermAutomationRunbookContentSourcePinnedRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent("azurerm_automation_runbook", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "publish_content_link",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "uri"}},
+					Blocks:     []hclext.BlockSchema{{Type: "hash", Body: &hclext.BodySchema{}}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		for _, link := range resource.Body.Blocks {
+			attribute, exists := link.Body.Attributes["uri"]
+			if !exists || len(link.Body.Blocks) > 0 {
+				continue
+			}
+
+			var uri string
+			err := runner.EvaluateExpr(attribute.Expr, &uri, nil)
+			err = runner.EnsureNoError(err, func() error {
+				branch, ok := contentLinkBranch(uri)
+				if !ok {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` publishes content from \"%s\", which follows the %s, pin it to a commit hash or release tag, or add a `hash` block", resource.Labels[0], resource.Labels[1], uri, branch),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// contentLinkBranch describes the branch that a content URL hosted on GitHub or Azure DevOps follows.
+// It returns false if the URL points at a commit hash or tag, or is not a recognised git hosting URL,
+// such as a storage account blob.
+func contentLinkBranch(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", false
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var ref string
+	switch strings.ToLower(u.Host) {
+	case "raw.githubusercontent.com":
+		// /{owner}/{repo}/{ref}/{path}, or /{owner}/{repo}/refs/heads/{ref}/{path}
+		switch {
+		case len(segments) >= 6 && segments[2] == "refs" && segments[3] == "heads":
+			return fmt.Sprintf("branch \"%s\"", segments[4]), true
+		case len(segments) >= 4 && segments[2] != "refs":
+			ref = segments[2]
+		}
+	case "github.com":
+		// /{owner}/{repo}/raw/{ref}/{path} and /{owner}/{repo}/blob/{ref}/{path}
+		if len(segments) >= 5 && (segments[2] == "raw" || segments[2] == "blob") {
+			ref = segments[3]
+		}
+	case "dev.azure.com":
+		// ?version=GB{branch}, GT{tag} or GC{commit}; items without a version follow the default branch
+		version := u.Query().Get("version")
+		switch {
+		case strings.HasPrefix(version, "GB"):
+			return fmt.Sprintf("branch \"%s\"", strings.TrimPrefix(version, "GB")), true
+		case version == "":
+			return "default branch", true
+		}
+	}
+	if ref == "" || pinnedRef.MatchString(ref) {
+		return "", false
+	}
+	return fmt.Sprintf("branch \"%s\"", ref), true
+}
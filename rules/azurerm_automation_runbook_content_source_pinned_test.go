@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermAutomationRunbookContentSourcePinned(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Branch URLs",
+			Content: `
+resource "azurerm_automation_runbook" "patch" {
+  publish_content_link {
+    uri = "https://raw.githubusercontent.com/contoso/runbooks/main/patch.ps1"
+  }
+}
+
+resource "azurerm_automation_runbook" "cleanup" {
+  publish_content_link {
+    uri = "https://dev.azure.com/contoso/ops/_apis/git/repositories/runbooks/items?path=/cleanup.ps1&version=GBdevelop"
+  }
+}`,
+			Config: `
+rule "azurerm_automation_runbook_content_source_pinned" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermAutomationRunbookContentSourcePinnedRule(),
+					Message: "`azurerm_automation_runbook.patch` publishes content from \"https://raw.githubusercontent.com/contoso/runbooks/main/patch.ps1\", which follows the branch \"main\", pin it to a commit hash or release tag, or add a `hash` block",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 11},
+						End:      hcl.Pos{Line: 4, Column: 78},
+					},
+				},
+				{
+					Rule:    NewAzurermAutomationRunbookContentSourcePinnedRule(),
+					Message: "`azurerm_automation_runbook.cleanup` publishes content from \"https://dev.azure.com/contoso/ops/_apis/git/repositories/runbooks/items?path=/cleanup.ps1&version=GBdevelop\", which follows the branch \"develop\", pin it to a commit hash or release tag, or add a `hash` block",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 11},
+						End:      hcl.Pos{Line: 10, Column: 120},
+					},
+				},
+			},
+		},
+		{
+			Name: "Pinned URLs",
+			Content: `
+resource "azurerm_automation_runbook" "patch" {
+  publish_content_link {
+    uri = "https://raw.githubusercontent.com/contoso/runbooks/v1.4.0/patch.ps1"
+  }
+}
+
+resource "azurerm_automation_runbook" "reboot" {
+  publish_content_link {
+    uri = "https://github.com/contoso/runbooks/raw/3f2a9c1d8e7b6a5f4e3d2c1b0a9f8e7d6c5b4a39/reboot.ps1"
+  }
+}
+
+resource "azurerm_automation_runbook" "cleanup" {
+  publish_content_link {
+    uri = "https://raw.githubusercontent.com/contoso/runbooks/main/cleanup.ps1"
+
+    hash {
+      algorithm = "SHA256"
+      value     = "0123456789abcdef"
+    }
+  }
+}
+
+resource "azurerm_automation_runbook" "backup" {
+  publish_content_link {
+    uri = "https://contoso.blob.core.windows.net/runbooks/backup.ps1"
+  }
+}`,
+			Config: `
+rule "azurerm_automation_runbook_content_source_pinned" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermAutomationRunbookContentSourcePinnedRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
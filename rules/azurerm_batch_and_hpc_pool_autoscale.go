@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermBatchAndHpcPoolAutoscaleRule checks that large Batch pools scale automatically
+type AzurermBatchAndHpcPoolAutoscaleRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermBatchAndHpcPoolAutoscaleRuleConfig struct {
+	MaxFixedDedicatedNodes *int  `hclext:"max_fixed_dedicated_nodes,optional"`
+	Enforce                *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermBatchAndHpcPoolAutoscaleRule returns new rule with default attributes
+func NewAzurermBatchAndHpcPoolAutoscaleRule() *AzurermBatchAndHpcPoolAutoscaleRule {
+	return &AzurermBatchAndHpcPoolAutoscaleRule{
+		resourceType: "azurerm_batch_pool",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermBatchAndHpcPoolAutoscaleRule) Name() string {
+	return "azurerm_batch_and_hpc_pool_autoscale"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermBatchAndHpcPoolAutoscaleRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermBatchAndHpcPoolAutoscaleRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermBatchAndHpcPoolAutoscaleRule) Link() string {
+	return ""
+}
+
+// Check checks Batch pools with more fixed dedicated nodes than allowed use an autoscale formula instead
+func (r *AzurermBatchAndHpcPoolAutoscaleRule) Check(runner tflint.Runner) error {
+	config := azurermBatchAndHpcPoolAutoscaleRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	maxNodes := 10
+	if config.MaxFixedDedicatedNodes != nil {
+		maxNodes = *config.MaxFixedDedicatedNodes
+	}
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "fixed_scale",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "target_dedicated_nodes"}}},
+			},
+			{
+				Type: "auto_scale",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "formula"}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		blocks := resource.Body.Blocks.ByType()
+		if len(blocks["auto_scale"]) > 0 {
+			continue
+		}
+
+		for _, block := range blocks["fixed_scale"] {
+			attribute, exists := block.Body.Attributes["target_dedicated_nodes"]
+			if !exists {
+				continue
+			}
+
+			var nodes int
+			err := runner.EvaluateExpr(attribute.Expr, &nodes, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if nodes > maxNodes {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` keeps %d dedicated nodes running (max %d without autoscaling), use an `auto_scale` block with a formula instead", resource.Labels[0], resource.Labels[1], nodes, maxNodes),
+						attribute.Expr.Range(),
+					)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermBatchAndHpcPoolAutoscale(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Over the default maximum",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = 11
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermBatchAndHpcPoolAutoscaleRule(),
+					Message: "`azurerm_batch_pool.render` keeps 11 dedicated nodes running (max 10 without autoscaling), use an `auto_scale` block with a formula instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 30},
+						End:      hcl.Pos{Line: 4, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "At the default maximum",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = 10
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Over the configured maximum",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = 5
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled                   = true
+  max_fixed_dedicated_nodes = 4
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermBatchAndHpcPoolAutoscaleRule(),
+					Message: "`azurerm_batch_pool.render` keeps 5 dedicated nodes running (max 4 without autoscaling), use an `auto_scale` block with a formula instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 30},
+						End:      hcl.Pos{Line: 4, Column: 31},
+					},
+				},
+			},
+		},
+		{
+			Name: "At the configured maximum",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = 4
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled                   = true
+  max_fixed_dedicated_nodes = 4
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Autoscale block",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = 50
+  }
+
+  auto_scale {
+    evaluation_interval = "PT15M"
+    formula             = "$TargetDedicatedNodes = min(50, $PendingTasks.GetSample(1));"
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Unknown node count",
+			Content: `
+resource "azurerm_batch_pool" "render" {
+  fixed_scale {
+    target_dedicated_nodes = data.azurerm_batch_pool.template.fixed_scale[0].target_dedicated_nodes
+  }
+}`,
+			Config: `
+rule "azurerm_batch_and_hpc_pool_autoscale" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermBatchAndHpcPoolAutoscaleRule()
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermConditionalCreationPatternRule checks that conditionally created resources are referenced by instance
+type AzurermConditionalCreationPatternRule struct {
+	tflint.DefaultRule
+}
+
+type azurermConditionalCreationPatternRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermConditionalCreationPatternRule returns new rule with default attributes
+func NewAzurermConditionalCreationPatternRule() *AzurermConditionalCreationPatternRule {
+	return &AzurermConditionalCreationPatternRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermConditionalCreationPatternRule) Name() string {
+	return "azurerm_conditional_creation_pattern"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermConditionalCreationPatternRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermConditionalCreationPatternRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermConditionalCreationPatternRule) Link() string {
+	return ""
+}
+
+// Check checks references to the attributes of resources whose `count` is a conditional, such as
+// `count = var.enabled ? 1 : 0`, for a missing instance key. The resources are lists of instances,
+// so `azurerm_x.y.id` fails at plan time and must be written `azurerm_x.y[0].id` or `one(azurerm_x.y[*].id)`.
+func (r *AzurermConditionalCreationPatternRule) Check(runner tflint.Runner) error {
+	config := azurermConditionalCreationPatternRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	bodies := []*hclsyntax.Body{}
+	for _, name := range sortedFileNames(files) {
+		if body, ok := files[name].Body.(*hclsyntax.Body); ok {
+			bodies = append(bodies, body)
+		}
+	}
+
+	conditional := map[string]bool{}
+	for _, body := range bodies {
+		for _, block := range body.Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+			count, exists := block.Body.Attributes["count"]
+			if !exists {
+				continue
+			}
+			if _, ok := count.Expr.(*hclsyntax.ConditionalExpr); ok {
+				conditional[block.Labels[0]+"."+block.Labels[1]] = true
+			}
+		}
+	}
+	if len(conditional) == 0 {
+		return nil
+	}
+
+	for _, body := range bodies {
+		for _, attribute := range bodyAttributes(body) {
+			traversals := attribute.Expr.Variables()
+			sort.Slice(traversals, func(i, j int) bool {
+				return traversals[i].SourceRange().Start.Byte < traversals[j].SourceRange().Start.Byte
+			})
+			for _, traversal := range traversals {
+				address, ok := unindexedResourceReference(traversal)
+				if !ok || !conditional[address] {
+					continue
+				}
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s` is created conditionally with `count`, so reference an instance such as `%s[0]` or use `one(%s[*])`", address, address, address),
+					traversal.SourceRange(),
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// unindexedResourceReference returns the address of the resource whose attribute the traversal refers to
+// without an instance key, such as `azurerm_x.y` for `azurerm_x.y.id`
+func unindexedResourceReference(traversal hcl.Traversal) (string, bool) {
+	if len(traversal) < 3 {
+		return "", false
+	}
+	root, ok := traversal[0].(hcl.TraverseRoot)
+	if !ok {
+		return "", false
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	if _, ok := traversal[2].(hcl.TraverseAttr); !ok {
+		return "", false
+	}
+	return root.Name + "." + name.Name, true
+}
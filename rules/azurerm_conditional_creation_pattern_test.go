@@ -0,0 +1,103 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermConditionalCreationPattern(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "References without an instance key",
+			Content: `
+resource "azurerm_log_analytics_workspace" "main" {
+  count = var.enabled ? 1 : 0
+  name  = "log-main"
+}
+
+resource "azurerm_resource_group" "main" {
+  name = "rg-main"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "main" {
+  name                       = "diag-main"
+  target_resource_id         = azurerm_resource_group.main.id
+  log_analytics_workspace_id = azurerm_log_analytics_workspace.main.id
+}
+
+output "workspace_id" {
+  value = one(azurerm_log_analytics_workspace.main[*].id)
+}
+
+output "workspace_name" {
+  value = var.enabled ? azurerm_log_analytics_workspace.main[0].name : null
+}
+
+output "workspace" {
+  value = {
+    customer_id = azurerm_log_analytics_workspace.main.workspace_id
+  }
+}`,
+			Config: `
+rule "azurerm_conditional_creation_pattern" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermConditionalCreationPatternRule(),
+					Message: "`azurerm_log_analytics_workspace.main` is created conditionally with `count`, so reference an instance such as `azurerm_log_analytics_workspace.main[0]` or use `one(azurerm_log_analytics_workspace.main[*])`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 14, Column: 32},
+						End:      hcl.Pos{Line: 14, Column: 71},
+					},
+				},
+				{
+					Rule:    NewAzurermConditionalCreationPatternRule(),
+					Message: "`azurerm_log_analytics_workspace.main` is created conditionally with `count`, so reference an instance such as `azurerm_log_analytics_workspace.main[0]` or use `one(azurerm_log_analytics_workspace.main[*])`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 27, Column: 19},
+						End:      hcl.Pos{Line: 27, Column: 68},
+					},
+				},
+			},
+		},
+		{
+			Name: "Counts that are not conditionals",
+			Content: `
+resource "azurerm_subnet" "main" {
+  count = 2
+  name  = "snet-${count.index}"
+}
+
+output "subnet_id" {
+  value = azurerm_subnet.main.id
+}`,
+			Config: `
+rule "azurerm_conditional_creation_pattern" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermConditionalCreationPatternRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermCountVsForEachForNamedResourcesRule checks that resources with stable identities use for_each rather than count
+type AzurermCountVsForEachForNamedResourcesRule struct {
+	tflint.DefaultRule
+
+	defaultResourceTypes []string
+}
+
+type azurermCountVsForEachForNamedResourcesRuleConfig struct {
+	ResourceTypes []string `hclext:"resource_types,optional"`
+	Enforce       *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermCountVsForEachForNamedResourcesRule returns new rule with default attributes
+func NewAzurermCountVsForEachForNamedResourcesRule() *AzurermCountVsForEachForNamedResourcesRule {
+	return &AzurermCountVsForEachForNamedResourcesRule{
+		defaultResourceTypes: []string{
+			"azurerm_resource_group",
+			"azurerm_virtual_network",
+			"azurerm_subnet",
+			"azurerm_key_vault",
+			"azurerm_storage_account",
+			"azurerm_storage_container",
+			"azurerm_network_security_group",
+			"azurerm_private_dns_zone",
+			"azurerm_dns_zone",
+			"azurerm_user_assigned_identity",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermCountVsForEachForNamedResourcesRule) Name() string {
+	return "azurerm_count_vs_for_each_for_named_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermCountVsForEachForNamedResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermCountVsForEachForNamedResourcesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermCountVsForEachForNamedResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks named resources do not use `count` to create several instances, since removing an
+// element from the middle of the list shifts the indexes and destroys the following resources
+func (r *AzurermCountVsForEachForNamedResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermCountVsForEachForNamedResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resourceTypes := r.defaultResourceTypes
+	if len(config.ResourceTypes) > 0 {
+		resourceTypes = config.ResourceTypes
+	}
+
+	for _, resourceType := range resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "count"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes["count"]
+			if !exists || conditionalToggle(attribute.Expr) {
+				continue
+			}
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` uses `count`, use `for_each` with stable keys instead so that removing an element does not destroy and recreate the following resources", resource.Labels[0], resource.Labels[1]),
+				attribute.Expr.Range(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// conditionalToggle returns whether a count expression only creates zero or one instance,
+// e.g. `var.enabled ? 1 : 0`, which has no indexes to shift
+func conditionalToggle(expr hcl.Expression) bool {
+	if conditional, ok := expr.(*hclsyntax.ConditionalExpr); ok {
+		return conditionalToggle(conditional.TrueResult) && conditionalToggle(conditional.FalseResult)
+	}
+	if len(expr.Variables()) > 0 {
+		return false
+	}
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || !val.Type().Equals(cty.Number) {
+		return false
+	}
+	return val.Equals(cty.Zero).True() || val.Equals(cty.NumberIntVal(1)).True()
+}
@@ -0,0 +1,96 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermCountVsForEachForNamedResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Count over a list",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  count = length(var.names)
+  name  = var.names[count.index]
+}`,
+			Config: `
+rule "azurerm_count_vs_for_each_for_named_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCountVsForEachForNamedResourcesRule(),
+					Message: "`azurerm_resource_group.main` uses `count`, use `for_each` with stable keys instead so that removing an element does not destroy and recreate the following resources",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "Conditional creation",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  count = var.create_key_vault ? 1 : 0
+}
+
+resource "azurerm_subnet" "main" {
+  count = 1
+}`,
+			Config: `
+rule "azurerm_count_vs_for_each_for_named_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Custom resource types",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  count = 3
+}
+
+resource "azurerm_linux_virtual_machine" "main" {
+  count = 3
+}`,
+			Config: `
+rule "azurerm_count_vs_for_each_for_named_resources" {
+  enabled        = true
+  resource_types = ["azurerm_linux_virtual_machine"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCountVsForEachForNamedResourcesRule(),
+					Message: "`azurerm_linux_virtual_machine.main` uses `count`, use `for_each` with stable keys instead so that removing an element does not destroy and recreate the following resources",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 11},
+						End:      hcl.Pos{Line: 7, Column: 12},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermCountVsForEachForNamedResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
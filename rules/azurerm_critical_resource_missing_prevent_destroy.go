@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermCriticalResourceMissingPreventDestroyRule checks that critical resources cannot be destroyed by a plan
+type AzurermCriticalResourceMissingPreventDestroyRule struct {
+	tflint.DefaultRule
+}
+
+type azurermCriticalResourceMissingPreventDestroyRuleConfig struct {
+	// Resources are the critical resource types or globs, replacing the default key vaults, SQL servers and storage accounts
+	Resources []string `hclext:"resources,optional"`
+	Enforce   *bool    `hclext:"enforce,optional"`
+}
+
+// defaultCriticalResources are the resource types whose data is lost when they are destroyed
+var defaultCriticalResources = []string{
+	"azurerm_key_vault",
+	"azurerm_mssql_server",
+	"azurerm_storage_account",
+}
+
+// NewAzurermCriticalResourceMissingPreventDestroyRule returns new rule with default attributes
+func NewAzurermCriticalResourceMissingPreventDestroyRule() *AzurermCriticalResourceMissingPreventDestroyRule {
+	return &AzurermCriticalResourceMissingPreventDestroyRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermCriticalResourceMissingPreventDestroyRule) Name() string {
+	return "azurerm_critical_resource_missing_prevent_destroy"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermCriticalResourceMissingPreventDestroyRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermCriticalResourceMissingPreventDestroyRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermCriticalResourceMissingPreventDestroyRule) Link() string {
+	return ""
+}
+
+// Check checks that critical resources set `lifecycle { prevent_destroy = true }`, so that a renamed resource
+// or a replacing change fails the plan instead of deleting the data held by the resource
+func (r *AzurermCriticalResourceMissingPreventDestroyRule) Check(runner tflint.Runner) error {
+	config := azurermCriticalResourceMissingPreventDestroyRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, pattern := range config.Resources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("resources: invalid glob %q: %s", pattern, err)
+		}
+	}
+	if len(config.Resources) == 0 {
+		config.Resources = defaultCriticalResources
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "lifecycle",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "prevent_destroy"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range content.Blocks {
+		if !matchesGlob(resource.Labels[0], config.Resources) {
+			continue
+		}
+		address := resource.Labels[0] + "." + resource.Labels[1]
+
+		var attribute *hclext.Attribute
+		for _, lifecycle := range resource.Body.Blocks {
+			if found, exists := lifecycle.Body.Attributes["prevent_destroy"]; exists {
+				attribute = found
+			}
+		}
+		if attribute == nil {
+			if err := runner.EmitIssue(r, fmt.Sprintf("`%s` is a critical resource without `lifecycle { prevent_destroy = true }`", address), resource.DefRange); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var prevented bool
+		err := evaluateBool(runner, attribute.Expr, &prevented)
+		err = runner.EnsureNoError(err, func() error {
+			if prevented {
+				return nil
+			}
+			return runner.EmitIssue(r, fmt.Sprintf("`%s` is a critical resource, set `prevent_destroy = true`", address), attribute.Expr.Range())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermCriticalResourceMissingPreventDestroy(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Default critical resources",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  name = "kv-main"
+}
+
+resource "azurerm_storage_account" "main" {
+  lifecycle {
+    prevent_destroy = false
+  }
+}
+
+resource "azurerm_mssql_server" "main" {
+  lifecycle {
+    ignore_changes  = [tags]
+    prevent_destroy = true
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  name = "rg-main"
+}`,
+			Config: `
+rule "azurerm_critical_resource_missing_prevent_destroy" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCriticalResourceMissingPreventDestroyRule(),
+					Message: "`azurerm_key_vault.main` is a critical resource without `lifecycle { prevent_destroy = true }`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 36},
+					},
+				},
+				{
+					Rule:    NewAzurermCriticalResourceMissingPreventDestroyRule(),
+					Message: "`azurerm_storage_account.main` is a critical resource, set `prevent_destroy = true`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 23},
+						End:      hcl.Pos{Line: 8, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom critical resources",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  name = "kv-main"
+}
+
+resource "azurerm_cosmosdb_account" "main" {
+  name = "cosmos-main"
+}`,
+			Config: `
+rule "azurerm_critical_resource_missing_prevent_destroy" {
+  enabled   = true
+  resources = ["azurerm_cosmosdb_*"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCriticalResourceMissingPreventDestroyRule(),
+					Message: "`azurerm_cosmosdb_account.main` is a critical resource without `lifecycle { prevent_destroy = true }`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 43},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermCriticalResourceMissingPreventDestroyRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermCustomScriptExtensionNoInlineSecretsRule checks VM extension settings for inline credentials and piped downloads
+type AzurermCustomScriptExtensionNoInlineSecretsRule struct {
+	tflint.DefaultRule
+
+	resourceTypes   []string
+	attributeNames  []string
+	defaultPatterns []string
+}
+
+type azurermCustomScriptExtensionNoInlineSecretsRuleConfig struct {
+	Patterns []string `hclext:"patterns,optional"`
+	Enforce  *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermCustomScriptExtensionNoInlineSecretsRule returns new rule with default attributes
+func NewAzurermCustomScriptExtensionNoInlineSecretsRule() *AzurermCustomScriptExtensionNoInlineSecretsRule {
+	return &AzurermCustomScriptExtensionNoInlineSecretsRule{
+		resourceTypes: []string{
+			"azurerm_virtual_machine_extension",
+			"azurerm_virtual_machine_scale_set_extension",
+		},
+		attributeNames: []string{"settings", "protected_settings"},
+		defaultPatterns: []string{
+			// Downloads piped straight into a shell
+			`(?i)\b(curl|wget)\b[^|]*\|\s*(sudo\s+)?(ba|z)?sh\b`,
+			// Credentials passed as arguments
+			`(?i)\b(password|passwd|pwd|secret|token|api_?key)\s*[=:]\s*['"]?[^\s'"$]+`,
+			`(?i)--?(password|secret|token)[=\s]+['"]?[^\s'"$-]+`,
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermCustomScriptExtensionNoInlineSecretsRule) Name() string {
+	return "azurerm_custom_script_extension_no_inline_secrets"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermCustomScriptExtensionNoInlineSecretsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermCustomScriptExtensionNoInlineSecretsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermCustomScriptExtensionNoInlineSecretsRule) Link() string {
+	return ""
+}
+
+// Check checks the JSON settings of VM extensions against the secret patterns
+func (r *AzurermCustomScriptExtensionNoInlineSecretsRule) Check(runner tflint.Runner) error {
+	config := azurermCustomScriptExtensionNoInlineSecretsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	sources := r.defaultPatterns
+	if len(config.Patterns) > 0 {
+		sources = config.Patterns
+	}
+	patterns, err := compilePatterns("patterns", sources)
+	if err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	schema := &hclext.BodySchema{}
+	for _, name := range r.attributeNames {
+		schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+	}
+
+	for _, resourceType := range r.resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, schema, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			for _, name := range r.attributeNames {
+				attribute, exists := resource.Body.Attributes[name]
+				if !exists {
+					continue
+				}
+
+				err := evaluateJSON(runner, attribute.Expr, func(document interface{}, err error) error {
+					if err != nil {
+						// Malformed settings are rejected by Azure and are out of scope here
+						return nil
+					}
+					walkJSONStrings(document, nil, func(path []string, value string) {
+						for _, pattern := range patterns {
+							if pattern.MatchString(value) {
+								runner.EmitIssue(
+									r,
+									fmt.Sprintf("`%s.%s` contains an inline credential or piped download matching `%s`, use a key vault reference or a downloaded script instead", name, strings.Join(path, "."), pattern),
+									attribute.Expr.Range(),
+								)
+								return
+							}
+						}
+					})
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
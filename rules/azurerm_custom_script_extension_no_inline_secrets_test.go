@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermCustomScriptExtensionNoInlineSecrets(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Piped download and inline password",
+			Content: `
+resource "azurerm_virtual_machine_extension" "script" {
+  settings = <<SETTINGS
+{
+  "commandToExecute": "curl -sL https://example.com/install.sh | sudo bash"
+}
+SETTINGS
+
+  protected_settings = <<SETTINGS
+{
+  "commandToExecute": "./setup.sh --password=Hunter2"
+}
+SETTINGS
+}`,
+			Config: `
+rule "azurerm_custom_script_extension_no_inline_secrets" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCustomScriptExtensionNoInlineSecretsRule(),
+					Message: "`settings.commandToExecute` contains an inline credential or piped download matching `(?i)\\b(curl|wget)\\b[^|]*\\|\\s*(sudo\\s+)?(ba|z)?sh\\b`, use a key vault reference or a downloaded script instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14},
+						End:      hcl.Pos{Line: 7, Column: 9},
+					},
+				},
+				{
+					Rule:    NewAzurermCustomScriptExtensionNoInlineSecretsRule(),
+					Message: "`protected_settings.commandToExecute` contains an inline credential or piped download matching `(?i)\\b(password|passwd|pwd|secret|token|api_?key)\\s*[=:]\\s*['\"]?[^\\s'\"$]+`, use a key vault reference or a downloaded script instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 24},
+						End:      hcl.Pos{Line: 13, Column: 9},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom patterns",
+			Content: `
+resource "azurerm_virtual_machine_scale_set_extension" "script" {
+  settings = "{\"commandToExecute\": \"Invoke-WebRequest https://example.com/a.ps1 | iex\"}"
+}`,
+			Config: `
+rule "azurerm_custom_script_extension_no_inline_secrets" {
+  enabled = true
+  patterns = ["\\|\\s*iex"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCustomScriptExtensionNoInlineSecretsRule(),
+					Message: "`settings.commandToExecute` contains an inline credential or piped download matching `\\|\\s*iex`, use a key vault reference or a downloaded script instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14},
+						End:      hcl.Pos{Line: 3, Column: 93},
+					},
+				},
+			},
+		},
+		{
+			Name: "Clean settings",
+			Content: `
+resource "azurerm_virtual_machine_extension" "script" {
+  settings = "{\"fileUris\": [\"https://example.com/setup.sh\"], \"commandToExecute\": \"./setup.sh\"}"
+}`,
+			Config: `
+rule "azurerm_custom_script_extension_no_inline_secrets" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermCustomScriptExtensionNoInlineSecretsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermDashboardAndWorkbookJSONValidRule checks that dashboards and workbooks embed well-formed JSON
+type AzurermDashboardAndWorkbookJSONValidRule struct {
+	tflint.DefaultRule
+}
+
+type azurermDashboardAndWorkbookJSONValidRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// embeddedJSONDocument describes the JSON document a resource type embeds in an attribute,
+// which must be an object with the property at the top level
+type embeddedJSONDocument struct {
+	resourceType string
+	attribute    string
+	property     string
+	// lenses of dashboards are either an array or an object keyed by index, while workbook items are an array
+	allowObject bool
+}
+
+var embeddedJSONDocuments = []embeddedJSONDocument{
+	{resourceType: "azurerm_portal_dashboard", attribute: "dashboard_properties", property: "lenses", allowObject: true},
+	{resourceType: "azurerm_dashboard", attribute: "dashboard_properties", property: "lenses", allowObject: true},
+	{resourceType: "azurerm_application_insights_workbook", attribute: "data_json", property: "items"},
+	{resourceType: "azurerm_application_insights_workbook_template", attribute: "template_data", property: "items"},
+}
+
+// NewAzurermDashboardAndWorkbookJSONValidRule returns new rule with default attributes
+func NewAzurermDashboardAndWorkbookJSONValidRule() *AzurermDashboardAndWorkbookJSONValidRule {
+	return &AzurermDashboardAndWorkbookJSONValidRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermDashboardAndWorkbookJSONValidRule) Name() string {
+	return "azurerm_dashboard_and_workbook_json_valid"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermDashboardAndWorkbookJSONValidRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermDashboardAndWorkbookJSONValidRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermDashboardAndWorkbookJSONValidRule) Link() string {
+	return ""
+}
+
+// Check checks that portal dashboards and Application Insights workbooks embed valid JSON with the expected
+// top-level shape, since a broken document is only rejected, or silently rendered empty, once applied
+func (r *AzurermDashboardAndWorkbookJSONValidRule) Check(runner tflint.Runner) error {
+	config := azurermDashboardAndWorkbookJSONValidRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, document := range embeddedJSONDocuments {
+		resources, err := runner.GetResourceContent(document.resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: document.attribute}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes[document.attribute]
+			if !exists {
+				continue
+			}
+
+			err := evaluateJSON(runner, attribute.Expr, func(value interface{}, err error) error {
+				if err != nil {
+					return runner.EmitIssue(r, fmt.Sprintf("`%s` of `%s.%s` is not valid JSON: %s", document.attribute, resource.Labels[0], resource.Labels[1], err), attribute.Expr.Range())
+				}
+				if problem := document.shapeProblem(value); problem != "" {
+					return runner.EmitIssue(r, fmt.Sprintf("`%s` of `%s.%s` %s", document.attribute, resource.Labels[0], resource.Labels[1], problem), attribute.Expr.Range())
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// shapeProblem describes how the decoded document differs from the expected shape, or returns an empty string
+func (d embeddedJSONDocument) shapeProblem(value interface{}) string {
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return "must be a JSON object"
+	}
+	property, exists := object[d.property]
+	if !exists {
+		return fmt.Sprintf("has no top-level `%s` property", d.property)
+	}
+	switch property.(type) {
+	case []interface{}:
+		return ""
+	case map[string]interface{}:
+		if d.allowObject {
+			return ""
+		}
+	}
+	if d.allowObject {
+		return fmt.Sprintf("must have an array or object `%s` property", d.property)
+	}
+	return fmt.Sprintf("must have an array `%s` property", d.property)
+}
@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDashboardAndWorkbookJSONValid(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Broken documents",
+			Content: `
+resource "azurerm_portal_dashboard" "ops" {
+  dashboard_properties = "{\"lenses\": {\"0\": {}}"
+}
+
+resource "azurerm_application_insights_workbook" "usage" {
+  data_json = "{\"version\": \"Notebook/1.0\", \"items\": {}}"
+}
+
+resource "azurerm_application_insights_workbook_template" "usage" {
+  template_data = "[]"
+}`,
+			Config: `
+rule "azurerm_dashboard_and_workbook_json_valid" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDashboardAndWorkbookJSONValidRule(),
+					Message: "`dashboard_properties` of `azurerm_portal_dashboard.ops` is not valid JSON: unexpected end of JSON input",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 26},
+						End:      hcl.Pos{Line: 3, Column: 52},
+					},
+				},
+				{
+					Rule:    NewAzurermDashboardAndWorkbookJSONValidRule(),
+					Message: "`data_json` of `azurerm_application_insights_workbook.usage` must have an array `items` property",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 15},
+						End:      hcl.Pos{Line: 7, Column: 63},
+					},
+				},
+				{
+					Rule:    NewAzurermDashboardAndWorkbookJSONValidRule(),
+					Message: "`template_data` of `azurerm_application_insights_workbook_template.usage` must be a JSON object",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 11, Column: 19},
+						End:      hcl.Pos{Line: 11, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "Missing lenses",
+			Content: `
+resource "azurerm_portal_dashboard" "ops" {
+  dashboard_properties = "{\"metadata\": {}}"
+}`,
+			Config: `
+rule "azurerm_dashboard_and_workbook_json_valid" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDashboardAndWorkbookJSONValidRule(),
+					Message: "`dashboard_properties` of `azurerm_portal_dashboard.ops` has no top-level `lenses` property",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 26},
+						End:      hcl.Pos{Line: 3, Column: 46},
+					},
+				},
+			},
+		},
+		{
+			Name: "Valid documents",
+			Content: `
+resource "azurerm_portal_dashboard" "ops" {
+  dashboard_properties = "{\"lenses\": [{\"order\": 0, \"parts\": []}]}"
+}
+
+resource "azurerm_application_insights_workbook" "usage" {
+  data_json = "{\"version\": \"Notebook/1.0\", \"items\": []}"
+}`,
+			Config: `
+rule "azurerm_dashboard_and_workbook_json_valid" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermDashboardAndWorkbookJSONValidRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
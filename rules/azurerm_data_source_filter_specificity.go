@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermDataSourceFilterSpecificityRule checks that data sources are pinned instead of resolving the latest match
+type AzurermDataSourceFilterSpecificityRule struct {
+	tflint.DefaultRule
+
+	dataSources map[string]dataSourcePinning
+	// Values that resolve to whatever is newest when the plan runs
+	floatingValues []string
+}
+
+// dataSourcePinning lists the attributes a data source must set and those it must not use
+type dataSourcePinning struct {
+	Type    string   `hclext:"type,label"`
+	Require []string `hclext:"require,optional"`
+	Forbid  []string `hclext:"forbid,optional"`
+}
+
+type azurermDataSourceFilterSpecificityRuleConfig struct {
+	DataSources []dataSourcePinning `hclext:"data_source,block"`
+	Enforce     *bool               `hclext:"enforce,optional"`
+}
+
+// NewAzurermDataSourceFilterSpecificityRule returns new rule with default attributes
+func NewAzurermDataSourceFilterSpecificityRule() *AzurermDataSourceFilterSpecificityRule {
+	return &AzurermDataSourceFilterSpecificityRule{
+		dataSources: map[string]dataSourcePinning{
+			"azurerm_image":                {Type: "azurerm_image", Forbid: []string{"name_regex"}},
+			"azurerm_platform_image":       {Type: "azurerm_platform_image", Require: []string{"version"}},
+			"azurerm_key_vault_secret":     {Type: "azurerm_key_vault_secret", Require: []string{"version"}},
+			"azurerm_shared_image_version": {Type: "azurerm_shared_image_version", Require: []string{"name"}},
+		},
+		floatingValues: []string{"latest", "recent"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermDataSourceFilterSpecificityRule) Name() string {
+	return "azurerm_data_source_filter_specificity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermDataSourceFilterSpecificityRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermDataSourceFilterSpecificityRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermDataSourceFilterSpecificityRule) Link() string {
+	return ""
+}
+
+// Check checks data sources set their pinning attributes to fixed values and avoid filters
+func (r *AzurermDataSourceFilterSpecificityRule) Check(runner tflint.Runner) error {
+	config := azurermDataSourceFilterSpecificityRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	dataSources := map[string]dataSourcePinning{}
+	for dataSourceType, pinning := range r.dataSources {
+		dataSources[dataSourceType] = pinning
+	}
+	for _, pinning := range config.DataSources {
+		dataSources[pinning.Type] = pinning
+	}
+
+	types := make([]string, 0, len(dataSources))
+	for dataSourceType := range dataSources {
+		types = append(types, dataSourceType)
+	}
+	sort.Strings(types)
+
+	for _, dataSourceType := range types {
+		pinning := dataSources[dataSourceType]
+
+		schema := &hclext.BodySchema{}
+		for _, name := range append(append([]string{}, pinning.Require...), pinning.Forbid...) {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+		}
+		blocks, err := getDataSourceContent(runner, dataSourceType, schema)
+		if err != nil {
+			return err
+		}
+
+		for _, block := range blocks {
+			address := fmt.Sprintf("data.%s.%s", block.Labels[0], block.Labels[1])
+
+			for _, name := range pinning.Forbid {
+				if attribute, exists := block.Body.Attributes[name]; exists {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s` filters with `%s`, which makes plans depend on whatever matches at the time, reference an exact object instead", address, name),
+						attribute.Range,
+					)
+				}
+			}
+
+			for _, name := range pinning.Require {
+				attribute, exists := block.Body.Attributes[name]
+				if !exists {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s` does not set `%s`, so it resolves to the latest version when the plan runs", address, name),
+						block.DefRange,
+					)
+					continue
+				}
+
+				var value string
+				err := runner.EvaluateExpr(attribute.Expr, &value, nil)
+				err = runner.EnsureNoError(err, func() error {
+					for _, floating := range r.floatingValues {
+						if strings.EqualFold(value, floating) {
+							runner.EmitIssue(
+								r,
+								fmt.Sprintf("`%s` sets `%s` to \"%s\", pin a fixed version instead", address, name, value),
+								attribute.Expr.Range(),
+							)
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
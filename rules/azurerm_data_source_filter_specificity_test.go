@@ -0,0 +1,115 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDataSourceFilterSpecificity(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Default pinning",
+			Content: `
+data "azurerm_image" "ubuntu" {
+  name_regex          = "^ubuntu-.*"
+  resource_group_name = "images"
+}
+
+data "azurerm_key_vault_secret" "password" {
+  name = "password"
+}
+
+data "azurerm_shared_image_version" "app" {
+  name = "latest"
+}
+
+data "azurerm_platform_image" "pinned" {
+  version = "20.04.202206010"
+}`,
+			Config: `
+rule "azurerm_data_source_filter_specificity" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDataSourceFilterSpecificityRule(),
+					Message: "`data.azurerm_image.ubuntu` filters with `name_regex`, which makes plans depend on whatever matches at the time, reference an exact object instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 37},
+					},
+				},
+				{
+					Rule:    NewAzurermDataSourceFilterSpecificityRule(),
+					Message: "`data.azurerm_key_vault_secret.password` does not set `version`, so it resolves to the latest version when the plan runs",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 43},
+					},
+				},
+				{
+					Rule:    NewAzurermDataSourceFilterSpecificityRule(),
+					Message: "`data.azurerm_shared_image_version.app` sets `name` to \"latest\", pin a fixed version instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 12, Column: 10},
+						End:      hcl.Pos{Line: 12, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured pinning",
+			Content: `
+data "azurerm_key_vault_secret" "password" {
+  name = "password"
+}
+
+data "azurerm_storage_blob" "script" {
+  name = "script.sh"
+}`,
+			Config: `
+rule "azurerm_data_source_filter_specificity" {
+  enabled = true
+
+  data_source "azurerm_key_vault_secret" {}
+
+  data_source "azurerm_storage_blob" {
+    require = ["version_id"]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDataSourceFilterSpecificityRule(),
+					Message: "`data.azurerm_storage_blob.script` does not set `version_id`, so it resolves to the latest version when the plan runs",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermDataSourceFilterSpecificityRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermDependsOnModulesDiscouragedRule checks that module calls containing many azurerm resources do not use depends_on
+type AzurermDependsOnModulesDiscouragedRule struct {
+	tflint.DefaultRule
+
+	defaultMinResources int
+}
+
+type azurermDependsOnModulesDiscouragedRuleConfig struct {
+	MinResources *int  `hclext:"min_resources,optional"`
+	Enforce      *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermDependsOnModulesDiscouragedRule returns new rule with default attributes
+func NewAzurermDependsOnModulesDiscouragedRule() *AzurermDependsOnModulesDiscouragedRule {
+	return &AzurermDependsOnModulesDiscouragedRule{
+		defaultMinResources: 3,
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermDependsOnModulesDiscouragedRule) Name() string {
+	return "azurerm_depends_on_modules_discouraged"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermDependsOnModulesDiscouragedRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermDependsOnModulesDiscouragedRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermDependsOnModulesDiscouragedRule) Link() string {
+	return ""
+}
+
+// Check checks module calls with `depends_on`, which makes every resource of the module wait for the dependencies
+// and defers its data sources to apply time. Local modules are only reported when they declare at least
+// `min_resources` azurerm resources, remote modules cannot be inspected and are always reported.
+func (r *AzurermDependsOnModulesDiscouragedRule) Check(runner tflint.Runner) error {
+	config := azurermDependsOnModulesDiscouragedRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	minResources := r.defaultMinResources
+	if config.MinResources != nil {
+		minResources = *config.MinResources
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "source"}, {Name: "depends_on"}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, module := range content.Blocks {
+		dependsOn, exists := module.Body.Attributes["depends_on"]
+		if !exists {
+			continue
+		}
+
+		detail := "its resources cannot be inspected"
+		if attribute, exists := module.Body.Attributes["source"]; exists {
+			if source, ok := literalString(attribute.Expr); ok && localModuleSource(source) {
+				dir := filepath.Join(filepath.Dir(module.DefRange.Filename), source)
+				count, err := countAzurermResources(dir)
+				if err != nil {
+					return err
+				}
+				if count < minResources {
+					continue
+				}
+				detail = fmt.Sprintf("it declares %d azurerm resources", count)
+				if count == 1 {
+					detail = "it declares an azurerm resource"
+				}
+			}
+		}
+
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`module.%s` uses `depends_on` and %s, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead", module.Labels[0], detail),
+			dependsOn.Expr.Range(),
+		)
+	}
+
+	return nil
+}
+
+// localModuleSource returns whether the module source is a path on the local filesystem
+func localModuleSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../")
+}
+
+// countAzurermResources counts the azurerm resources declared in the .tf and .tf.json files of the module directory.
+// A missing directory counts as no resources, since `terraform init` reports it.
+func countAzurermResources(dir string) (int, error) {
+	files, err := parseModuleFiles(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, file := range files {
+		for _, block := range fileBlocks(file, "resource", "type", "name") {
+			if strings.HasPrefix(block.Labels[0], "azurerm_") {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// parseModuleFiles parses the .tf and .tf.json files of a module directory, skipping invalid files,
+// which are reported when the module itself is linted
+func parseModuleFiles(dir string) ([]*hcl.File, error) {
+	filenames := []string{}
+	for _, pattern := range []string{"*.tf", "*.tf.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		filenames = append(filenames, matches...)
+	}
+
+	parser := hclparse.NewParser()
+	files := []*hcl.File{}
+	for _, filename := range filenames {
+		var file *hcl.File
+		var diags hcl.Diagnostics
+		if strings.HasSuffix(filename, ".json") {
+			file, diags = parser.ParseJSONFile(filename)
+		} else {
+			file, diags = parser.ParseHCLFile(filename)
+		}
+		if diags.HasErrors() {
+			continue
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDependsOnModulesDiscouraged(t *testing.T) {
+	dir := t.TempDir()
+	modules := map[string]string{
+		"network": `
+resource "azurerm_virtual_network" "main" {}
+resource "azurerm_subnet" "main" {}
+resource "azurerm_network_security_group" "main" {}`,
+		"identity": `
+resource "azurerm_user_assigned_identity" "main" {}`,
+	}
+	for name, content := range modules {
+		if err := os.MkdirAll(filepath.Join(dir, "modules", name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "modules", name, "main.tf"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	content := `
+module "network" {
+  source     = "./modules/network"
+  depends_on = [azurerm_resource_group.main]
+}
+
+module "identity" {
+  source     = "./modules/identity"
+  depends_on = [azurerm_resource_group.main]
+}
+
+module "aks" {
+  source     = "Azure/aks/azurerm"
+  depends_on = [module.network]
+}
+
+module "dns" {
+  source = "./modules/dns"
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Default",
+			Config: `
+rule "azurerm_depends_on_modules_discouraged" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDependsOnModulesDiscouragedRule(),
+					Message: "`module.network` uses `depends_on` and it declares 3 azurerm resources, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 4, Column: 16},
+						End:      hcl.Pos{Line: 4, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermDependsOnModulesDiscouragedRule(),
+					Message: "`module.aks` uses `depends_on` and its resources cannot be inspected, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 14, Column: 16},
+						End:      hcl.Pos{Line: 14, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "Minimum resources",
+			Config: `
+rule "azurerm_depends_on_modules_discouraged" {
+  enabled       = true
+  min_resources = 1
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDependsOnModulesDiscouragedRule(),
+					Message: "`module.network` uses `depends_on` and it declares 3 azurerm resources, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 4, Column: 16},
+						End:      hcl.Pos{Line: 4, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermDependsOnModulesDiscouragedRule(),
+					Message: "`module.identity` uses `depends_on` and it declares an azurerm resource, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 9, Column: 16},
+						End:      hcl.Pos{Line: 9, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermDependsOnModulesDiscouragedRule(),
+					Message: "`module.aks` uses `depends_on` and its resources cannot be inspected, which wait for the dependencies and defer data sources to apply time; reference the outputs the module needs instead",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 14, Column: 16},
+						End:      hcl.Pos{Line: 14, Column: 32},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermDependsOnModulesDiscouragedRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{filepath.Join(dir, "main.tf"): content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
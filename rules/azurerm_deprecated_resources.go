@@ -0,0 +1,127 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermDeprecatedResourcesRule checks for resource types and arguments deprecated by the azurerm provider
+type AzurermDeprecatedResourcesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermDeprecatedResourcesRuleConfig struct {
+	// ProviderVersion is the azurerm provider version in use. Deprecations of newer versions are not reported.
+	ProviderVersion string `hclext:"provider_version,optional"`
+	Enforce         *bool  `hclext:"enforce,optional"`
+}
+
+// NewAzurermDeprecatedResourcesRule returns new rule with default attributes
+func NewAzurermDeprecatedResourcesRule() *AzurermDeprecatedResourcesRule {
+	return &AzurermDeprecatedResourcesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermDeprecatedResourcesRule) Name() string {
+	return "azurerm_deprecated_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermDeprecatedResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermDeprecatedResourcesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermDeprecatedResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks resources against the deprecation table, so that deprecated resource types and arguments
+// are replaced before the next major provider version removes them
+func (r *AzurermDeprecatedResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermDeprecatedResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.ProviderVersion != "" {
+		if _, err := compareVersions(config.ProviderVersion, config.ProviderVersion); err != nil {
+			return fmt.Errorf("provider_version: %s", err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	deprecatedTypes := map[string]providerDeprecation{}
+	deprecatedArguments := map[string][]providerDeprecation{}
+	schema := &hclext.BodySchema{}
+	arguments := map[string]bool{}
+	for _, deprecation := range providerDeprecations {
+		if config.ProviderVersion != "" {
+			if cmp, _ := compareVersions(deprecation.Version, config.ProviderVersion); cmp > 0 {
+				continue
+			}
+		}
+		if deprecation.Argument == "" {
+			deprecatedTypes[deprecation.Resource] = deprecation
+			continue
+		}
+		deprecatedArguments[deprecation.Resource] = append(deprecatedArguments[deprecation.Resource], deprecation)
+		if !arguments[deprecation.Argument] {
+			arguments[deprecation.Argument] = true
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: deprecation.Argument})
+		}
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: schema},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range content.Blocks {
+		if deprecation, exists := deprecatedTypes[resource.Labels[0]]; exists {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` is deprecated since azurerm %s, use %s instead", resource.Labels[0], deprecation.Version, replacements(deprecation)),
+				resource.DefRange,
+			); err != nil {
+				return err
+			}
+		}
+
+		for _, deprecation := range deprecatedArguments[resource.Labels[0]] {
+			attribute, exists := resource.Body.Attributes[deprecation.Argument]
+			if !exists {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` of `%s.%s` is deprecated since azurerm %s, use %s instead", deprecation.Argument, resource.Labels[0], resource.Labels[1], deprecation.Version, replacements(deprecation)),
+				attribute.NameRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replacements returns the replacements of the deprecation for messages, e.g. "`a` or `b`"
+func replacements(deprecation providerDeprecation) string {
+	quoted := make([]string, len(deprecation.Replacements))
+	for i, replacement := range deprecation.Replacements {
+		quoted[i] = "`" + replacement + "`"
+	}
+	return strings.Join(quoted, " or ")
+}
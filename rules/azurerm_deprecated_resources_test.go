@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDeprecatedResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Deprecated resources and arguments",
+			Content: `
+resource "azurerm_app_service" "main" {
+  name = "app-main"
+}
+
+resource "azurerm_storage_account" "main" {
+  enable_https_traffic_only = true
+  min_tls_version           = "TLS1_2"
+}
+
+resource "azurerm_linux_web_app" "main" {
+  name = "app-main"
+}`,
+			Config: `
+rule "azurerm_deprecated_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedResourcesRule(),
+					Message: "`azurerm_app_service` is deprecated since azurerm 3.0.0, use `azurerm_linux_web_app` or `azurerm_windows_web_app` instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 38},
+					},
+				},
+				{
+					Rule:    NewAzurermDeprecatedResourcesRule(),
+					Message: "`enable_https_traffic_only` of `azurerm_storage_account.main` is deprecated since azurerm 4.0.0, use `https_traffic_only_enabled` instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "Provider version",
+			Content: `
+resource "azurerm_sql_server" "main" {
+  name = "sql-main"
+}
+
+resource "azurerm_storage_account" "main" {
+  enable_https_traffic_only = true
+}`,
+			Config: `
+rule "azurerm_deprecated_resources" {
+  enabled          = true
+  provider_version = "3.116.0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDeprecatedResourcesRule(),
+					Message: "`azurerm_sql_server` is deprecated since azurerm 3.0.0, use `azurerm_mssql_server` instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 37},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermDeprecatedResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermDiskExportAndSharedAccessDisabledRule checks that managed disks cannot be exported or accessed publicly
+type AzurermDiskExportAndSharedAccessDisabledRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermDiskExportAndSharedAccessDisabledRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermDiskExportAndSharedAccessDisabledRule returns new rule with default attributes
+func NewAzurermDiskExportAndSharedAccessDisabledRule() *AzurermDiskExportAndSharedAccessDisabledRule {
+	return &AzurermDiskExportAndSharedAccessDisabledRule{
+		resourceType: "azurerm_managed_disk",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermDiskExportAndSharedAccessDisabledRule) Name() string {
+	return "azurerm_disk_export_and_shared_access_disabled"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermDiskExportAndSharedAccessDisabledRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermDiskExportAndSharedAccessDisabledRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermDiskExportAndSharedAccessDisabledRule) Link() string {
+	return ""
+}
+
+// Check checks managed disks do not allow export from any network or public network access
+func (r *AzurermDiskExportAndSharedAccessDisabledRule) Check(runner tflint.Runner) error {
+	config := azurermDiskExportAndSharedAccessDisabledRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "network_access_policy"},
+			{Name: "public_network_access_enabled"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		if attribute, exists := resource.Body.Attributes["network_access_policy"]; exists {
+			var policy string
+			err := runner.EvaluateExpr(attribute.Expr, &policy, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if policy == "AllowAll" {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` allows the disk to be exported from any network, use \"AllowPrivate\" or \"DenyAll\"", resource.Labels[0], resource.Labels[1]),
+						attribute.Expr.Range(),
+					)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if attribute, exists := resource.Body.Attributes["public_network_access_enabled"]; exists {
+			var enabled bool
+			err := evaluateBool(runner, attribute.Expr, &enabled)
+			err = runner.EnsureNoError(err, func() error {
+				if enabled {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` enables public network access to the disk", resource.Labels[0], resource.Labels[1]),
+						attribute.Expr.Range(),
+					)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
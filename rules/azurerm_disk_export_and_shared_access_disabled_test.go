@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermDiskExportAndSharedAccessDisabled(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Export allowed from any network",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  network_access_policy = "AllowAll"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDiskExportAndSharedAccessDisabledRule(),
+					Message: "`azurerm_managed_disk.data` allows the disk to be exported from any network, use \"AllowPrivate\" or \"DenyAll\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 27},
+						End:      hcl.Pos{Line: 3, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "Export allowed from private endpoints",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  network_access_policy = "AllowPrivate"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Export denied",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  network_access_policy = "DenyAll"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Unknown network access policy",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  network_access_policy = data.azurerm_managed_disk.template.network_access_policy
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Public network access enabled",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  public_network_access_enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermDiskExportAndSharedAccessDisabledRule(),
+					Message: "`azurerm_managed_disk.data` enables public network access to the disk",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 35},
+						End:      hcl.Pos{Line: 3, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "Public network access disabled",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  public_network_access_enabled = false
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Unknown public network access",
+			Content: `
+resource "azurerm_managed_disk" "data" {
+  public_network_access_enabled = data.azurerm_managed_disk.template.public_network_access_enabled
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_disk_export_and_shared_access_disabled" {
+  enabled = true
+}`
+	rule := NewAzurermDiskExportAndSharedAccessDisabledRule()
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
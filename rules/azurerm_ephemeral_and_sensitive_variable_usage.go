@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermEphemeralAndSensitiveVariableUsageRule checks that variables feeding secret attributes are declared sensitive
+type AzurermEphemeralAndSensitiveVariableUsageRule struct {
+	tflint.DefaultRule
+
+	defaultAttributePatterns []string
+}
+
+type azurermEphemeralAndSensitiveVariableUsageRuleConfig struct {
+	AttributePatterns []string `hclext:"attribute_patterns,optional"`
+	SuggestEphemeral  bool     `hclext:"suggest_ephemeral,optional"`
+	Enforce           *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermEphemeralAndSensitiveVariableUsageRule returns new rule with default attributes
+func NewAzurermEphemeralAndSensitiveVariableUsageRule() *AzurermEphemeralAndSensitiveVariableUsageRule {
+	return &AzurermEphemeralAndSensitiveVariableUsageRule{
+		defaultAttributePatterns: []string{
+			`(?i)(password|secret|token|connection_?string|access_?key|account_?key|api_?key|private_key|sas_?|secure_)`,
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermEphemeralAndSensitiveVariableUsageRule) Name() string {
+	return "azurerm_ephemeral_and_sensitive_variable_usage"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermEphemeralAndSensitiveVariableUsageRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermEphemeralAndSensitiveVariableUsageRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermEphemeralAndSensitiveVariableUsageRule) Link() string {
+	return ""
+}
+
+// Check checks variables that flow into secret-like attributes of azurerm resources, directly or through
+// local values, are declared `sensitive = true`
+func (r *AzurermEphemeralAndSensitiveVariableUsageRule) Check(runner tflint.Runner) error {
+	config := azurermEphemeralAndSensitiveVariableUsageRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	sources := r.defaultAttributePatterns
+	if len(config.AttributePatterns) > 0 {
+		sources = config.AttributePatterns
+	}
+	patterns, err := compilePatterns("attribute_patterns", sources)
+	if err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	// Resource attributes have arbitrary names, so references are collected from the source
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	resources := hcl.Blocks{}
+	for _, name := range sortedFileNames(files) {
+		for _, block := range fileBlocks(files[name], "resource", "type", "name") {
+			if strings.HasPrefix(block.Labels[0], "azurerm_") {
+				resources = append(resources, block)
+			}
+		}
+	}
+
+	// feeds maps each variable to the first secret attribute it flows into
+	feeds := map[string]string{}
+	for _, resource := range resources {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+		walkSecretAttributes(resource.Body, address, patterns, func(expr hcl.Expression, path string) {
+			for _, variable := range variablesFeeding(expr, locals) {
+				if _, exists := feeds[variable]; !exists {
+					feeds[variable] = path
+				}
+			}
+		})
+	}
+	if len(feeds) == 0 {
+		return nil
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "sensitive"}, {Name: "ephemeral"}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range content.Blocks {
+		path, exists := feeds[variable.Labels[0]]
+		if !exists {
+			continue
+		}
+		sensitive := literalTrue(variable.Body, "sensitive")
+		ephemeral := literalTrue(variable.Body, "ephemeral")
+
+		switch {
+		case !sensitive && !ephemeral:
+			message := fmt.Sprintf("`var.%s` feeds the secret attribute `%s` but is not declared `sensitive = true`", variable.Labels[0], path)
+			if config.SuggestEphemeral {
+				message += ", or `ephemeral = true` if it is only passed to write-only attributes"
+			}
+			runner.EmitIssue(r, message, variable.DefRange)
+		case config.SuggestEphemeral && !ephemeral:
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`var.%s` feeds the secret attribute `%s`, consider declaring it `ephemeral = true` and passing it to a write-only attribute so it is not persisted in state", variable.Labels[0], path),
+				variable.DefRange,
+			)
+		}
+	}
+
+	return nil
+}
+
+// walkSecretAttributes calls proc with every attribute of the body and its nested blocks whose name matches any of the patterns.
+// Nested blocks are objects in JSON syntax, so the items of object values are walked as well.
+func walkSecretAttributes(body hcl.Body, path string, patterns []*regexp.Regexp, proc func(hcl.Expression, string)) {
+	attributes := blockAttributes(body)
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		walkSecretItems(attributes[name].Expr, path+"."+name, name, patterns, proc)
+	}
+
+	if native, ok := body.(*hclsyntax.Body); ok {
+		for _, block := range native.Blocks {
+			walkSecretAttributes(block.Body, path+"."+block.Type, patterns, proc)
+		}
+	}
+}
+
+// walkSecretItems calls proc with the expression if its name matches any of the patterns, or else walks into object and list items
+func walkSecretItems(expr hcl.Expression, path string, name string, patterns []*regexp.Regexp, proc func(hcl.Expression, string)) {
+	if matchesAny(patterns, name) {
+		proc(expr, path)
+		return
+	}
+	if items, diags := hcl.ExprList(expr); !diags.HasErrors() {
+		for _, item := range items {
+			walkSecretItems(item, path, name, patterns, proc)
+		}
+		return
+	}
+	if pairs, diags := hcl.ExprMap(expr); !diags.HasErrors() {
+		for _, pair := range pairs {
+			if key := settingKey(pair.Key); key != "" {
+				walkSecretItems(pair.Value, path+"."+key, key, patterns, proc)
+			}
+		}
+	}
+}
+
+// variablesFeeding returns the variables referenced by the expression, following local values transitively
+func variablesFeeding(expr hcl.Expression, locals map[string]*hcl.Attribute) []string {
+	variables := referencedValues(expr, "var")
+	visited := map[string]bool{}
+	pending := referencedValues(expr, "local")
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+
+		local, exists := locals[name]
+		if !exists {
+			continue
+		}
+		for _, variable := range referencedValues(local.Expr, "var") {
+			if !stringInSlice(variable, variables) {
+				variables = append(variables, variable)
+			}
+		}
+		pending = append(pending, referencedValues(local.Expr, "local")...)
+	}
+	return variables
+}
+
+// literalTrue returns whether the attribute is set to the literal `true`, as Terraform requires for variable flags
+func literalTrue(body *hclext.BodyContent, name string) bool {
+	attribute, exists := body.Attributes[name]
+	if !exists {
+		return false
+	}
+	val, diags := attribute.Expr.Value(nil)
+	if diags.HasErrors() || val.IsNull() || !val.Type().Equals(cty.Bool) {
+		return false
+	}
+	return val.True()
+}
@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermEphemeralAndSensitiveVariableUsage(t *testing.T) {
+	content := `
+variable "admin_password" {
+  type = string
+}
+
+variable "storage_key" {
+  type      = string
+  sensitive = true
+}
+
+variable "location" {
+  type = string
+}
+
+locals {
+  connection = "AccountKey=${local.key}"
+  key        = var.storage_key
+}
+
+resource "azurerm_mssql_server" "main" {
+  location                     = var.location
+  administrator_login_password = var.admin_password
+}
+
+resource "azurerm_container_group" "main" {
+  container {
+    secure_environment_variables = {
+      CONNECTION = local.connection
+    }
+  }
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Default",
+			Config: `
+rule "azurerm_ephemeral_and_sensitive_variable_usage" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+					Message: "`var.admin_password` feeds the secret attribute `azurerm_mssql_server.main.administrator_login_password` but is not declared `sensitive = true`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "Suggest ephemeral",
+			Config: `
+rule "azurerm_ephemeral_and_sensitive_variable_usage" {
+  enabled           = true
+  suggest_ephemeral = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+					Message: "`var.admin_password` feeds the secret attribute `azurerm_mssql_server.main.administrator_login_password` but is not declared `sensitive = true`, or `ephemeral = true` if it is only passed to write-only attributes",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 26},
+					},
+				},
+				{
+					Rule:    NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+					Message: "`var.storage_key` feeds the secret attribute `azurerm_container_group.main.container.secure_environment_variables`, consider declaring it `ephemeral = true` and passing it to a write-only attribute so it is not persisted in state",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 23},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermEphemeralAndSensitiveVariableUsageRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
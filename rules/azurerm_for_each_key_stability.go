@@ -0,0 +1,169 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermForEachKeyStabilityRule checks that `for_each` is keyed on values that do not change between plans
+type AzurermForEachKeyStabilityRule struct {
+	tflint.DefaultRule
+}
+
+type azurermForEachKeyStabilityRuleConfig struct {
+	// Heuristics are the checks to run, all of forEachKeyHeuristics by default
+	Heuristics []string `hclext:"heuristics,optional"`
+	Enforce    *bool    `hclext:"enforce,optional"`
+}
+
+// forEachKeyHeuristics are the kinds of unstable `for_each` keys the rule can check for
+var forEachKeyHeuristics = []string{
+	// range reports list indices created with range(), as in toset(range(length(var.subnets)))
+	"range",
+	// resource_attributes reports the attributes of managed resources, which may be unknown until apply
+	"resource_attributes",
+}
+
+// nonResourceRoots are the roots of references that do not refer to managed resources
+var nonResourceRoots = []string{"var", "local", "module", "data", "each", "count", "path", "terraform", "self"}
+
+// NewAzurermForEachKeyStabilityRule returns new rule with default attributes
+func NewAzurermForEachKeyStabilityRule() *AzurermForEachKeyStabilityRule {
+	return &AzurermForEachKeyStabilityRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermForEachKeyStabilityRule) Name() string {
+	return "azurerm_for_each_key_stability"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermForEachKeyStabilityRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermForEachKeyStabilityRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermForEachKeyStabilityRule) Link() string {
+	return ""
+}
+
+// Check checks the `for_each` of resources, data sources and modules for keys that are likely to change,
+// which destroys and recreates the instances whose keys changed. Iterating a resource with `for_each`,
+// such as `for_each = azurerm_subnet.main`, reuses its stable keys and is allowed.
+func (r *AzurermForEachKeyStabilityRule) Check(runner tflint.Runner) error {
+	config := azurermForEachKeyStabilityRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, heuristic := range config.Heuristics {
+		if !stringInSlice(heuristic, forEachKeyHeuristics) {
+			return fmt.Errorf("heuristics: unknown heuristic %q, expected one of %s", heuristic, quoteAll(forEachKeyHeuristics))
+		}
+	}
+	if config.Heuristics == nil {
+		config.Heuristics = forEachKeyHeuristics
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	forEach := &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "for_each"}}}
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: forEach},
+			{Type: "data", LabelNames: []string{"type", "name"}, Body: forEach},
+			{Type: "module", LabelNames: []string{"name"}, Body: forEach},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range content.Blocks {
+		attribute, exists := block.Body.Attributes["for_each"]
+		if !exists {
+			continue
+		}
+		expr, ok := attribute.Expr.(hclsyntax.Expression)
+		if !ok {
+			// JSON syntax expressions are strings and templates, which are not walked
+			continue
+		}
+		address := blockAddress(block)
+
+		for _, key := range unstableForEachKeys(expr, config.Heuristics) {
+			if err := runner.EmitIssue(r, fmt.Sprintf("`%s` keys `for_each` on %s", address, key.description), key.location); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// unstableForEachKey is a part of a `for_each` expression whose values are likely to change
+type unstableForEachKey struct {
+	description string
+	location    hcl.Range
+}
+
+// unstableForEachKeys returns the parts of the expression found by the heuristics in source order
+func unstableForEachKeys(expr hclsyntax.Expression, heuristics []string) []unstableForEachKey {
+	// Resources iterated as a whole, directly or as the collection of a for expression, keep their own keys
+	iterated := map[hclsyntax.Node]bool{expr: true}
+	// The symbols of for expressions are not resources
+	symbols := append([]string{}, nonResourceRoots...)
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		if forExpr, ok := node.(*hclsyntax.ForExpr); ok {
+			iterated[forExpr.CollExpr] = true
+			symbols = append(symbols, forExpr.KeyVar, forExpr.ValVar)
+		}
+		return nil
+	})
+
+	keys := []unstableForEachKey{}
+	hclsyntax.VisitAll(expr, func(node hclsyntax.Node) hcl.Diagnostics {
+		switch node := node.(type) {
+		case *hclsyntax.FunctionCallExpr:
+			if node.Name == "range" && stringInSlice("range", heuristics) {
+				keys = append(keys, unstableForEachKey{
+					description: "list indices from `range()`, so removing an element recreates the instances after it; key on a name instead",
+					location:    node.Range(),
+				})
+			}
+		case *hclsyntax.ScopeTraversalExpr:
+			if !stringInSlice("resource_attributes", heuristics) || len(node.Traversal) < 2 || stringInSlice(node.Traversal.RootName(), symbols) {
+				return nil
+			}
+			name, ok := node.Traversal[1].(hcl.TraverseAttr)
+			if !ok || (len(node.Traversal) == 2 && iterated[node]) {
+				return nil
+			}
+			keys = append(keys, unstableForEachKey{
+				description: fmt.Sprintf("attributes of `%s.%s`, which may be unknown until apply and recreate the instances when they change; key on values of the configuration instead", node.Traversal.RootName(), name.Name),
+				location:    node.Range(),
+			})
+		}
+		return nil
+	})
+	return keys
+}
+
+// blockAddress returns the address of a resource, data source or module block
+func blockAddress(block *hclext.Block) string {
+	switch block.Type {
+	case "data":
+		return "data." + block.Labels[0] + "." + block.Labels[1]
+	case "module":
+		return "module." + block.Labels[0]
+	default:
+		return block.Labels[0] + "." + block.Labels[1]
+	}
+}
@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermForEachKeyStability(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unstable keys",
+			Content: `
+resource "azurerm_subnet" "indexed" {
+  for_each = toset([for i in range(length(var.subnets)) : tostring(i)])
+}
+
+resource "azurerm_network_interface" "main" {
+  for_each = toset(azurerm_subnet.indexed[*].id)
+}
+
+resource "azurerm_subnet_network_security_group_association" "main" {
+  for_each  = azurerm_subnet.indexed
+  subnet_id = each.value.id
+}
+
+data "azurerm_subnet" "named" {
+  for_each = { for k, v in azurerm_subnet.indexed : k => v.name }
+}
+
+module "dns" {
+  for_each = azurerm_private_endpoint.main.private_dns_zone_configs
+}
+
+module "stable" {
+  for_each = var.zones
+}`,
+			Config: `
+rule "azurerm_for_each_key_stability" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermForEachKeyStabilityRule(),
+					Message: "`azurerm_subnet.indexed` keys `for_each` on list indices from `range()`, so removing an element recreates the instances after it; key on a name instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 30},
+						End:      hcl.Pos{Line: 3, Column: 56},
+					},
+				},
+				{
+					Rule:    NewAzurermForEachKeyStabilityRule(),
+					Message: "`azurerm_network_interface.main` keys `for_each` on attributes of `azurerm_subnet.indexed`, which may be unknown until apply and recreate the instances when they change; key on values of the configuration instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 20},
+						End:      hcl.Pos{Line: 7, Column: 42},
+					},
+				},
+				{
+					Rule:    NewAzurermForEachKeyStabilityRule(),
+					Message: "`module.dns` keys `for_each` on attributes of `azurerm_private_endpoint.main`, which may be unknown until apply and recreate the instances when they change; key on values of the configuration instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 20, Column: 14},
+						End:      hcl.Pos{Line: 20, Column: 68},
+					},
+				},
+			},
+		},
+		{
+			Name: "Selected heuristics",
+			Content: `
+resource "azurerm_subnet" "indexed" {
+  for_each = toset([for i in range(3) : tostring(i)])
+}
+
+resource "azurerm_network_interface" "main" {
+  for_each = toset(azurerm_subnet.indexed[*].id)
+}`,
+			Config: `
+rule "azurerm_for_each_key_stability" {
+  enabled    = true
+  heuristics = ["range"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermForEachKeyStabilityRule(),
+					Message: "`azurerm_subnet.indexed` keys `for_each` on list indices from `range()`, so removing an element recreates the instances after it; key on a name instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 30},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermForEachKeyStabilityRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,105 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermForbiddenResourcesRule checks that the module declares no resource or data source types
+// on the deny-list of its config
+type AzurermForbiddenResourcesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermForbiddenResourcesRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+
+	Forbidden []forbiddenType `hclext:"forbid,block"`
+}
+
+// forbiddenType forbids a resource type or a data source type, or the types matching a glob such as "azurerm_sql_*"
+type forbiddenType struct {
+	Resource   string `hclext:"resource,optional"`
+	DataSource string `hclext:"data_source,optional"`
+	// Message explains the policy in the issues, e.g. who to ask instead
+	Message string `hclext:"message,optional"`
+}
+
+// NewAzurermForbiddenResourcesRule returns new rule with default attributes
+func NewAzurermForbiddenResourcesRule() *AzurermForbiddenResourcesRule {
+	return &AzurermForbiddenResourcesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermForbiddenResourcesRule) Name() string {
+	return "azurerm_forbidden_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermForbiddenResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermForbiddenResourcesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermForbiddenResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks the resources and data sources of the module against the `forbid` blocks of the config,
+// so that types reserved to other teams can be blocked per repository without a dedicated rule
+func (r *AzurermForbiddenResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermForbiddenResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, forbidden := range config.Forbidden {
+		if (forbidden.Resource == "") == (forbidden.DataSource == "") {
+			return fmt.Errorf("forbid: set exactly one of `resource` and `data_source`")
+		}
+		if _, err := path.Match(forbidden.Resource+forbidden.DataSource, ""); err != nil {
+			return fmt.Errorf("forbid: invalid glob %q: %s", forbidden.Resource+forbidden.DataSource, err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: &hclext.BodySchema{}},
+			{Type: "data", LabelNames: []string{"type", "name"}, Body: &hclext.BodySchema{}},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, block := range content.Blocks {
+		for _, forbidden := range config.Forbidden {
+			glob, kind, address := forbidden.Resource, "resource", block.Labels[0]+"."+block.Labels[1]
+			if block.Type == "data" {
+				glob, kind, address = forbidden.DataSource, "data source", "data."+address
+			}
+			if !matchesGlob(block.Labels[0], []string{glob}) {
+				continue
+			}
+
+			message := fmt.Sprintf("`%s` is a forbidden %s type", address, kind)
+			if forbidden.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, forbidden.Message)
+			}
+			if err := runner.EmitIssue(r, message, block.DefRange); err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	return nil
+}
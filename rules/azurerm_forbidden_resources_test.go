@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermForbiddenResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Forbidden resources and data sources",
+			Content: `
+resource "azurerm_kubernetes_cluster" "main" {
+  name = "aks-platform"
+}
+
+resource "azurerm_sql_server" "legacy" {
+  name = "sql-legacy"
+}
+
+resource "azurerm_storage_account" "logs" {
+  name = "stlogs"
+}
+
+data "azurerm_key_vault_secret" "password" {
+  name = "password"
+}`,
+			Config: `
+rule "azurerm_forbidden_resources" {
+  enabled = true
+
+  forbid {
+    resource = "azurerm_kubernetes_cluster"
+    message  = "clusters are provided by the platform team"
+  }
+
+  forbid {
+    resource = "azurerm_sql_*"
+  }
+
+  forbid {
+    data_source = "azurerm_key_vault_secret"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermForbiddenResourcesRule(),
+					Message: "`azurerm_kubernetes_cluster.main` is a forbidden resource type: clusters are provided by the platform team",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermForbiddenResourcesRule(),
+					Message: "`azurerm_sql_server.legacy` is a forbidden resource type",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 39},
+					},
+				},
+				{
+					Rule:    NewAzurermForbiddenResourcesRule(),
+					Message: "`data.azurerm_key_vault_secret.password` is a forbidden data source type",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 14, Column: 1},
+						End:      hcl.Pos{Line: 14, Column: 43},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermForbiddenResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
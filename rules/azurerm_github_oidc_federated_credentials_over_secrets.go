@@ -0,0 +1,104 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermGithubOidcFederatedCredentialsOverSecretsRule checks that CI pipelines sign in with federated credentials
+type AzurermGithubOidcFederatedCredentialsOverSecretsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermGithubOidcFederatedCredentialsOverSecretsRuleConfig struct {
+	// NamePatterns replaces the default patterns matching names of secrets that are used by CI pipelines
+	NamePatterns []string `hclext:"name_patterns,optional"`
+	Enforce      *bool    `hclext:"enforce,optional"`
+}
+
+// defaultCIPatterns match names such as "github_actions", "deploy-pipeline" or "ci"
+var defaultCIPatterns = []string{
+	`(?i)github|gitlab|bitbucket|jenkins|circleci|azdo|devops`,
+	`(?i)pipeline|workflow|actions|deploy|cicd`,
+	`(?i)(^|[-_. ])(ci|cd|gha)($|[-_. ])`,
+}
+
+// clientSecretResourceTypes are the resources creating client secrets of applications and service principals
+var clientSecretResourceTypes = []string{"azuread_application_password", "azuread_service_principal_password"}
+
+// NewAzurermGithubOidcFederatedCredentialsOverSecretsRule returns new rule with default attributes
+func NewAzurermGithubOidcFederatedCredentialsOverSecretsRule() *AzurermGithubOidcFederatedCredentialsOverSecretsRule {
+	return &AzurermGithubOidcFederatedCredentialsOverSecretsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermGithubOidcFederatedCredentialsOverSecretsRule) Name() string {
+	return "azurerm_github_oidc_federated_credentials_over_secrets"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermGithubOidcFederatedCredentialsOverSecretsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermGithubOidcFederatedCredentialsOverSecretsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermGithubOidcFederatedCredentialsOverSecretsRule) Link() string {
+	return ""
+}
+
+// Check checks for client secrets whose resource name or display name suggests a CI pipeline uses them.
+// Pipelines such as GitHub Actions can sign in with a federated identity credential instead, so that
+// no long-lived secret has to be stored in the CI system and rotated.
+func (r *AzurermGithubOidcFederatedCredentialsOverSecretsRule) Check(runner tflint.Runner) error {
+	config := azurermGithubOidcFederatedCredentialsOverSecretsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	sources := defaultCIPatterns
+	if len(config.NamePatterns) > 0 {
+		sources = config.NamePatterns
+	}
+	patterns, err := compilePatterns("name_patterns", sources)
+	if err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, resourceType := range clientSecretResourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "display_name"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			name := resource.Labels[1]
+			if attribute, exists := resource.Body.Attributes["display_name"]; exists && !matchesAny(patterns, name) {
+				if displayName, ok := literalString(attribute.Expr); ok {
+					name = displayName
+				}
+			}
+			if !matchesAny(patterns, name) {
+				continue
+			}
+
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` creates a client secret for \"%s\", which looks like a CI pipeline, use an `azuread_application_federated_identity_credential` so that the pipeline signs in with OIDC instead", resource.Labels[0], resource.Labels[1], name),
+				resource.DefRange,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
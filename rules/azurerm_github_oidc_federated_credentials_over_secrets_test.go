@@ -0,0 +1,98 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermGithubOidcFederatedCredentialsOverSecrets(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "CI secrets",
+			Content: `
+resource "azuread_application_password" "github_actions" {
+  application_object_id = azuread_application.deployer.object_id
+}
+
+resource "azuread_service_principal_password" "main" {
+  service_principal_id = azuread_service_principal.deployer.object_id
+  display_name         = "terraform-ci"
+}
+
+resource "azuread_application_password" "grafana" {
+  application_object_id = azuread_application.grafana.object_id
+  display_name          = "Grafana datasource"
+}`,
+			Config: `
+rule "azurerm_github_oidc_federated_credentials_over_secrets" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermGithubOidcFederatedCredentialsOverSecretsRule(),
+					Message: "`azuread_application_password.github_actions` creates a client secret for \"github_actions\", which looks like a CI pipeline, use an `azuread_application_federated_identity_credential` so that the pipeline signs in with OIDC instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 57},
+					},
+				},
+				{
+					Rule:    NewAzurermGithubOidcFederatedCredentialsOverSecretsRule(),
+					Message: "`azuread_service_principal_password.main` creates a client secret for \"terraform-ci\", which looks like a CI pipeline, use an `azuread_application_federated_identity_credential` so that the pipeline signs in with OIDC instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom patterns",
+			Content: `
+resource "azuread_application_password" "github_actions" {
+  application_object_id = azuread_application.deployer.object_id
+}
+
+resource "azuread_application_password" "octopus" {
+  application_object_id = azuread_application.octopus.object_id
+}`,
+			Config: `
+rule "azurerm_github_oidc_federated_credentials_over_secrets" {
+  enabled       = true
+  name_patterns = ["(?i)octopus"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermGithubOidcFederatedCredentialsOverSecretsRule(),
+					Message: "`azuread_application_password.octopus` creates a client secret for \"octopus\", which looks like a CI pipeline, use an `azuread_application_federated_identity_credential` so that the pipeline signs in with OIDC instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 50},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermGithubOidcFederatedCredentialsOverSecretsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermImageSourceAllowlistRule checks that virtual machines are built from approved images
+type AzurermImageSourceAllowlistRule struct {
+	tflint.DefaultRule
+
+	// Resource types and the block referencing a marketplace image
+	imageReferenceBlocks map[string]string
+}
+
+type azurermImageSourceAllowlistRuleConfig struct {
+	Images    []allowedImage `hclext:"image,block"`
+	Galleries []string       `hclext:"galleries,optional"`
+	Enforce   *bool          `hclext:"enforce,optional"`
+}
+
+// allowedImage approves a marketplace publisher, optionally limited to a single offer
+type allowedImage struct {
+	Publisher string `hclext:"publisher"`
+	Offer     string `hclext:"offer,optional"`
+}
+
+var galleryImageIDPattern = regexp.MustCompile(`(?i)/galleries/([^/]+)`)
+
+// NewAzurermImageSourceAllowlistRule returns new rule with default attributes
+func NewAzurermImageSourceAllowlistRule() *AzurermImageSourceAllowlistRule {
+	return &AzurermImageSourceAllowlistRule{
+		imageReferenceBlocks: map[string]string{
+			"azurerm_linux_virtual_machine":                  "source_image_reference",
+			"azurerm_windows_virtual_machine":                "source_image_reference",
+			"azurerm_linux_virtual_machine_scale_set":        "source_image_reference",
+			"azurerm_windows_virtual_machine_scale_set":      "source_image_reference",
+			"azurerm_orchestrated_virtual_machine_scale_set": "source_image_reference",
+			"azurerm_virtual_machine":                        "storage_image_reference",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermImageSourceAllowlistRule) Name() string {
+	return "azurerm_image_source_allowlist"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermImageSourceAllowlistRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermImageSourceAllowlistRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermImageSourceAllowlistRule) Link() string {
+	return ""
+}
+
+// Check checks marketplace images and gallery image IDs against the allowlist
+func (r *AzurermImageSourceAllowlistRule) Check(runner tflint.Runner) error {
+	config := azurermImageSourceAllowlistRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if len(config.Images) == 0 && len(config.Galleries) == 0 {
+		return fmt.Errorf("neither `image` nor `galleries` is set for the `%s` rule in .tflint.hcl or the base config", r.Name())
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, resourceType := range sortedKeys(r.imageReferenceBlocks) {
+		blockType := r.imageReferenceBlocks[resourceType]
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "source_image_id"}},
+			Blocks: []hclext.BlockSchema{
+				{
+					Type: blockType,
+					Body: &hclext.BodySchema{
+						// `id` is only available on the legacy storage_image_reference block
+						Attributes: []hclext.AttributeSchema{{Name: "publisher"}, {Name: "offer"}, {Name: "id"}},
+					},
+				},
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if attribute, exists := resource.Body.Attributes["source_image_id"]; exists {
+				if err := r.checkImageID(runner, config, attribute); err != nil {
+					return err
+				}
+			}
+
+			for _, reference := range resource.Body.Blocks {
+				if attribute, exists := reference.Body.Attributes["id"]; exists {
+					if err := r.checkImageID(runner, config, attribute); err != nil {
+						return err
+					}
+				}
+				if err := r.checkMarketplaceImage(runner, config, reference); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *AzurermImageSourceAllowlistRule) checkMarketplaceImage(runner tflint.Runner, config azurermImageSourceAllowlistRuleConfig, reference *hclext.Block) error {
+	publisherAttribute, publisherExists := reference.Body.Attributes["publisher"]
+	offerAttribute, offerExists := reference.Body.Attributes["offer"]
+	if !publisherExists || !offerExists {
+		return nil
+	}
+
+	var publisher, offer string
+	err := runner.EvaluateExpr(publisherAttribute.Expr, &publisher, nil)
+	return runner.EnsureNoError(err, func() error {
+		err := runner.EvaluateExpr(offerAttribute.Expr, &offer, nil)
+		return runner.EnsureNoError(err, func() error {
+			publishers := make([]string, 0, len(config.Images))
+			for _, image := range config.Images {
+				if strings.EqualFold(image.Publisher, publisher) && (image.Offer == "" || strings.EqualFold(image.Offer, offer)) {
+					return nil
+				}
+				publishers = append(publishers, image.Publisher)
+			}
+
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("The image \"%s/%s\" is not from an approved publisher and offer.%s", publisher, offer, didYouMean(publisher, publishers)),
+				reference.DefRange,
+			)
+			return nil
+		})
+	})
+}
+
+func (r *AzurermImageSourceAllowlistRule) checkImageID(runner tflint.Runner, config azurermImageSourceAllowlistRuleConfig, attribute *hclext.Attribute) error {
+	var id string
+	err := runner.EvaluateExpr(attribute.Expr, &id, nil)
+	return runner.EnsureNoError(err, func() error {
+		match := galleryImageIDPattern.FindStringSubmatch(id)
+		if match == nil {
+			runner.EmitIssue(r, fmt.Sprintf("The image \"%s\" is not from a Shared Image Gallery", id), attribute.Expr.Range())
+			return nil
+		}
+
+		for _, gallery := range config.Galleries {
+			if strings.EqualFold(gallery, match[1]) {
+				return nil
+			}
+		}
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("The image is from the gallery \"%s\", which is not an approved Shared Image Gallery.%s", match[1], didYouMean(match[1], config.Galleries)),
+			attribute.Expr.Range(),
+		)
+		return nil
+	})
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermImageSourceAllowlist(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unapproved marketplace offer",
+			Content: `
+resource "azurerm_linux_virtual_machine" "vm" {
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "18.04-LTS"
+    version   = "latest"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermImageSourceAllowlistRule(),
+					Message: "The image \"Canonical/UbuntuServer\" is not from an approved publisher and offer.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "Image from an unapproved gallery",
+			Content: `
+resource "azurerm_windows_virtual_machine_scale_set" "vmss" {
+  source_image_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Compute/galleries/sandbox/images/win/versions/1.0.0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermImageSourceAllowlistRule(),
+					Message: "The image is from the gallery \"sandbox\", which is not an approved Shared Image Gallery.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 21},
+						End:      hcl.Pos{Line: 3, Column: 164},
+					},
+				},
+			},
+		},
+		{
+			Name: "Approved images",
+			Content: `
+resource "azurerm_linux_virtual_machine" "vm" {
+  source_image_reference {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "latest"
+  }
+}
+
+resource "azurerm_virtual_machine" "legacy" {
+  storage_image_reference {
+    id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg/providers/Microsoft.Compute/galleries/golden/images/linux"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_image_source_allowlist" {
+  enabled   = true
+  galleries = ["golden"]
+
+  image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+  }
+}`
+	rule := NewAzurermImageSourceAllowlistRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermKeyVaultMissingProtectionRule checks that key vaults enable purge protection and keep deleted vaults
+// for long enough
+type AzurermKeyVaultMissingProtectionRule struct {
+	tflint.DefaultRule
+}
+
+type azurermKeyVaultMissingProtectionRuleConfig struct {
+	// MinSoftDeleteRetentionDays is the fewest days deleted vaults may be kept for, 90 by default
+	MinSoftDeleteRetentionDays int   `hclext:"min_soft_delete_retention_days,optional"`
+	Enforce                    *bool `hclext:"enforce,optional"`
+}
+
+// softDeleteRetentionDays are the bounds Azure allows for `soft_delete_retention_days`, whose default is the maximum
+const (
+	minSoftDeleteRetentionDays = 7
+	maxSoftDeleteRetentionDays = 90
+)
+
+// NewAzurermKeyVaultMissingProtectionRule returns new rule with default attributes
+func NewAzurermKeyVaultMissingProtectionRule() *AzurermKeyVaultMissingProtectionRule {
+	return &AzurermKeyVaultMissingProtectionRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermKeyVaultMissingProtectionRule) Name() string {
+	return "azurerm_key_vault_missing_protection"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermKeyVaultMissingProtectionRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermKeyVaultMissingProtectionRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermKeyVaultMissingProtectionRule) Link() string {
+	return ""
+}
+
+// Check checks key vaults without `purge_protection_enabled = true`, whose deleted keys and secrets can be purged
+// before they are recovered, or with `soft_delete_retention_days` below the configured minimum
+func (r *AzurermKeyVaultMissingProtectionRule) Check(runner tflint.Runner) error {
+	config := azurermKeyVaultMissingProtectionRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.MinSoftDeleteRetentionDays == 0 {
+		config.MinSoftDeleteRetentionDays = maxSoftDeleteRetentionDays
+	}
+	if config.MinSoftDeleteRetentionDays < minSoftDeleteRetentionDays || config.MinSoftDeleteRetentionDays > maxSoftDeleteRetentionDays {
+		return fmt.Errorf("min_soft_delete_retention_days: %d is out of range, expected %d to %d days", config.MinSoftDeleteRetentionDays, minSoftDeleteRetentionDays, maxSoftDeleteRetentionDays)
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent("azurerm_key_vault", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "purge_protection_enabled"},
+			{Name: "soft_delete_retention_days"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+
+		// Purge protection is disabled by default
+		err := evaluateOptionalBool(runner, resource.Body, "purge_protection_enabled", false, func(enabled bool) error {
+			if enabled {
+				return nil
+			}
+			issueRange := resource.DefRange
+			if attribute, exists := resource.Body.Attributes["purge_protection_enabled"]; exists {
+				issueRange = attribute.Expr.Range()
+			}
+			return runner.EmitIssue(r, fmt.Sprintf("`%s` does not set `purge_protection_enabled = true`, so deleted keys and secrets can be purged before they are recovered", address), issueRange)
+		})
+		if err != nil {
+			return err
+		}
+
+		attribute, exists := resource.Body.Attributes["soft_delete_retention_days"]
+		if !exists {
+			continue
+		}
+		var days int
+		err = runner.EvaluateExpr(attribute.Expr, &days, nil)
+		err = runner.EnsureNoError(err, func() error {
+			if days >= config.MinSoftDeleteRetentionDays {
+				return nil
+			}
+			return runner.EmitIssue(r, fmt.Sprintf("`%s` keeps deleted vaults for %d days, fewer than the minimum of %d", address, days, config.MinSoftDeleteRetentionDays), attribute.Expr.Range())
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermKeyVaultMissingProtection(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unprotected key vaults",
+			Content: `
+variable "retention_days" {
+  default = 7
+}
+
+variable "purge_protection" {
+  default = true
+}
+
+resource "azurerm_key_vault" "defaults" {
+  name = "kv-defaults"
+}
+
+resource "azurerm_key_vault" "disabled" {
+  purge_protection_enabled   = false
+  soft_delete_retention_days = var.retention_days
+}
+
+resource "azurerm_key_vault" "protected" {
+  purge_protection_enabled   = var.purge_protection
+  soft_delete_retention_days = 90
+}`,
+			Config: `
+rule "azurerm_key_vault_missing_protection" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyVaultMissingProtectionRule(),
+					Message: "`azurerm_key_vault.defaults` does not set `purge_protection_enabled = true`, so deleted keys and secrets can be purged before they are recovered",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 40},
+					},
+				},
+				{
+					Rule:    NewAzurermKeyVaultMissingProtectionRule(),
+					Message: "`azurerm_key_vault.disabled` does not set `purge_protection_enabled = true`, so deleted keys and secrets can be purged before they are recovered",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 15, Column: 32},
+						End:      hcl.Pos{Line: 15, Column: 37},
+					},
+				},
+				{
+					Rule:    NewAzurermKeyVaultMissingProtectionRule(),
+					Message: "`azurerm_key_vault.disabled` keeps deleted vaults for 7 days, fewer than the minimum of 90",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 16, Column: 32},
+						End:      hcl.Pos{Line: 16, Column: 50},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom minimum",
+			Content: `
+resource "azurerm_key_vault" "dev" {
+  purge_protection_enabled   = true
+  soft_delete_retention_days = 14
+}`,
+			Config: `
+rule "azurerm_key_vault_missing_protection" {
+  enabled                        = true
+  min_soft_delete_retention_days = 30
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyVaultMissingProtectionRule(),
+					Message: "`azurerm_key_vault.dev` keeps deleted vaults for 14 days, fewer than the minimum of 30",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 32},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermKeyVaultMissingProtectionRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,136 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermKeyvaultKeyRotationPolicyRule checks that key vault keys are rotated automatically
+type AzurermKeyvaultKeyRotationPolicyRule struct {
+	tflint.DefaultRule
+
+	resourceType    string
+	maxRotationDays int
+}
+
+type azurermKeyvaultKeyRotationPolicyRuleConfig struct {
+	MaxRotationDays *int  `hclext:"max_rotation_days,optional"`
+	Enforce         *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermKeyvaultKeyRotationPolicyRule returns new rule with default attributes
+func NewAzurermKeyvaultKeyRotationPolicyRule() *AzurermKeyvaultKeyRotationPolicyRule {
+	return &AzurermKeyvaultKeyRotationPolicyRule{
+		resourceType:    "azurerm_key_vault_key",
+		maxRotationDays: 365,
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermKeyvaultKeyRotationPolicyRule) Name() string {
+	return "azurerm_keyvault_key_rotation_policy"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermKeyvaultKeyRotationPolicyRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermKeyvaultKeyRotationPolicyRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermKeyvaultKeyRotationPolicyRule) Link() string {
+	return ""
+}
+
+// Check checks key vault keys have a rotation policy within the maximum rotation period
+func (r *AzurermKeyvaultKeyRotationPolicyRule) Check(runner tflint.Runner) error {
+	config := azurermKeyvaultKeyRotationPolicyRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	maxRotationDays := r.maxRotationDays
+	if config.MaxRotationDays != nil {
+		maxRotationDays = *config.MaxRotationDays
+	}
+	if maxRotationDays <= 0 {
+		return fmt.Errorf("max_rotation_days: %d is out of range, expected a positive number of days", maxRotationDays)
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "rotation_policy",
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "automatic",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "time_after_creation"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		policies := resource.Body.Blocks.ByType()["rotation_policy"]
+		if len(policies) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` has no `rotation_policy` block, so the key is never rotated", resource.Labels[0], resource.Labels[1]),
+				resource.DefRange,
+			)
+			continue
+		}
+
+		for _, policy := range policies {
+			automatic := policy.Body.Blocks.ByType()["automatic"]
+			if len(automatic) == 0 {
+				runner.EmitIssue(r, "`rotation_policy` has no `automatic` block, so the key is never rotated", policy.DefRange)
+				continue
+			}
+
+			for _, block := range automatic {
+				attribute, exists := block.Body.Attributes["time_after_creation"]
+				if !exists {
+					continue
+				}
+
+				var duration string
+				err := runner.EvaluateExpr(attribute.Expr, &duration, nil)
+				err = runner.EnsureNoError(err, func() error {
+					days, err := iso8601DurationDays(duration)
+					if err != nil {
+						runner.EmitIssue(r, fmt.Sprintf("`time_after_creation` is not a valid ISO 8601 duration: %q", duration), attribute.Expr.Range())
+						return nil
+					}
+					if days > maxRotationDays {
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`time_after_creation` rotates the key after %d days, which is longer than the maximum of %d days", days, maxRotationDays),
+							attribute.Expr.Range(),
+						)
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
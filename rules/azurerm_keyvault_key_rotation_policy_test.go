@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermKeyvaultKeyRotationPolicy(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "No rotation policy",
+			Content: `
+resource "azurerm_key_vault_key" "key" {
+  name = "key"
+}`,
+			Config: `
+rule "azurerm_keyvault_key_rotation_policy" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultKeyRotationPolicyRule(),
+					Message: "`azurerm_key_vault_key.key` has no `rotation_policy` block, so the key is never rotated",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "Rotation period above the configured maximum",
+			Content: `
+resource "azurerm_key_vault_key" "key" {
+  rotation_policy {
+    automatic {
+      time_after_creation = "P1Y"
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_key_rotation_policy" {
+  enabled = true
+  max_rotation_days = 90
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultKeyRotationPolicyRule(),
+					Message: "`time_after_creation` rotates the key after 365 days, which is longer than the maximum of 90 days",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 29},
+						End:      hcl.Pos{Line: 5, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "Rotation policy without automatic rotation",
+			Content: `
+resource "azurerm_key_vault_key" "key" {
+  rotation_policy {
+    expire_after = "P2Y"
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_key_rotation_policy" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultKeyRotationPolicyRule(),
+					Message: "`rotation_policy` has no `automatic` block, so the key is never rotated",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "Rotation period within the default maximum",
+			Content: `
+resource "azurerm_key_vault_key" "key" {
+  rotation_policy {
+    automatic {
+      time_after_creation = "P90D"
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_key_rotation_policy" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermKeyvaultKeyRotationPolicyRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermKeyvaultKeyRotationPolicy_InvalidMaxRotationDays(t *testing.T) {
+	for _, days := range []string{"0", "-30"} {
+		runner := helper.TestRunner(t, map[string]string{
+			"module.tf": "",
+			".tflint.hcl": `
+rule "azurerm_keyvault_key_rotation_policy" {
+  enabled           = true
+  max_rotation_days = ` + days + `
+}`,
+		})
+
+		err := NewAzurermKeyvaultKeyRotationPolicyRule().Check(runner)
+		if expected := "max_rotation_days: " + days + " is out of range, expected a positive number of days"; err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	}
+}
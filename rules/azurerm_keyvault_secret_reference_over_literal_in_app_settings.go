@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule checks that app settings reference secrets from Key Vault
+type AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule struct {
+	tflint.DefaultRule
+
+	resourceTypes      []string
+	defaultKeyPatterns []string
+}
+
+type azurermKeyvaultSecretReferenceOverLiteralInAppSettingsRuleConfig struct {
+	KeyPatterns []string `hclext:"key_patterns,optional"`
+	Enforce     *bool    `hclext:"enforce,optional"`
+}
+
+// keyVaultReferencePrefix starts an App Service Key Vault reference, e.g. "@Microsoft.KeyVault(SecretUri=...)"
+const keyVaultReferencePrefix = "@Microsoft.KeyVault("
+
+// NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule returns new rule with default attributes
+func NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule() *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule {
+	return &AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule{
+		resourceTypes: []string{
+			"azurerm_linux_web_app",
+			"azurerm_linux_web_app_slot",
+			"azurerm_windows_web_app",
+			"azurerm_windows_web_app_slot",
+			"azurerm_linux_function_app",
+			"azurerm_linux_function_app_slot",
+			"azurerm_windows_function_app",
+			"azurerm_windows_function_app_slot",
+			"azurerm_app_service",
+			"azurerm_app_service_slot",
+			"azurerm_function_app",
+			"azurerm_function_app_slot",
+		},
+		defaultKeyPatterns: []string{
+			`(?i)(password|passwd|secret|token|connection_?string|access_?key|account_?key|api_?key)`,
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule) Name() string {
+	return "azurerm_keyvault_secret_reference_over_literal_in_app_settings"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule) Link() string {
+	return ""
+}
+
+// Check checks app settings with secret-like names are not set to literal values
+func (r *AzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule) Check(runner tflint.Runner) error {
+	config := azurermKeyvaultSecretReferenceOverLiteralInAppSettingsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	sources := r.defaultKeyPatterns
+	if len(config.KeyPatterns) > 0 {
+		sources = config.KeyPatterns
+	}
+	patterns, err := compilePatterns("key_patterns", sources)
+	if err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, resourceType := range r.resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "app_settings"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes["app_settings"]
+			if !exists {
+				continue
+			}
+			// Only map literals are inspected, settings built from variables or functions are not literal secrets
+			pairs, diags := hcl.ExprMap(attribute.Expr)
+			if diags.HasErrors() {
+				continue
+			}
+
+			for _, pair := range pairs {
+				key := settingKey(pair.Key)
+				if key == "" || !matchesAny(patterns, key) {
+					continue
+				}
+
+				value, ok := literalString(pair.Value)
+				if !ok || value == "" || strings.HasPrefix(value, keyVaultReferencePrefix) {
+					continue
+				}
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` sets the `%s` app setting to a literal value, store it in Key Vault and use \"%sSecretUri=...)\" instead", resource.Labels[0], resource.Labels[1], key, keyVaultReferencePrefix),
+					pair.Value.Range(),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// settingKey returns the static name of a map key, or an empty string if it is computed
+func settingKey(expr hcl.Expression) string {
+	if keyword := hcl.ExprAsKeyword(expr); keyword != "" {
+		return keyword
+	}
+	value, ok := literalString(expr)
+	if !ok {
+		return ""
+	}
+	return value
+}
+
+// literalString returns the value of an expression that is a string without any references
+func literalString(expr hcl.Expression) (string, bool) {
+	if len(expr.Variables()) > 0 {
+		return "", false
+	}
+	value, diags := expr.Value(nil)
+	if diags.HasErrors() || !value.IsKnown() || value.IsNull() || value.Type() != cty.String {
+		return "", false
+	}
+	return value.AsString(), true
+}
+
+// matchesAny returns whether any of the patterns matches the value
+func matchesAny(patterns []*regexp.Regexp, value string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
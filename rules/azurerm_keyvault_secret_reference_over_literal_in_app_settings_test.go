@@ -0,0 +1,93 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermKeyvaultSecretReferenceOverLiteralInAppSettings(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Literal secrets",
+			Content: `
+resource "azurerm_linux_web_app" "main" {
+  app_settings = {
+    DB_PASSWORD          = "hunter2"
+    "Storage:AccessKey"  = "abc123=="
+    API_TOKEN            = "@Microsoft.KeyVault(SecretUri=https://kv.vault.azure.net/secrets/token/)"
+    SERVICEBUS_SECRET    = var.servicebus_secret
+    WEBSITE_RUN_FROM_ZIP = "1"
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_secret_reference_over_literal_in_app_settings" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+					Message: "`azurerm_linux_web_app.main` sets the `DB_PASSWORD` app setting to a literal value, store it in Key Vault and use \"@Microsoft.KeyVault(SecretUri=...)\" instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 28},
+						End:      hcl.Pos{Line: 4, Column: 37},
+					},
+				},
+				{
+					Rule:    NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+					Message: "`azurerm_linux_web_app.main` sets the `Storage:AccessKey` app setting to a literal value, store it in Key Vault and use \"@Microsoft.KeyVault(SecretUri=...)\" instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 28},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured key patterns",
+			Content: `
+resource "azurerm_windows_function_app" "main" {
+  app_settings = {
+    DB_PASSWORD = "hunter2"
+    LICENSE     = "XXXX-YYYY"
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_secret_reference_over_literal_in_app_settings" {
+  enabled      = true
+  key_patterns = ["^LICENSE$"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+					Message: "`azurerm_windows_function_app.main` sets the `LICENSE` app setting to a literal value, store it in Key Vault and use \"@Microsoft.KeyVault(SecretUri=...)\" instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 19},
+						End:      hcl.Pos{Line: 5, Column: 30},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,126 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermKubeConfigNotWrittenToLocalFileRule checks that kube configs and other credentials are not written to files
+type AzurermKubeConfigNotWrittenToLocalFileRule struct {
+	tflint.DefaultRule
+
+	// contentAttributes maps the file resource types to their attributes holding the content of the file
+	contentAttributes map[string][]string
+}
+
+type azurermKubeConfigNotWrittenToLocalFileRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermKubeConfigNotWrittenToLocalFileRule returns new rule with default attributes
+func NewAzurermKubeConfigNotWrittenToLocalFileRule() *AzurermKubeConfigNotWrittenToLocalFileRule {
+	return &AzurermKubeConfigNotWrittenToLocalFileRule{
+		contentAttributes: map[string][]string{
+			"local_file":           {"content", "content_base64", "sensitive_content"},
+			"local_sensitive_file": {"content", "content_base64"},
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermKubeConfigNotWrittenToLocalFileRule) Name() string {
+	return "azurerm_kube_config_not_written_to_local_file"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermKubeConfigNotWrittenToLocalFileRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermKubeConfigNotWrittenToLocalFileRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermKubeConfigNotWrittenToLocalFileRule) Link() string {
+	return ""
+}
+
+// Check checks `local_file` and `local_sensitive_file` resources whose content references the kube config of a cluster
+// or another attribute of secretAttributes, directly or through local values. The files are left on the machines
+// running Terraform, such as shared CI agents, where anyone with access can use the credentials.
+func (r *AzurermKubeConfigNotWrittenToLocalFileRule) Check(runner tflint.Runner) error {
+	config := azurermKubeConfigNotWrittenToLocalFileRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range []string{"local_file", "local_sensitive_file"} {
+		schema := &hclext.BodySchema{}
+		for _, name := range r.contentAttributes[resourceType] {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+		}
+		resources, err := runner.GetResourceContent(resourceType, schema, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			for _, name := range r.contentAttributes[resourceType] {
+				attribute, exists := resource.Body.Attributes[name]
+				if !exists {
+					continue
+				}
+				for _, secret := range referencedSecrets(attribute.Expr, locals, 0) {
+					if err := runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` writes `%s` of `%s` to a file, where it can be read by anyone with access to the machine running Terraform", resource.Labels[0], resource.Labels[1], secret.attribute, secret.address),
+						attribute.Expr.Range(),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// secretReference is a reference to an attribute of secretAttributes
+type secretReference struct {
+	address   string
+	attribute string
+}
+
+// referencedSecrets returns the secret attributes referenced by the expression, following local values
+func referencedSecrets(expr hcl.Expression, locals map[string]*hcl.Attribute, depth int) []secretReference {
+	secrets := []secretReference{}
+	if depth > maxLocalDepth {
+		return secrets
+	}
+
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == "local" {
+			if value, ok := localValue(traversal, locals); ok {
+				secrets = append(secrets, referencedSecrets(value, locals, depth+1)...)
+			}
+			continue
+		}
+		address, attribute := referencedAttribute(traversal)
+		if attribute != "" && stringInSlice(attribute, secretAttributes[addressType(address)]) {
+			secrets = append(secrets, secretReference{address: address, attribute: attribute})
+		}
+	}
+	return secrets
+}
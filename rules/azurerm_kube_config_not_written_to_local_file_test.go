@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermKubeConfigNotWrittenToLocalFile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Credentials written to files",
+			Content: `
+locals {
+  kube_config = azurerm_kubernetes_cluster.main.kube_admin_config_raw
+}
+
+resource "local_file" "kube_config" {
+  filename = "${path.module}/kubeconfig"
+  content  = azurerm_kubernetes_cluster.main.kube_config_raw
+}
+
+resource "local_sensitive_file" "admin" {
+  filename = "${path.module}/admin.kubeconfig"
+  content  = local.kube_config
+}
+
+resource "local_file" "storage" {
+  filename       = "${path.module}/storage.env"
+  content_base64 = base64encode("KEY=${azurerm_storage_account.main.primary_access_key}")
+}
+
+resource "local_file" "inventory" {
+  filename = "${path.module}/inventory"
+  content  = azurerm_kubernetes_cluster.main.fqdn
+}`,
+			Config: `
+rule "azurerm_kube_config_not_written_to_local_file" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKubeConfigNotWrittenToLocalFileRule(),
+					Message: "`local_file.kube_config` writes `kube_config_raw` of `azurerm_kubernetes_cluster.main` to a file, where it can be read by anyone with access to the machine running Terraform",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 14},
+						End:      hcl.Pos{Line: 8, Column: 61},
+					},
+				},
+				{
+					Rule:    NewAzurermKubeConfigNotWrittenToLocalFileRule(),
+					Message: "`local_file.storage` writes `primary_access_key` of `azurerm_storage_account.main` to a file, where it can be read by anyone with access to the machine running Terraform",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 18, Column: 20},
+						End:      hcl.Pos{Line: 18, Column: 90},
+					},
+				},
+				{
+					Rule:    NewAzurermKubeConfigNotWrittenToLocalFileRule(),
+					Message: "`local_sensitive_file.admin` writes `kube_admin_config_raw` of `azurerm_kubernetes_cluster.main` to a file, where it can be read by anyone with access to the machine running Terraform",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 13, Column: 14},
+						End:      hcl.Pos{Line: 13, Column: 31},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermKubeConfigNotWrittenToLocalFileRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
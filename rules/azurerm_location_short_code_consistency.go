@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/region"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermLocationShortCodeConsistencyRule checks that region short codes in resource names match their location
+type AzurermLocationShortCodeConsistencyRule struct {
+	tflint.DefaultRule
+}
+
+type azurermLocationShortCodeConsistencyRuleConfig struct {
+	// ShortCodes overrides the short codes of the region catalog by region name, e.g. { westeurope = "euw" }
+	ShortCodes map[string]string `hclext:"short_codes,optional"`
+	Enforce    *bool             `hclext:"enforce,optional"`
+}
+
+// NewAzurermLocationShortCodeConsistencyRule returns new rule with default attributes
+func NewAzurermLocationShortCodeConsistencyRule() *AzurermLocationShortCodeConsistencyRule {
+	return &AzurermLocationShortCodeConsistencyRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermLocationShortCodeConsistencyRule) Name() string {
+	return "azurerm_location_short_code_consistency"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermLocationShortCodeConsistencyRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermLocationShortCodeConsistencyRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermLocationShortCodeConsistencyRule) Link() string {
+	return ""
+}
+
+// Check checks resources whose name embeds a region short code between separators, like "app-weu-01",
+// and reports names whose code is not the short code of the resource's location
+func (r *AzurermLocationShortCodeConsistencyRule) Check(runner tflint.Runner) error {
+	config := azurermLocationShortCodeConsistencyRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	codes := region.ShortCodes()
+	for name, code := range config.ShortCodes {
+		codes[region.Normalize(name)] = strings.ToLower(code)
+	}
+	regions := make(map[string]string, len(codes))
+	for name, code := range codes {
+		regions[code] = name
+	}
+
+	declared, err := declaredResourceTypes(runner)
+	if err != nil {
+		return err
+	}
+	resourceTypes := make([]string, 0, len(declared))
+	for resourceType := range declared {
+		if strings.HasPrefix(resourceType, "azurerm_") {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "name"}, {Name: "location"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			nameAttribute, exists := resource.Body.Attributes["name"]
+			if !exists {
+				continue
+			}
+			locationAttribute, exists := resource.Body.Attributes["location"]
+			if !exists {
+				continue
+			}
+
+			var name, location string
+			err := runner.EvaluateExpr(nameAttribute.Expr, &name, nil)
+			err = runner.EnsureNoError(err, func() error {
+				return runner.EnsureNoError(runner.EvaluateExpr(locationAttribute.Expr, &location, nil), func() error {
+					expected, known := codes[region.Normalize(location)]
+					if !known {
+						return nil
+					}
+					for _, token := range nameTokens(name) {
+						named, isCode := regions[token]
+						if !isCode || token == expected {
+							continue
+						}
+						return runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` has the short code \"%s\" of %s in its name \"%s\", but is located in %s, whose short code is \"%s\"", resource.Labels[0], resource.Labels[1], token, named, name, location, expected),
+							nameAttribute.Expr.Range(),
+						)
+					}
+					return nil
+				})
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// nameTokens splits a resource name into its lowercase parts between the separators `-`, `_` and `.`
+func nameTokens(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(c rune) bool {
+		return c == '-' || c == '_' || c == '.'
+	})
+}
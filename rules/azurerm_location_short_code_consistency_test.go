@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermLocationShortCodeConsistency(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Mismatched short codes",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  name     = "rg-app-neu-01"
+  location = "westeurope"
+}
+
+resource "azurerm_key_vault" "main" {
+  name     = "kv-app-weu-01"
+  location = "West Europe"
+}
+
+resource "azurerm_virtual_network" "main" {
+  name     = "vnet_hub_uks"
+  location = "ukwest"
+}`,
+			Config: `
+rule "azurerm_location_short_code_consistency" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLocationShortCodeConsistencyRule(),
+					Message: "`azurerm_resource_group.main` has the short code \"neu\" of northeurope in its name \"rg-app-neu-01\", but is located in westeurope, whose short code is \"weu\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+				{
+					Rule:    NewAzurermLocationShortCodeConsistencyRule(),
+					Message: "`azurerm_virtual_network.main` has the short code \"uks\" of uksouth in its name \"vnet_hub_uks\", but is located in ukwest, whose short code is \"ukw\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 13, Column: 14},
+						End:      hcl.Pos{Line: 13, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "Overridden short codes",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  name     = "rg-app-euw-01"
+  location = "westeurope"
+}
+
+resource "azurerm_resource_group" "legacy" {
+  name     = "rg-app-weu-01"
+  location = "westeurope"
+}`,
+			Config: `
+rule "azurerm_location_short_code_consistency" {
+  enabled     = true
+  short_codes = { westeurope = "euw" }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermLocationShortCodeConsistencyRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,102 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermLogicAppAndAutomationScheduleTimezoneRule checks that recurring schedules declare their time zone
+type AzurermLogicAppAndAutomationScheduleTimezoneRule struct {
+	tflint.DefaultRule
+
+	// Time zone attribute of each schedule resource type, and what an unset time zone means for its run times
+	schedules []scheduleTimeZone
+}
+
+// scheduleTimeZone is the time zone attribute of a schedule resource type
+type scheduleTimeZone struct {
+	resourceType string
+	attribute    string
+	// consequence completes the issue message, since each service falls back to its own default
+	consequence string
+}
+
+type azurermLogicAppAndAutomationScheduleTimezoneRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermLogicAppAndAutomationScheduleTimezoneRule returns new rule with default attributes
+func NewAzurermLogicAppAndAutomationScheduleTimezoneRule() *AzurermLogicAppAndAutomationScheduleTimezoneRule {
+	return &AzurermLogicAppAndAutomationScheduleTimezoneRule{
+		schedules: []scheduleTimeZone{
+			{
+				resourceType: "azurerm_automation_schedule",
+				attribute:    "timezone",
+				consequence:  "so it runs in the provider default \"Etc/UTC\" instead of a local time zone",
+			},
+			{
+				resourceType: "azurerm_data_factory_trigger_schedule",
+				attribute:    "time_zone",
+				consequence:  "so Data Factory evaluates its schedule in UTC and run times do not follow local daylight saving time",
+			},
+			{
+				resourceType: "azurerm_logic_app_trigger_recurrence",
+				attribute:    "time_zone",
+				consequence:  "so Logic Apps evaluates its schedule in UTC and run times do not follow local daylight saving time",
+			},
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermLogicAppAndAutomationScheduleTimezoneRule) Name() string {
+	return "azurerm_logic_app_and_automation_schedule_timezone"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermLogicAppAndAutomationScheduleTimezoneRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermLogicAppAndAutomationScheduleTimezoneRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermLogicAppAndAutomationScheduleTimezoneRule) Link() string {
+	return ""
+}
+
+// Check checks automation schedules, Logic App recurrences and Data Factory schedule triggers set a time zone
+func (r *AzurermLogicAppAndAutomationScheduleTimezoneRule) Check(runner tflint.Runner) error {
+	config := azurermLogicAppAndAutomationScheduleTimezoneRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, timeZone := range r.schedules {
+		resources, err := runner.GetResourceContent(timeZone.resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: timeZone.attribute}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if _, exists := resource.Body.Attributes[timeZone.attribute]; exists {
+				continue
+			}
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` does not set `%s`, %s", resource.Labels[0], resource.Labels[1], timeZone.attribute, timeZone.consequence),
+				resource.DefRange,
+			)
+		}
+	}
+
+	return nil
+}
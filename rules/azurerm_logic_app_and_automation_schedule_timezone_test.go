@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermLogicAppAndAutomationScheduleTimezone(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Automation schedule without a time zone",
+			Content: `
+resource "azurerm_automation_schedule" "nightly" {
+  frequency = "Day"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLogicAppAndAutomationScheduleTimezoneRule(),
+					Message: "`azurerm_automation_schedule.nightly` does not set `timezone`, so it runs in the provider default \"Etc/UTC\" instead of a local time zone",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 49},
+					},
+				},
+			},
+		},
+		{
+			Name: "Automation schedule with a time zone",
+			Content: `
+resource "azurerm_automation_schedule" "nightly" {
+  frequency = "Day"
+  timezone  = "Europe/London"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Logic App recurrence without a time zone",
+			Content: `
+resource "azurerm_logic_app_trigger_recurrence" "hourly" {
+  frequency = "Day"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLogicAppAndAutomationScheduleTimezoneRule(),
+					Message: "`azurerm_logic_app_trigger_recurrence.hourly` does not set `time_zone`, so Logic Apps evaluates its schedule in UTC and run times do not follow local daylight saving time",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 57},
+					},
+				},
+			},
+		},
+		{
+			Name: "Logic App recurrence with a time zone",
+			Content: `
+resource "azurerm_logic_app_trigger_recurrence" "hourly" {
+  frequency = "Day"
+  time_zone = "W. Europe Standard Time"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Data Factory schedule trigger without a time zone",
+			Content: `
+resource "azurerm_data_factory_trigger_schedule" "daily" {
+  frequency = "Day"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermLogicAppAndAutomationScheduleTimezoneRule(),
+					Message: "`azurerm_data_factory_trigger_schedule.daily` does not set `time_zone`, so Data Factory evaluates its schedule in UTC and run times do not follow local daylight saving time",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 57},
+					},
+				},
+			},
+		},
+		{
+			Name: "Data Factory schedule trigger with a time zone",
+			Content: `
+resource "azurerm_data_factory_trigger_schedule" "daily" {
+  frequency = "Day"
+  time_zone = "W. Europe Standard Time"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_logic_app_and_automation_schedule_timezone" {
+  enabled = true
+}`
+	rule := NewAzurermLogicAppAndAutomationScheduleTimezoneRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
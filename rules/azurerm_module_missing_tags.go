@@ -0,0 +1,183 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermModuleMissingTagsRule checks whether module calls pass the required tags
+type AzurermModuleMissingTagsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermModuleMissingTagsRuleConfig struct {
+	Tags []string `hclext:"tags,optional"`
+	// Variable is the input variable of the modules that sets the tags of their resources, "tags" by default
+	Variable string `hclext:"variable,optional"`
+	// ExcludeModules lists module names or name globs, e.g. "naming_*"
+	ExcludeModules []string `hclext:"exclude_modules,optional"`
+	Enforce        *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermModuleMissingTagsRule returns new rule with default attributes
+func NewAzurermModuleMissingTagsRule() *AzurermModuleMissingTagsRule {
+	return &AzurermModuleMissingTagsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermModuleMissingTagsRule) Name() string {
+	return "azurerm_module_missing_tags"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermModuleMissingTagsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermModuleMissingTagsRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *AzurermModuleMissingTagsRule) Link() string {
+	return ""
+}
+
+// Check checks module calls for the required tags in their tags input, since the resources of shared modules
+// are not seen by the `azurerm_resource_missing_tags` rule. Local modules that declare no tags variable
+// are skipped, remote modules cannot be inspected and are always expected to accept tags.
+func (r *AzurermModuleMissingTagsRule) Check(runner tflint.Runner) error {
+	config := azurermModuleMissingTagsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Tags == nil {
+		return fmt.Errorf("`tags` is not set for the `%s` rule in .tflint.hcl or the base config", r.Name())
+	}
+	if config.Variable == "" {
+		config.Variable = tagsAttributeName
+	}
+	for _, pattern := range config.ExcludeModules {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exclude_modules: invalid glob %q: %s", pattern, err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "source"}, {Name: config.Variable}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, module := range content.Blocks {
+		if matchesGlob(module.Labels[0], config.ExcludeModules) {
+			continue
+		}
+
+		attribute, exists := module.Body.Attributes[config.Variable]
+		if !exists {
+			accepts, err := acceptsTagsVariable(module, config.Variable)
+			if err != nil {
+				return err
+			}
+			if !accepts {
+				continue
+			}
+			required := append([]string{}, config.Tags...)
+			sort.Strings(required)
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("`module.%s` passes no `%s`, so its resources are missing the following tags: %s.", module.Labels[0], config.Variable, quoteAll(required)),
+				module.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		tags, _, err := evaluateTags(runner, attribute.Expr, locals)
+		if errors.Is(err, tflint.ErrUnknownValue) {
+			continue
+		}
+		err = runner.EnsureNoError(err, func() error {
+			var missing []string
+			for _, tag := range config.Tags {
+				if _, ok := tags[tag]; !ok && !stringInSlice(tag, missing) {
+					missing = append(missing, tag)
+				}
+			}
+			if len(missing) == 0 {
+				return nil
+			}
+			sort.Strings(missing)
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`module.%s` is missing the following tags: %s.", module.Labels[0], quoteAll(missing)),
+				attribute.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// acceptsTagsVariable returns whether the module declares the tags variable.
+// Only local modules can be inspected, so other modules are assumed to accept it.
+func acceptsTagsVariable(module *hclext.Block, variable string) (bool, error) {
+	attribute, exists := module.Body.Attributes["source"]
+	if !exists {
+		return true, nil
+	}
+	source, ok := literalString(attribute.Expr)
+	if !ok || !localModuleSource(source) {
+		return true, nil
+	}
+
+	files, err := parseModuleFiles(filepath.Join(filepath.Dir(module.DefRange.Filename), source))
+	if err != nil {
+		return false, err
+	}
+	for _, file := range files {
+		for _, block := range fileBlocks(file, "variable", "name") {
+			if block.Labels[0] == variable {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// matchesGlob returns whether the name matches any of the globs
+func matchesGlob(name string, globs []string) bool {
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
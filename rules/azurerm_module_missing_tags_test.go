@@ -0,0 +1,117 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermModuleMissingTags(t *testing.T) {
+	dir := t.TempDir()
+	modules := map[string]string{
+		"network": `
+variable "tags" {
+  type = map(string)
+}`,
+		"naming": `
+variable "prefix" {
+  type = string
+}`,
+	}
+	for name, content := range modules {
+		if err := os.MkdirAll(filepath.Join(dir, "modules", name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "modules", name, "main.tf"), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Missing tags",
+			Content: `
+locals {
+  common_tags = { Owner = "platform" }
+}
+
+module "network" {
+  source = "./modules/network"
+  tags   = merge(local.common_tags, { Name = "network" })
+}
+
+module "naming" {
+  source = "./modules/naming"
+}
+
+module "aks" {
+  source = "Azure/aks/azurerm"
+}`,
+			Config: `
+rule "azurerm_module_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermModuleMissingTagsRule(),
+					Message: "`module.network` is missing the following tags: \"Environment\".",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 8, Column: 12},
+						End:      hcl.Pos{Line: 8, Column: 58},
+					},
+				},
+				{
+					Rule:    NewAzurermModuleMissingTagsRule(),
+					Message: "`module.aks` passes no `tags`, so its resources are missing the following tags: \"Environment\", \"Owner\".",
+					Range: hcl.Range{
+						Filename: filepath.Join(dir, "main.tf"),
+						Start:    hcl.Pos{Line: 15, Column: 1},
+						End:      hcl.Pos{Line: 15, Column: 13},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom variable and excluded modules",
+			Content: `
+module "network" {
+  source      = "./modules/network"
+  common_tags = { Owner = "platform", Environment = "prod" }
+}
+
+module "aks" {
+  source = "Azure/aks/azurerm"
+}`,
+			Config: `
+rule "azurerm_module_missing_tags" {
+  enabled         = true
+  tags            = ["Owner", "Environment"]
+  variable        = "common_tags"
+  exclude_modules = ["ak*"]
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermModuleMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{filepath.Join(dir, "main.tf"): tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
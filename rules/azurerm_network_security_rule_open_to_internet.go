@@ -0,0 +1,224 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermNetworkSecurityRuleOpenToInternetRule checks that security rules do not open sensitive ports to the internet
+type AzurermNetworkSecurityRuleOpenToInternetRule struct {
+	tflint.DefaultRule
+}
+
+type azurermNetworkSecurityRuleOpenToInternetRuleConfig struct {
+	// Ports are the sensitive destination ports, SSH and RDP by default
+	Ports   []int `hclext:"ports,optional"`
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// internetSources are the source address prefixes matching every address on the internet, compared case-insensitively
+var internetSources = []string{"*", "0.0.0.0/0", "internet", "any"}
+
+// securityRuleSchema is the schema of standalone and inline security rules
+var securityRuleSchema = &hclext.BodySchema{
+	Attributes: []hclext.AttributeSchema{
+		{Name: "name"},
+		{Name: "direction"},
+		{Name: "access"},
+		{Name: "source_address_prefix"},
+		{Name: "source_address_prefixes"},
+		{Name: "destination_port_range"},
+		{Name: "destination_port_ranges"},
+	},
+}
+
+// NewAzurermNetworkSecurityRuleOpenToInternetRule returns new rule with default attributes
+func NewAzurermNetworkSecurityRuleOpenToInternetRule() *AzurermNetworkSecurityRuleOpenToInternetRule {
+	return &AzurermNetworkSecurityRuleOpenToInternetRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) Name() string {
+	return "azurerm_network_security_rule_open_to_internet"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) Link() string {
+	return ""
+}
+
+// Check checks `azurerm_network_security_rule` resources and the inline `security_rule` blocks of
+// `azurerm_network_security_group` resources for inbound rules allowing any internet address to a sensitive port
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) Check(runner tflint.Runner) error {
+	config := azurermNetworkSecurityRuleOpenToInternetRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Ports == nil {
+		config.Ports = []int{22, 3389}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	rules, err := runner.GetResourceContent("azurerm_network_security_rule", securityRuleSchema, nil)
+	if err != nil {
+		return err
+	}
+	for _, resource := range rules.Blocks {
+		subject := fmt.Sprintf("`%s.%s`", resource.Labels[0], resource.Labels[1])
+		if err := r.checkSecurityRule(runner, subject, resource, config.Ports); err != nil {
+			return err
+		}
+	}
+
+	groups, err := runner.GetResourceContent("azurerm_network_security_group", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "security_rule", Body: securityRuleSchema}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, resource := range groups.Blocks {
+		for _, block := range resource.Body.Blocks {
+			subject := fmt.Sprintf("A security rule of `%s.%s`", resource.Labels[0], resource.Labels[1])
+			if attribute, exists := block.Body.Attributes["name"]; exists {
+				if name, ok := literalString(attribute.Expr); ok {
+					subject = fmt.Sprintf("The \"%s\" security rule of `%s.%s`", name, resource.Labels[0], resource.Labels[1])
+				}
+			}
+			if err := r.checkSecurityRule(runner, subject, block, config.Ports); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSecurityRule reports the security rule if it allows inbound traffic from the internet to any of the ports.
+// Rules with unknown values are skipped.
+func (r *AzurermNetworkSecurityRuleOpenToInternetRule) checkSecurityRule(runner tflint.Runner, subject string, block *hclext.Block, ports []int) error {
+	values := map[string][]string{}
+	for _, names := range [][2]string{
+		{"direction", ""},
+		{"access", ""},
+		{"source_address_prefix", "source_address_prefixes"},
+		{"destination_port_range", "destination_port_ranges"},
+	} {
+		evaluated, known, err := evaluateStringOrList(runner, block.Body, names[0], names[1])
+		if err != nil || !known {
+			return err
+		}
+		values[names[0]] = evaluated
+	}
+
+	if !equalFoldAny(values["direction"], "Inbound") || !equalFoldAny(values["access"], "Allow") {
+		return nil
+	}
+	internet := false
+	for _, source := range values["source_address_prefix"] {
+		internet = internet || stringInSlice(strings.ToLower(source), internetSources)
+	}
+	if !internet {
+		return nil
+	}
+
+	sorted := append([]int{}, ports...)
+	sort.Ints(sorted)
+	open := []string{}
+	for _, port := range sorted {
+		for _, portRange := range values["destination_port_range"] {
+			if portInRange(port, portRange) {
+				open = append(open, strconv.Itoa(port))
+				break
+			}
+		}
+	}
+	if len(open) == 0 {
+		return nil
+	}
+	noun := "port"
+	if len(open) > 1 {
+		noun = "ports"
+	}
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("%s allows inbound traffic from the internet to the sensitive %s %s", subject, noun, strings.Join(open, ", ")),
+		block.DefRange,
+	)
+}
+
+// evaluateStringOrList evaluates the string attribute and the list attribute, which can be empty, into their values.
+// It returns false if any value is unknown.
+func evaluateStringOrList(runner tflint.Runner, body *hclext.BodyContent, single string, list string) ([]string, bool, error) {
+	values := []string{}
+	known := true
+	if attribute, exists := body.Attributes[single]; exists {
+		var value string
+		known = false
+		err := runner.EvaluateExpr(attribute.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			values, known = append(values, value), true
+			return nil
+		})
+		if err != nil || !known {
+			return nil, false, err
+		}
+	}
+	if attribute, exists := body.Attributes[list]; exists && list != "" {
+		var items []string
+		known = false
+		err := runner.EvaluateExpr(attribute.Expr, &items, nil)
+		err = runner.EnsureNoError(err, func() error {
+			values, known = append(values, items...), true
+			return nil
+		})
+		if err != nil || !known {
+			return nil, false, err
+		}
+	}
+	return values, known, nil
+}
+
+// equalFoldAny returns whether any of the values equals the expected value case-insensitively
+func equalFoldAny(values []string, expected string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, expected) {
+			return true
+		}
+	}
+	return false
+}
+
+// portInRange returns whether the port is in a port range of a security rule, such as "22", "1024-65535" or "*"
+func portInRange(port int, portRange string) bool {
+	if portRange == "*" {
+		return true
+	}
+	low, high, isRange := strings.Cut(portRange, "-")
+	if !isRange {
+		high = low
+	}
+	from, err := strconv.Atoi(strings.TrimSpace(low))
+	if err != nil {
+		return false
+	}
+	to, err := strconv.Atoi(strings.TrimSpace(high))
+	if err != nil {
+		return false
+	}
+	return from <= port && port <= to
+}
@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermNetworkSecurityRuleOpenToInternet(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Rules open to the internet",
+			Content: `
+resource "azurerm_network_security_rule" "ssh" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "*"
+  destination_port_range = "22"
+}
+
+resource "azurerm_network_security_rule" "management" {
+  direction               = "Inbound"
+  access                  = "Allow"
+  source_address_prefix   = "Internet"
+  destination_port_ranges = ["443", "3000-3500"]
+}
+
+resource "azurerm_network_security_rule" "office" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "203.0.113.0/24"
+  destination_port_range = "22"
+}
+
+resource "azurerm_network_security_group" "main" {
+  security_rule {
+    name                    = "allow-all"
+    direction               = "Inbound"
+    access                  = "Allow"
+    source_address_prefixes = ["10.0.0.0/8", "0.0.0.0/0"]
+    destination_port_range  = "*"
+  }
+
+  security_rule {
+    name                   = "deny-rdp"
+    direction              = "Inbound"
+    access                 = "Deny"
+    source_address_prefix  = "*"
+    destination_port_range = "3389"
+  }
+}`,
+			Config: `
+rule "azurerm_network_security_rule_open_to_internet" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+					Message: "`azurerm_network_security_rule.ssh` allows inbound traffic from the internet to the sensitive port 22",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 47},
+					},
+				},
+				{
+					Rule:    NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+					Message: "`azurerm_network_security_rule.management` allows inbound traffic from the internet to the sensitive port 3389",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 1},
+						End:      hcl.Pos{Line: 9, Column: 54},
+					},
+				},
+				{
+					Rule:    NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+					Message: "The \"allow-all\" security rule of `azurerm_network_security_group.main` allows inbound traffic from the internet to the sensitive ports 22, 3389",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 24, Column: 3},
+						End:      hcl.Pos{Line: 24, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured ports",
+			Content: `
+resource "azurerm_network_security_rule" "ssh" {
+  direction              = "Inbound"
+  access                 = "Allow"
+  source_address_prefix  = "*"
+  destination_port_range = "20-25"
+}`,
+			Config: `
+rule "azurerm_network_security_rule_open_to_internet" {
+  enabled = true
+  ports   = [5432, 21]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+					Message: "`azurerm_network_security_rule.ssh` allows inbound traffic from the internet to the sensitive port 21",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 47},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermNetworkSecurityRuleOpenToInternetRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
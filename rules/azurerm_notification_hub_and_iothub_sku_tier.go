@@ -0,0 +1,178 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/sku"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermNotificationHubAndIothubSkuTierRule checks that messaging services in production paths use a minimum SKU tier
+type AzurermNotificationHubAndIothubSkuTierRule struct {
+	tflint.DefaultRule
+
+	messagingSkus   map[string]messagingSku
+	defaultMinimums map[string]string
+	defaultPaths    []string
+}
+
+// messagingSku locates the SKU name of a resource type, optionally inside a block
+type messagingSku struct {
+	service   string
+	block     string
+	attribute string
+}
+
+type azurermNotificationHubAndIothubSkuTierRuleConfig struct {
+	MinimumSkus map[string]string `hclext:"minimum_skus,optional"`
+	Paths       []string          `hclext:"paths,optional"`
+	Enforce     *bool             `hclext:"enforce,optional"`
+}
+
+// NewAzurermNotificationHubAndIothubSkuTierRule returns new rule with default attributes
+func NewAzurermNotificationHubAndIothubSkuTierRule() *AzurermNotificationHubAndIothubSkuTierRule {
+	return &AzurermNotificationHubAndIothubSkuTierRule{
+		messagingSkus: map[string]messagingSku{
+			"azurerm_iothub":                     {service: sku.IoTHub, block: "sku", attribute: "name"},
+			"azurerm_notification_hub_namespace": {service: sku.NotificationHubNamespace, attribute: "sku_name"},
+			"azurerm_eventhub_namespace":         {service: sku.EventHubNamespace, attribute: "sku"},
+			"azurerm_servicebus_namespace":       {service: sku.ServiceBusNamespace, attribute: "sku"},
+		},
+		defaultMinimums: map[string]string{
+			"azurerm_iothub":                     "S1",
+			"azurerm_notification_hub_namespace": "Standard",
+			"azurerm_eventhub_namespace":         "Standard",
+			"azurerm_servicebus_namespace":       "Standard",
+		},
+		defaultPaths: []string{"*prod*"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermNotificationHubAndIothubSkuTierRule) Name() string {
+	return "azurerm_notification_hub_and_iothub_sku_tier"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermNotificationHubAndIothubSkuTierRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermNotificationHubAndIothubSkuTierRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermNotificationHubAndIothubSkuTierRule) Link() string {
+	return ""
+}
+
+// Check checks IoT hubs and messaging namespaces declared in production paths are at least on the minimum SKU
+func (r *AzurermNotificationHubAndIothubSkuTierRule) Check(runner tflint.Runner) error {
+	config := azurermNotificationHubAndIothubSkuTierRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	minimums := map[string]string{}
+	for resourceType, minimum := range r.defaultMinimums {
+		minimums[resourceType] = minimum
+	}
+	for resourceType, minimum := range config.MinimumSkus {
+		target, known := r.messagingSkus[resourceType]
+		if !known {
+			return fmt.Errorf("minimum_skus: `%s` is not a supported resource type, expected one of %s", resourceType, strings.Join(sortedKeys(minimums), ", "))
+		}
+		if sku.Rank(target.service, minimum) < 0 {
+			return fmt.Errorf("minimum_skus: \"%s\" is not a known SKU of `%s`.%s", minimum, resourceType, didYouMean(minimum, sku.Names(target.service)))
+		}
+		minimums[resourceType] = minimum
+	}
+
+	paths := r.defaultPaths
+	if len(config.Paths) > 0 {
+		paths = config.Paths
+	}
+	for _, pattern := range paths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("paths: invalid glob %q: %s", pattern, err)
+		}
+	}
+
+	for _, resourceType := range sortedKeys(minimums) {
+		target := r.messagingSkus[resourceType]
+		minimum := minimums[resourceType]
+
+		schema := &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: target.attribute}}}
+		if target.block != "" {
+			schema = &hclext.BodySchema{Blocks: []hclext.BlockSchema{{Type: target.block, Body: schema}}}
+		}
+		resources, err := runner.GetResourceContent(resourceType, schema, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if !productionPath(resource.DefRange.Filename, paths) {
+				continue
+			}
+
+			bodies := []*hclext.BodyContent{resource.Body}
+			if target.block != "" {
+				bodies = nil
+				for _, block := range resource.Body.Blocks {
+					bodies = append(bodies, block.Body)
+				}
+			}
+
+			for _, body := range bodies {
+				attribute, exists := body.Attributes[target.attribute]
+				if !exists {
+					continue
+				}
+
+				var name string
+				err := runner.EvaluateExpr(attribute.Expr, &name, nil)
+				err = runner.EnsureNoError(err, func() error {
+					rank := sku.Rank(target.service, name)
+					if rank >= 0 && rank < sku.Rank(target.service, minimum) {
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` uses the \"%s\" SKU in a production path, the minimum is \"%s\"", resource.Labels[0], resource.Labels[1], name, minimum),
+							attribute.Expr.Range(),
+						)
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// productionPath returns whether any of the globs matches the file path, one of its segments,
+// or the directory of the module, which is the working directory when TFLint runs inside it
+func productionPath(filename string, patterns []string) bool {
+	filename = filepath.ToSlash(filename)
+	candidates := append([]string{filename}, strings.Split(filename, "/")...)
+	if dir, err := filepath.Abs(filepath.Dir(filename)); err == nil {
+		candidates = append(candidates, filepath.ToSlash(dir), filepath.Base(dir))
+	}
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if matched, _ := filepath.Match(pattern, candidate); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
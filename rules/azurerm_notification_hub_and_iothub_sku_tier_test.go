@@ -0,0 +1,155 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermNotificationHubAndIothubSkuTier(t *testing.T) {
+	content := `
+resource "azurerm_iothub" "main" {
+  sku {
+    name     = "B3"
+    capacity = 1
+  }
+}
+
+resource "azurerm_servicebus_namespace" "main" {
+  sku = "Basic"
+}`
+
+	cases := []struct {
+		Name     string
+		Filename string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "Non-production path",
+			Filename: "dev.tf",
+			Config: `
+rule "azurerm_notification_hub_and_iothub_sku_tier" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:     "Production path",
+			Filename: "prod.tf",
+			Config: `
+rule "azurerm_notification_hub_and_iothub_sku_tier" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNotificationHubAndIothubSkuTierRule(),
+					Message: "`azurerm_iothub.main` uses the \"B3\" SKU in a production path, the minimum is \"S1\"",
+					Range: hcl.Range{
+						Filename: "prod.tf",
+						Start:    hcl.Pos{Line: 4, Column: 16},
+						End:      hcl.Pos{Line: 4, Column: 20},
+					},
+				},
+				{
+					Rule:    NewAzurermNotificationHubAndIothubSkuTierRule(),
+					Message: "`azurerm_servicebus_namespace.main` uses the \"Basic\" SKU in a production path, the minimum is \"Standard\"",
+					Range: hcl.Range{
+						Filename: "prod.tf",
+						Start:    hcl.Pos{Line: 10, Column: 9},
+						End:      hcl.Pos{Line: 10, Column: 16},
+					},
+				},
+			},
+		},
+		{
+			Name:     "Configured paths and minimums",
+			Filename: "live.tf",
+			Config: `
+rule "azurerm_notification_hub_and_iothub_sku_tier" {
+  enabled      = true
+  paths        = ["live.tf"]
+  minimum_skus = {
+    azurerm_iothub               = "B2"
+    azurerm_servicebus_namespace = "Premium"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNotificationHubAndIothubSkuTierRule(),
+					Message: "`azurerm_servicebus_namespace.main` uses the \"Basic\" SKU in a production path, the minimum is \"Premium\"",
+					Range: hcl.Range{
+						Filename: "live.tf",
+						Start:    hcl.Pos{Line: 10, Column: 9},
+						End:      hcl.Pos{Line: 10, Column: 16},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermNotificationHubAndIothubSkuTierRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{tc.Filename: content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermNotificationHubAndIothubSkuTier_InvalidMinimum(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"prod.tf": "",
+		".tflint.hcl": `
+rule "azurerm_notification_hub_and_iothub_sku_tier" {
+  enabled      = true
+  minimum_skus = { azurerm_iothub = "s1" }
+}`,
+	})
+
+	err := NewAzurermNotificationHubAndIothubSkuTierRule().Check(runner)
+	if err == nil {
+		t.Fatal("Expected an error for an unknown SKU")
+	}
+	if expected := "minimum_skus: \"s1\" is not a known SKU of `azurerm_iothub`. Did you mean \"S1\"?"; err.Error() != expected {
+		t.Fatalf("Expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func Test_ProductionPath(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(t.TempDir(), "prod")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	cases := []struct {
+		Name     string
+		Filename string
+		Expected bool
+	}{
+		{Name: "Module directory", Filename: "main.tf", Expected: true},
+		{Name: "Path segment", Filename: "../dev/prod.tf", Expected: true},
+		{Name: "Non-production module directory", Filename: "../dev/main.tf", Expected: false},
+	}
+
+	for _, tc := range cases {
+		if got := productionPath(tc.Filename, []string{"*prod*"}); got != tc.Expected {
+			t.Fatalf("%s: expected %t for %q, got %t", tc.Name, tc.Expected, tc.Filename, got)
+		}
+	}
+}
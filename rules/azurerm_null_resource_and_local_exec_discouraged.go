@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermNullResourceAndLocalExecDiscouragedRule checks that `local-exec` provisioners do not manage Azure with the az CLI
+type AzurermNullResourceAndLocalExecDiscouragedRule struct {
+	tflint.DefaultRule
+
+	resourceTypes []string
+}
+
+type azurermNullResourceAndLocalExecDiscouragedRuleConfig struct {
+	// AllowScripts are patterns of the commands allowed to run the az CLI, such as scripts without a native alternative
+	AllowScripts []string `hclext:"allow_scripts,optional"`
+	Enforce      *bool    `hclext:"enforce,optional"`
+}
+
+// azCommand matches an az CLI invocation in a shell command and captures its command group and command,
+// e.g. "group create" in `az group create --name rg`
+var azCommand = regexp.MustCompile(`(?:^|[\s;&|(` + "`" + `])az\s+([a-z][\w-]*(?:\s+[a-z][\w-]*)?)`)
+
+// NewAzurermNullResourceAndLocalExecDiscouragedRule returns new rule with default attributes
+func NewAzurermNullResourceAndLocalExecDiscouragedRule() *AzurermNullResourceAndLocalExecDiscouragedRule {
+	return &AzurermNullResourceAndLocalExecDiscouragedRule{
+		resourceTypes: []string{"null_resource", "terraform_data"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermNullResourceAndLocalExecDiscouragedRule) Name() string {
+	return "azurerm_null_resource_and_local_exec_discouraged"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermNullResourceAndLocalExecDiscouragedRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermNullResourceAndLocalExecDiscouragedRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermNullResourceAndLocalExecDiscouragedRule) Link() string {
+	return ""
+}
+
+// Check checks the `local-exec` provisioners of `null_resource` and `terraform_data` resources for commands running
+// the az CLI. Changes made by the CLI are not in the state, so they are not planned, drift unnoticed and are not
+// destroyed, while native azurerm resources or azapi resources for APIs the provider lacks are.
+func (r *AzurermNullResourceAndLocalExecDiscouragedRule) Check(runner tflint.Runner) error {
+	config := azurermNullResourceAndLocalExecDiscouragedRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	allowed, err := compilePatterns("allow_scripts", config.AllowScripts)
+	if err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, resourceType := range r.resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{
+				{
+					Type:       "provisioner",
+					LabelNames: []string{"type"},
+					Body: &hclext.BodySchema{
+						Attributes: []hclext.AttributeSchema{{Name: "command"}},
+					},
+				},
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			for _, provisioner := range resource.Body.Blocks {
+				if provisioner.Labels[0] != "local-exec" {
+					continue
+				}
+				attribute, exists := provisioner.Body.Attributes["command"]
+				if !exists {
+					continue
+				}
+
+				err := commandText(runner, attribute.Expr, func(command string) error {
+					match := azCommand.FindStringSubmatch(command)
+					if match == nil {
+						return nil
+					}
+					for _, pattern := range allowed {
+						if pattern.MatchString(strings.TrimSpace(command)) {
+							return nil
+						}
+					}
+					return runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` runs `az %s` in a `local-exec` provisioner, use a native azurerm resource or an azapi resource instead, or allow the command in `allow_scripts`", resource.Labels[0], resource.Labels[1], match[1]),
+						attribute.Expr.Range(),
+					)
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// commandText calls proc with the text of a command. The literal parts of templates are read from the source,
+// since commands often interpolate values such as `self.triggers` that are unknown at lint time,
+// and other expressions are evaluated.
+func commandText(runner tflint.Runner, expr hcl.Expression, proc func(string) error) error {
+	if template, ok := expr.(*hclsyntax.TemplateExpr); ok {
+		var text strings.Builder
+		for _, part := range template.Parts {
+			if literal, ok := part.(*hclsyntax.LiteralValueExpr); ok && literal.Val.Type() == cty.String {
+				text.WriteString(literal.Val.AsString())
+				continue
+			}
+			// Interpolations stand in for a single argument
+			text.WriteString("_")
+		}
+		return proc(text.String())
+	}
+
+	var command string
+	err := runner.EvaluateExpr(expr, &command, nil)
+	return runner.EnsureNoError(err, func() error {
+		return proc(command)
+	})
+}
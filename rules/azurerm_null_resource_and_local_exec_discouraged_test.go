@@ -0,0 +1,89 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermNullResourceAndLocalExecDiscouraged(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "az CLI in local-exec",
+			Content: `
+resource "null_resource" "feature" {
+  provisioner "local-exec" {
+    command = "az feature register --namespace Microsoft.ContainerService --name ${var.feature}"
+  }
+}
+
+resource "terraform_data" "dns" {
+  provisioner "local-exec" {
+    command = <<-EOT
+      set -e
+      az network private-dns link vnet create --name ${self.input}
+    EOT
+  }
+}
+
+resource "terraform_data" "kubeconfig" {
+  provisioner "local-exec" {
+    command = "az aks get-credentials --name ${var.cluster}"
+  }
+}
+
+resource "null_resource" "lint" {
+  provisioner "local-exec" {
+    command = "tflint --format azure"
+  }
+
+  provisioner "remote-exec" {
+    inline = ["az login --identity"]
+  }
+}`,
+			Config: `
+rule "azurerm_null_resource_and_local_exec_discouraged" {
+  enabled       = true
+  allow_scripts = ["^az aks get-credentials "]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermNullResourceAndLocalExecDiscouragedRule(),
+					Message: "`null_resource.feature` runs `az feature register` in a `local-exec` provisioner, use a native azurerm resource or an azapi resource instead, or allow the command in `allow_scripts`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15},
+						End:      hcl.Pos{Line: 4, Column: 97},
+					},
+				},
+				{
+					Rule:    NewAzurermNullResourceAndLocalExecDiscouragedRule(),
+					Message: "`terraform_data.dns` runs `az network private-dns` in a `local-exec` provisioner, use a native azurerm resource or an azapi resource instead, or allow the command in `allow_scripts`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 15},
+						End:      hcl.Pos{Line: 13, Column: 8},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermNullResourceAndLocalExecDiscouragedRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
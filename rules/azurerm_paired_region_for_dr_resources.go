@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/region"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPairedRegionForDrResourcesRule checks that disaster recovery resources replicate to the paired region
+type AzurermPairedRegionForDrResourcesRule struct {
+	tflint.DefaultRule
+
+	// secondaryLocations maps the checked resource types to the blocks holding their secondary locations
+	secondaryLocations map[string]string
+}
+
+type azurermPairedRegionForDrResourcesRuleConfig struct {
+	// Tag and Value select the resources used for disaster recovery, `DR = "true"` by default
+	Tag     string `hclext:"tag,optional"`
+	Value   string `hclext:"value,optional"`
+	Enforce *bool  `hclext:"enforce,optional"`
+}
+
+// NewAzurermPairedRegionForDrResourcesRule returns new rule with default attributes
+func NewAzurermPairedRegionForDrResourcesRule() *AzurermPairedRegionForDrResourcesRule {
+	return &AzurermPairedRegionForDrResourcesRule{
+		secondaryLocations: map[string]string{
+			"azurerm_api_management":     "additional_location",
+			"azurerm_container_registry": "georeplications",
+			"azurerm_cosmosdb_account":   "geo_location",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermPairedRegionForDrResourcesRule) Name() string {
+	return "azurerm_paired_region_for_dr_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPairedRegionForDrResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPairedRegionForDrResourcesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermPairedRegionForDrResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks that resources tagged for disaster recovery replicate to the Azure paired region of their location,
+// which is updated one at a time during platform maintenance and prioritized for recovery in a regional outage
+func (r *AzurermPairedRegionForDrResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermPairedRegionForDrResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Tag == "" {
+		config.Tag = "DR"
+	}
+	if config.Value == "" {
+		config.Value = "true"
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range sortedKeys(r.secondaryLocations) {
+		blockType := r.secondaryLocations[resourceType]
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "location"}, {Name: tagsAttributeName}},
+			Blocks: []hclext.BlockSchema{
+				{Type: blockType, Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "location"}}}},
+			},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			tags, exists := resource.Body.Attributes[tagsAttributeName]
+			if !exists {
+				continue
+			}
+			items, resolved := resolveMapItems(runner, tags.Expr, locals)
+			if !resolved || items[config.Tag] == nil {
+				continue
+			}
+			if value, ok := literalString(items[config.Tag]); !ok || !strings.EqualFold(value, config.Value) {
+				continue
+			}
+
+			primary, exists := resource.Body.Attributes["location"]
+			if !exists {
+				continue
+			}
+			var location string
+			err := runner.EvaluateExpr(primary.Expr, &location, nil)
+			err = runner.EnsureNoError(err, func() error {
+				name, primaryRegion, known := region.Lookup(location)
+				if !known || primaryRegion.PairedRegion == "" {
+					return nil
+				}
+
+				// Cosmos DB lists the primary region among its geo locations, so only other regions count as secondaries
+				secondaries, evaluated := 0, 0
+				for _, block := range resource.Body.Blocks {
+					attribute, exists := block.Body.Attributes["location"]
+					if !exists {
+						continue
+					}
+					var secondary string
+					err := runner.EvaluateExpr(attribute.Expr, &secondary, nil)
+					err = runner.EnsureNoError(err, func() error {
+						evaluated++
+						secondaryName := region.Normalize(secondary)
+						if secondaryName == name {
+							return nil
+						}
+						secondaries++
+						if secondaryName == primaryRegion.PairedRegion {
+							return nil
+						}
+						return runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` is tagged for disaster recovery and replicates to \"%s\" in `%s`, but the paired region of \"%s\" is \"%s\"", resource.Labels[0], resource.Labels[1], secondary, blockType, location, primaryRegion.PairedRegion),
+							attribute.Expr.Range(),
+						)
+					})
+					if err != nil {
+						return err
+					}
+				}
+
+				// Secondary locations that are not known yet may be in the paired region
+				if secondaries > 0 || evaluated < len(resource.Body.Blocks) {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` is tagged for disaster recovery but has no `%s` in \"%s\", the paired region of \"%s\"", resource.Labels[0], resource.Labels[1], blockType, primaryRegion.PairedRegion, location),
+					resource.DefRange,
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
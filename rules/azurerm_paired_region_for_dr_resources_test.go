@@ -0,0 +1,166 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPairedRegionForDrResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unpaired secondary regions",
+			Content: `
+resource "azurerm_cosmosdb_account" "orders" {
+  location = "westeurope"
+  tags     = { DR = "true" }
+
+  geo_location {
+    location          = "westeurope"
+    failover_priority = 0
+  }
+
+  geo_location {
+    location          = "uksouth"
+    failover_priority = 1
+  }
+}
+
+resource "azurerm_container_registry" "images" {
+  location = "East US 2"
+  tags     = { DR = "True" }
+
+  georeplications {
+    location = "Central US"
+  }
+}
+
+resource "azurerm_container_registry" "cache" {
+  location = "westeurope"
+
+  georeplications {
+    location = "eastus"
+  }
+}`,
+			Config: `
+rule "azurerm_paired_region_for_dr_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPairedRegionForDrResourcesRule(),
+					Message: "`azurerm_cosmosdb_account.orders` is tagged for disaster recovery and replicates to \"uksouth\" in `geo_location`, but the paired region of \"westeurope\" is \"northeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 12, Column: 25},
+						End:      hcl.Pos{Line: 12, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom tag",
+			Content: `
+resource "azurerm_api_management" "gateway" {
+  location = "japaneast"
+  tags     = { criticality = "tier0" }
+
+  additional_location {
+    location = "koreacentral"
+  }
+}`,
+			Config: `
+rule "azurerm_paired_region_for_dr_resources" {
+  enabled = true
+  tag     = "criticality"
+  value   = "tier0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPairedRegionForDrResourcesRule(),
+					Message: "`azurerm_api_management.gateway` is tagged for disaster recovery and replicates to \"koreacentral\" in `additional_location`, but the paired region of \"japaneast\" is \"japanwest\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 16},
+						End:      hcl.Pos{Line: 7, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "No secondary location",
+			Content: `
+resource "azurerm_container_registry" "images" {
+  location = "westeurope"
+  tags     = { DR = "true" }
+}
+
+resource "azurerm_cosmosdb_account" "orders" {
+  location = "westeurope"
+  tags     = { DR = "true" }
+
+  geo_location {
+    location          = "westeurope"
+    failover_priority = 0
+  }
+}
+
+resource "azurerm_cosmosdb_account" "paired" {
+  location = "westeurope"
+  tags     = { DR = "true" }
+
+  geo_location {
+    location          = "westeurope"
+    failover_priority = 0
+  }
+
+  geo_location {
+    location          = "northeurope"
+    failover_priority = 1
+  }
+}`,
+			Config: `
+rule "azurerm_paired_region_for_dr_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPairedRegionForDrResourcesRule(),
+					Message: "`azurerm_container_registry.images` is tagged for disaster recovery but has no `georeplications` in \"northeurope\", the paired region of \"westeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 47},
+					},
+				},
+				{
+					Rule:    NewAzurermPairedRegionForDrResourcesRule(),
+					Message: "`azurerm_cosmosdb_account.orders` is tagged for disaster recovery but has no `geo_location` in \"northeurope\", the paired region of \"westeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 45},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermPairedRegionForDrResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
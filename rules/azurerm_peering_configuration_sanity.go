@@ -0,0 +1,179 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPeeringConfigurationSanityRule checks that both directions of a virtual network peering agree on gateway settings
+type AzurermPeeringConfigurationSanityRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermPeeringConfigurationSanityRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// virtualNetworkPeering is one direction of a peering with the networks it connects
+type virtualNetworkPeering struct {
+	block                      *hclext.Block
+	local                      string
+	remote                     string
+	allowGatewayTransit        bool
+	useRemoteGateways          bool
+	useRemoteGatewaysAttribute *hclext.Attribute
+}
+
+// NewAzurermPeeringConfigurationSanityRule returns new rule with default attributes
+func NewAzurermPeeringConfigurationSanityRule() *AzurermPeeringConfigurationSanityRule {
+	return &AzurermPeeringConfigurationSanityRule{
+		resourceType: "azurerm_virtual_network_peering",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermPeeringConfigurationSanityRule) Name() string {
+	return "azurerm_peering_configuration_sanity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPeeringConfigurationSanityRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPeeringConfigurationSanityRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermPeeringConfigurationSanityRule) Link() string {
+	return ""
+}
+
+// Check checks gateway transit settings of peerings declared in both directions in the module
+func (r *AzurermPeeringConfigurationSanityRule) Check(runner tflint.Runner) error {
+	config := azurermPeeringConfigurationSanityRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "virtual_network_name"},
+			{Name: "remote_virtual_network_id"},
+			{Name: "allow_gateway_transit"},
+			{Name: "use_remote_gateways"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	peerings := []*virtualNetworkPeering{}
+	for _, resource := range resources.Blocks {
+		peering, err := r.decodePeering(runner, resource)
+		if err != nil {
+			return err
+		}
+		if peering != nil {
+			peerings = append(peerings, peering)
+		}
+	}
+
+	for i, peering := range peerings {
+		for _, other := range peerings[i+1:] {
+			if peering.local != other.remote || peering.remote != other.local {
+				continue
+			}
+			r.checkPair(runner, peering, other)
+			r.checkPair(runner, other, peering)
+		}
+	}
+
+	return nil
+}
+
+// checkPair checks the peering can use the gateways of its counterpart
+func (r *AzurermPeeringConfigurationSanityRule) checkPair(runner tflint.Runner, peering *virtualNetworkPeering, other *virtualNetworkPeering) {
+	if !peering.useRemoteGateways {
+		return
+	}
+
+	otherAddress := other.block.Labels[0] + "." + other.block.Labels[1]
+	switch {
+	case other.useRemoteGateways:
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`use_remote_gateways` is enabled in both directions of the peering with `%s`, only one side can use remote gateways", otherAddress),
+			peering.useRemoteGatewaysAttribute.Expr.Range(),
+		)
+	case !other.allowGatewayTransit:
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`use_remote_gateways` is enabled but the reverse peering `%s` does not set `allow_gateway_transit = true`", otherAddress),
+			peering.useRemoteGatewaysAttribute.Expr.Range(),
+		)
+	}
+}
+
+// decodePeering returns the peering with its networks and gateway settings,
+// or nil when any of them cannot be determined statically
+func (r *AzurermPeeringConfigurationSanityRule) decodePeering(runner tflint.Runner, resource *hclext.Block) (*virtualNetworkPeering, error) {
+	localAttribute, localExists := resource.Body.Attributes["virtual_network_name"]
+	remoteAttribute, remoteExists := resource.Body.Attributes["remote_virtual_network_id"]
+	if !localExists || !remoteExists {
+		return nil, nil
+	}
+
+	local, err := r.networkKey(runner, localAttribute)
+	if err != nil || local == "" {
+		return nil, err
+	}
+	remote, err := r.networkKey(runner, remoteAttribute)
+	if err != nil || remote == "" {
+		return nil, err
+	}
+
+	var decoded *virtualNetworkPeering
+	err = evaluateOptionalBool(runner, resource.Body, "allow_gateway_transit", false, func(allowGatewayTransit bool) error {
+		return evaluateOptionalBool(runner, resource.Body, "use_remote_gateways", false, func(useRemoteGateways bool) error {
+			decoded = &virtualNetworkPeering{
+				block:                      resource,
+				local:                      local,
+				remote:                     remote,
+				allowGatewayTransit:        allowGatewayTransit,
+				useRemoteGateways:          useRemoteGateways,
+				useRemoteGatewaysAttribute: resource.Body.Attributes["use_remote_gateways"],
+			}
+			return nil
+		})
+	})
+	return decoded, err
+}
+
+// networkKey identifies the virtual network an attribute refers to, either by the address of
+// the referenced azurerm_virtual_network resource or by the network name
+func (r *AzurermPeeringConfigurationSanityRule) networkKey(runner tflint.Runner, attribute *hclext.Attribute) (string, error) {
+	for _, address := range referencedResources(attribute.Expr) {
+		if strings.HasPrefix(address, "azurerm_virtual_network.") {
+			return address, nil
+		}
+	}
+
+	key := ""
+	var val string
+	err := runner.EvaluateExpr(attribute.Expr, &val, nil)
+	err = runner.EnsureNoError(err, func() error {
+		// Both a name and a resource ID end with the network name
+		key = "name:" + val[strings.LastIndex(val, "/")+1:]
+		return nil
+	})
+	return key, err
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPeeringConfigurationSanity(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Remote gateways without gateway transit",
+			Content: `
+resource "azurerm_virtual_network_peering" "hub_to_spoke" {
+  virtual_network_name      = azurerm_virtual_network.hub.name
+  remote_virtual_network_id = azurerm_virtual_network.spoke.id
+}
+
+resource "azurerm_virtual_network_peering" "spoke_to_hub" {
+  virtual_network_name      = azurerm_virtual_network.spoke.name
+  remote_virtual_network_id = azurerm_virtual_network.hub.id
+  use_remote_gateways       = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPeeringConfigurationSanityRule(),
+					Message: "`use_remote_gateways` is enabled but the reverse peering `azurerm_virtual_network_peering.hub_to_spoke` does not set `allow_gateway_transit = true`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 31},
+						End:      hcl.Pos{Line: 10, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "Remote gateways in both directions",
+			Content: `
+resource "azurerm_virtual_network_peering" "a_to_b" {
+  virtual_network_name      = "vnet-a"
+  remote_virtual_network_id = "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet-b"
+  use_remote_gateways       = true
+}
+
+resource "azurerm_virtual_network_peering" "b_to_a" {
+  virtual_network_name      = "vnet-b"
+  remote_virtual_network_id = "/subscriptions/0000/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet-a"
+  use_remote_gateways       = true
+  allow_gateway_transit     = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPeeringConfigurationSanityRule(),
+					Message: "`use_remote_gateways` is enabled in both directions of the peering with `azurerm_virtual_network_peering.b_to_a`, only one side can use remote gateways",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 31},
+						End:      hcl.Pos{Line: 5, Column: 35},
+					},
+				},
+				{
+					Rule:    NewAzurermPeeringConfigurationSanityRule(),
+					Message: "`use_remote_gateways` is enabled in both directions of the peering with `azurerm_virtual_network_peering.a_to_b`, only one side can use remote gateways",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 11, Column: 31},
+						End:      hcl.Pos{Line: 11, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "Consistent gateway transit",
+			Content: `
+resource "azurerm_virtual_network_peering" "hub_to_spoke" {
+  virtual_network_name      = azurerm_virtual_network.hub.name
+  remote_virtual_network_id = azurerm_virtual_network.spoke.id
+  allow_gateway_transit     = true
+}
+
+resource "azurerm_virtual_network_peering" "spoke_to_hub" {
+  virtual_network_name      = azurerm_virtual_network.spoke.name
+  remote_virtual_network_id = azurerm_virtual_network.hub.id
+  use_remote_gateways       = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_peering_configuration_sanity" {
+  enabled = true
+}`
+	rule := NewAzurermPeeringConfigurationSanityRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
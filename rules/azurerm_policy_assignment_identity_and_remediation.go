@@ -0,0 +1,226 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPolicyAssignmentIdentityAndRemediationRule checks that assignments of remediating policies have a managed identity
+type AzurermPolicyAssignmentIdentityAndRemediationRule struct {
+	tflint.DefaultRule
+
+	assignmentTypes []string
+	definitionType  string
+	// Effects that need a managed identity to remediate resources
+	remediatingEffects []string
+}
+
+type azurermPolicyAssignmentIdentityAndRemediationRuleConfig struct {
+	PolicyDefinitionIDs []string `hclext:"policy_definition_ids,optional"`
+	Enforce             *bool    `hclext:"enforce,optional"`
+}
+
+// parameterPattern matches effects taken from a policy parameter, e.g. "[parameters('effect')]"
+var parameterPattern = regexp.MustCompile(`^\[parameters\('([^']+)'\)\]$`)
+
+// NewAzurermPolicyAssignmentIdentityAndRemediationRule returns new rule with default attributes
+func NewAzurermPolicyAssignmentIdentityAndRemediationRule() *AzurermPolicyAssignmentIdentityAndRemediationRule {
+	return &AzurermPolicyAssignmentIdentityAndRemediationRule{
+		assignmentTypes: []string{
+			"azurerm_management_group_policy_assignment",
+			"azurerm_subscription_policy_assignment",
+			"azurerm_resource_group_policy_assignment",
+			"azurerm_resource_policy_assignment",
+		},
+		definitionType:     "azurerm_policy_definition",
+		remediatingEffects: []string{"DeployIfNotExists", "Modify"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) Name() string {
+	return "azurerm_policy_assignment_identity_and_remediation"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) Link() string {
+	return ""
+}
+
+// policyDefinition holds the parts of a policy definition needed to resolve its effect
+type policyDefinition struct {
+	effect     string
+	parameters map[string]interface{}
+}
+
+// Check checks assignments of DeployIfNotExists and Modify policies have an identity block
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) Check(runner tflint.Runner) error {
+	config := azurermPolicyAssignmentIdentityAndRemediationRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	definitions, err := r.policyDefinitions(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, assignmentType := range r.assignmentTypes {
+		assignments, err := runner.GetResourceContent(assignmentType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{
+				{Name: "policy_definition_id"},
+				{Name: "parameters"},
+			},
+			Blocks: []hclext.BlockSchema{{Type: "identity", Body: &hclext.BodySchema{}}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, assignment := range assignments.Blocks {
+			if len(assignment.Body.Blocks) > 0 {
+				continue
+			}
+			attribute, exists := assignment.Body.Attributes["policy_definition_id"]
+			if !exists {
+				continue
+			}
+
+			effect := ""
+			if addresses := referencedResources(attribute.Expr); len(addresses) > 0 {
+				for _, address := range addresses {
+					definition, known := definitions[address]
+					if !known {
+						continue
+					}
+					effect, err = r.resolveEffect(runner, definition, assignment.Body)
+					if err != nil {
+						return err
+					}
+				}
+			} else {
+				var id string
+				err := runner.EvaluateExpr(attribute.Expr, &id, nil)
+				err = runner.EnsureNoError(err, func() error {
+					for _, configured := range config.PolicyDefinitionIDs {
+						if strings.EqualFold(id, configured) {
+							effect = "configured"
+						}
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			if effect == "" {
+				continue
+			}
+			message := fmt.Sprintf("`%s.%s` assigns a policy that needs a managed identity to remediate resources, add an `identity` block", assignment.Labels[0], assignment.Labels[1])
+			if effect != "configured" {
+				message = fmt.Sprintf("`%s.%s` assigns a %s policy without an `identity` block, so remediation tasks cannot run", assignment.Labels[0], assignment.Labels[1], effect)
+			}
+			runner.EmitIssue(r, message, assignment.DefRange)
+		}
+	}
+
+	return nil
+}
+
+// resolveEffect returns the remediating effect of the definition as assigned, or an empty string.
+// Effects taken from parameters resolve to the assigned value, then to the parameter default.
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) resolveEffect(runner tflint.Runner, definition policyDefinition, assignment *hclext.BodyContent) (string, error) {
+	effect := definition.effect
+	if match := parameterPattern.FindStringSubmatch(effect); match != nil {
+		effect = ""
+		if parameter, ok := definition.parameters[match[1]].(map[string]interface{}); ok {
+			if value, ok := parameter["defaultValue"].(string); ok {
+				effect = value
+			}
+		}
+
+		if attribute, exists := assignment.Attributes["parameters"]; exists {
+			err := evaluateJSON(runner, attribute.Expr, func(document interface{}, err error) error {
+				if parameters, ok := document.(map[string]interface{}); ok {
+					if parameter, ok := parameters[match[1]].(map[string]interface{}); ok {
+						if value, ok := parameter["value"].(string); ok {
+							effect = value
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	for _, remediating := range r.remediatingEffects {
+		if strings.EqualFold(effect, remediating) {
+			return remediating, nil
+		}
+	}
+	return "", nil
+}
+
+// policyDefinitions returns the custom policy definitions of the module keyed by address
+func (r *AzurermPolicyAssignmentIdentityAndRemediationRule) policyDefinitions(runner tflint.Runner) (map[string]policyDefinition, error) {
+	resources, err := runner.GetResourceContent(r.definitionType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "policy_rule"},
+			{Name: "parameters"},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := map[string]policyDefinition{}
+	for _, resource := range resources.Blocks {
+		definition := policyDefinition{}
+
+		if attribute, exists := resource.Body.Attributes["policy_rule"]; exists {
+			err := evaluateJSON(runner, attribute.Expr, func(document interface{}, err error) error {
+				if rule, ok := document.(map[string]interface{}); ok {
+					if then, ok := rule["then"].(map[string]interface{}); ok {
+						definition.effect, _ = then["effect"].(string)
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if attribute, exists := resource.Body.Attributes["parameters"]; exists {
+			err := evaluateJSON(runner, attribute.Expr, func(document interface{}, err error) error {
+				definition.parameters, _ = document.(map[string]interface{})
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		definitions[resource.Labels[0]+"."+resource.Labels[1]] = definition
+	}
+	return definitions, nil
+}
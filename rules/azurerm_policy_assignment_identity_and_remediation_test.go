@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPolicyAssignmentIdentityAndRemediation(t *testing.T) {
+	definitions := `
+resource "azurerm_policy_definition" "diagnostics" {
+  policy_rule = <<RULE
+{"if": {"field": "type", "equals": "Microsoft.KeyVault/vaults"}, "then": {"effect": "deployIfNotExists"}}
+RULE
+}
+
+resource "azurerm_policy_definition" "tags" {
+  policy_rule = <<RULE
+{"if": {"field": "tags", "exists": "false"}, "then": {"effect": "[parameters('effect')]"}}
+RULE
+  parameters = <<PARAMETERS
+{"effect": {"type": "String", "defaultValue": "Audit"}}
+PARAMETERS
+}
+`
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "DeployIfNotExists definition without identity",
+			Content: definitions + `
+resource "azurerm_subscription_policy_assignment" "diagnostics" {
+  policy_definition_id = azurerm_policy_definition.diagnostics.id
+}
+
+resource "azurerm_subscription_policy_assignment" "diagnostics_with_identity" {
+  policy_definition_id = azurerm_policy_definition.diagnostics.id
+
+  identity {
+    type = "SystemAssigned"
+  }
+}`,
+			Config: `
+rule "azurerm_policy_assignment_identity_and_remediation" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPolicyAssignmentIdentityAndRemediationRule(),
+					Message: "`azurerm_subscription_policy_assignment.diagnostics` assigns a DeployIfNotExists policy without an `identity` block, so remediation tasks cannot run",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 17, Column: 1},
+						End:      hcl.Pos{Line: 17, Column: 64},
+					},
+				},
+			},
+		},
+		{
+			Name: "Effect resolved from parameters",
+			Content: definitions + `
+resource "azurerm_resource_group_policy_assignment" "audit" {
+  policy_definition_id = azurerm_policy_definition.tags.id
+}
+
+resource "azurerm_resource_group_policy_assignment" "modify" {
+  policy_definition_id = azurerm_policy_definition.tags.id
+  parameters           = <<PARAMETERS
+{"effect": {"value": "Modify"}}
+PARAMETERS
+}`,
+			Config: `
+rule "azurerm_policy_assignment_identity_and_remediation" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPolicyAssignmentIdentityAndRemediationRule(),
+					Message: "`azurerm_resource_group_policy_assignment.modify` assigns a Modify policy without an `identity` block, so remediation tasks cannot run",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 21, Column: 1},
+						End:      hcl.Pos{Line: 21, Column: 61},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured built-in definition",
+			Content: `
+resource "azurerm_management_group_policy_assignment" "builtin" {
+  policy_definition_id = "/providers/Microsoft.Authorization/policyDefinitions/0000-1111"
+}`,
+			Config: `
+rule "azurerm_policy_assignment_identity_and_remediation" {
+  enabled               = true
+  policy_definition_ids = ["/providers/Microsoft.Authorization/policyDefinitions/0000-1111"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPolicyAssignmentIdentityAndRemediationRule(),
+					Message: "`azurerm_management_group_policy_assignment.builtin` assigns a policy that needs a managed identity to remediate resources, add an `identity` block",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 64},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermPolicyAssignmentIdentityAndRemediationRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
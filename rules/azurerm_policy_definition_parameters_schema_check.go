@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPolicyDefinitionParametersSchemaCheckRule checks that policy definitions have well-formed and consistent JSON
+type AzurermPolicyDefinitionParametersSchemaCheckRule struct {
+	tflint.DefaultRule
+}
+
+type azurermPolicyDefinitionParametersSchemaCheckRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// policyParameterReference matches template expressions such as `[parameters('effect')]`
+var policyParameterReference = regexp.MustCompile(`parameters\(\s*'([^']+)'\s*\)`)
+
+// NewAzurermPolicyDefinitionParametersSchemaCheckRule returns new rule with default attributes
+func NewAzurermPolicyDefinitionParametersSchemaCheckRule() *AzurermPolicyDefinitionParametersSchemaCheckRule {
+	return &AzurermPolicyDefinitionParametersSchemaCheckRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermPolicyDefinitionParametersSchemaCheckRule) Name() string {
+	return "azurerm_policy_definition_parameters_schema_check"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPolicyDefinitionParametersSchemaCheckRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPolicyDefinitionParametersSchemaCheckRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermPolicyDefinitionParametersSchemaCheckRule) Link() string {
+	return ""
+}
+
+// Check checks that `policy_rule` and `parameters` of policy definitions are valid JSON and that every
+// parameter the policy rule references is declared, which Azure only reports when the definition is deployed
+func (r *AzurermPolicyDefinitionParametersSchemaCheckRule) Check(runner tflint.Runner) error {
+	config := azurermPolicyDefinitionParametersSchemaCheckRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent("azurerm_policy_definition", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "policy_rule"}, {Name: "parameters"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		policyRule, exists := resource.Body.Attributes["policy_rule"]
+		if !exists {
+			continue
+		}
+
+		// declared stays nil when the parameters cannot be inspected, so that references are not reported
+		declared := map[string]bool{}
+		if parameters, exists := resource.Body.Attributes["parameters"]; exists {
+			declared = nil
+			err := evaluateJSON(runner, parameters.Expr, func(document interface{}, err error) error {
+				if err != nil {
+					return runner.EmitIssue(r, fmt.Sprintf("`parameters` of `%s.%s` is not valid JSON: %s", resource.Labels[0], resource.Labels[1], err), parameters.Expr.Range())
+				}
+				object, ok := document.(map[string]interface{})
+				if !ok {
+					return runner.EmitIssue(r, fmt.Sprintf("`parameters` of `%s.%s` must be a JSON object of parameter definitions", resource.Labels[0], resource.Labels[1]), parameters.Expr.Range())
+				}
+				declared = map[string]bool{}
+				for name := range object {
+					// Parameter names are case-insensitive in policy rules
+					declared[strings.ToLower(name)] = true
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		err := evaluateJSON(runner, policyRule.Expr, func(document interface{}, err error) error {
+			if err != nil {
+				return runner.EmitIssue(r, fmt.Sprintf("`policy_rule` of `%s.%s` is not valid JSON: %s", resource.Labels[0], resource.Labels[1], err), policyRule.Expr.Range())
+			}
+			if declared == nil {
+				return nil
+			}
+
+			var undeclared []string
+			walkJSONStrings(document, nil, func(path []string, value string) {
+				for _, match := range policyParameterReference.FindAllStringSubmatch(value, -1) {
+					if name := match[1]; !declared[strings.ToLower(name)] && !stringInSlice(name, undeclared) {
+						undeclared = append(undeclared, name)
+					}
+				}
+			})
+			sort.Strings(undeclared)
+			for _, name := range undeclared {
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("`policy_rule` of `%s.%s` references the parameter `%s`, which is not declared in `parameters`", resource.Labels[0], resource.Labels[1], name),
+					policyRule.Expr.Range(),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
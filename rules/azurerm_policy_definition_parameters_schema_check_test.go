@@ -0,0 +1,109 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPolicyDefinitionParametersSchemaCheck(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Undeclared parameters",
+			Content: `
+resource "azurerm_policy_definition" "allowed_locations" {
+  policy_rule = <<JSON
+{
+  "if": {"not": {"field": "location", "in": "[parameters('allowedLocations')]"}},
+  "then": {"effect": "[parameters('effect')]"}
+}
+JSON
+  parameters = <<JSON
+{"AllowedLocations": {"type": "Array"}}
+JSON
+}`,
+			Config: `
+rule "azurerm_policy_definition_parameters_schema_check" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPolicyDefinitionParametersSchemaCheckRule(),
+					Message: "`policy_rule` of `azurerm_policy_definition.allowed_locations` references the parameter `effect`, which is not declared in `parameters`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 17},
+						End:      hcl.Pos{Line: 8, Column: 5},
+					},
+				},
+			},
+		},
+		{
+			Name: "Malformed JSON",
+			Content: `
+resource "azurerm_policy_definition" "deny" {
+  policy_rule = "{\"if\": {}, \"then\": {\"effect\": \"deny\"}"
+  parameters  = "[]"
+}`,
+			Config: `
+rule "azurerm_policy_definition_parameters_schema_check" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPolicyDefinitionParametersSchemaCheckRule(),
+					Message: "`parameters` of `azurerm_policy_definition.deny` must be a JSON object of parameter definitions",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 21},
+					},
+				},
+				{
+					Rule:    NewAzurermPolicyDefinitionParametersSchemaCheckRule(),
+					Message: "`policy_rule` of `azurerm_policy_definition.deny` is not valid JSON: unexpected end of JSON input",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 17},
+						End:      hcl.Pos{Line: 3, Column: 64},
+					},
+				},
+			},
+		},
+		{
+			Name: "Consistent definitions",
+			Content: `
+resource "azurerm_policy_definition" "deny" {
+  policy_rule = "{\"if\": {\"field\": \"type\", \"equals\": \"[parameters('type')]\"}, \"then\": {\"effect\": \"deny\"}}"
+  parameters  = "{\"type\": {\"type\": \"String\"}}"
+}
+
+resource "azurerm_policy_definition" "audit" {
+  policy_rule = "{\"if\": {}, \"then\": {\"effect\": \"audit\"}}"
+}`,
+			Config: `
+rule "azurerm_policy_definition_parameters_schema_check" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermPolicyDefinitionParametersSchemaCheckRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
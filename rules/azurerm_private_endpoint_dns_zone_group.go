@@ -0,0 +1,237 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPrivateEndpointDNSZoneGroupRule checks that private endpoints register in the private DNS zone of their subresource
+type AzurermPrivateEndpointDNSZoneGroupRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+	zoneType     string
+	// Private DNS zones used by each private link subresource
+	zones map[string]string
+}
+
+type azurermPrivateEndpointDNSZoneGroupRuleConfig struct {
+	Zones   map[string]string `hclext:"zones,optional"`
+	Enforce *bool             `hclext:"enforce,optional"`
+}
+
+// NewAzurermPrivateEndpointDNSZoneGroupRule returns new rule with default attributes
+func NewAzurermPrivateEndpointDNSZoneGroupRule() *AzurermPrivateEndpointDNSZoneGroupRule {
+	return &AzurermPrivateEndpointDNSZoneGroupRule{
+		resourceType: "azurerm_private_endpoint",
+		zoneType:     "azurerm_private_dns_zone",
+		zones: map[string]string{
+			"blob":                "privatelink.blob.core.windows.net",
+			"blob_secondary":      "privatelink.blob.core.windows.net",
+			"file":                "privatelink.file.core.windows.net",
+			"queue":               "privatelink.queue.core.windows.net",
+			"table":               "privatelink.table.core.windows.net",
+			"web":                 "privatelink.web.core.windows.net",
+			"dfs":                 "privatelink.dfs.core.windows.net",
+			"vault":               "privatelink.vaultcore.azure.net",
+			"sqlServer":           "privatelink.database.windows.net",
+			"registry":            "privatelink.azurecr.io",
+			"Sql":                 "privatelink.documents.azure.com",
+			"MongoDB":             "privatelink.mongo.cosmos.azure.com",
+			"namespace":           "privatelink.servicebus.windows.net",
+			"sites":               "privatelink.azurewebsites.net",
+			"configurationStores": "privatelink.azconfig.io",
+			"postgresqlServer":    "privatelink.postgres.database.azure.com",
+			"mysqlServer":         "privatelink.mysql.database.azure.com",
+			"redisCache":          "privatelink.redis.cache.windows.net",
+			"account":             "privatelink.cognitiveservices.azure.com",
+			"searchService":       "privatelink.search.windows.net",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) Name() string {
+	return "azurerm_private_endpoint_dns_zone_group"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) Link() string {
+	return ""
+}
+
+// Check checks private endpoints have a DNS zone group referencing the zone of their subresource
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) Check(runner tflint.Runner) error {
+	config := azurermPrivateEndpointDNSZoneGroupRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	zones := map[string]string{}
+	for subresource, zone := range r.zones {
+		zones[subresource] = zone
+	}
+	for subresource, zone := range config.Zones {
+		zones[subresource] = zone
+	}
+
+	zoneNames, err := r.zoneNames(runner)
+	if err != nil {
+		return err
+	}
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "private_service_connection",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "subresource_names"}}},
+			},
+			{
+				Type: "private_dns_zone_group",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "private_dns_zone_ids"}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		blocks := resource.Body.Blocks.ByType()
+		groups := blocks["private_dns_zone_group"]
+		if len(groups) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` has no `private_dns_zone_group` block, so its address is not registered in private DNS", resource.Labels[0], resource.Labels[1]),
+				resource.DefRange,
+			)
+			continue
+		}
+
+		for _, connection := range blocks["private_service_connection"] {
+			attribute, exists := connection.Body.Attributes["subresource_names"]
+			if !exists {
+				continue
+			}
+
+			var subresources []string
+			err := runner.EvaluateExpr(attribute.Expr, &subresources, nil)
+			err = runner.EnsureNoError(err, func() error {
+				for _, subresource := range subresources {
+					expected, known := zones[subresource]
+					if !known {
+						continue
+					}
+					for _, group := range groups {
+						if err := r.checkZoneGroup(runner, group, subresource, expected, zoneNames); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkZoneGroup checks the zone group references the expected zone, when the referenced zones are known
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) checkZoneGroup(runner tflint.Runner, group *hclext.Block, subresource string, expected string, zoneNames map[string]string) error {
+	attribute, exists := group.Body.Attributes["private_dns_zone_ids"]
+	if !exists {
+		return nil
+	}
+
+	referenced := []string{}
+	if addresses := referencedResources(attribute.Expr); len(addresses) > 0 {
+		for _, address := range addresses {
+			if name, known := zoneNames[address]; known {
+				referenced = append(referenced, name)
+			}
+		}
+	} else {
+		var ids []string
+		err := runner.EvaluateExpr(attribute.Expr, &ids, nil)
+		err = runner.EnsureNoError(err, func() error {
+			for _, id := range ids {
+				// Zone IDs end with the zone name
+				referenced = append(referenced, id[strings.LastIndex(id, "/")+1:])
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(referenced) == 0 {
+		return nil
+	}
+	for _, name := range referenced {
+		if strings.EqualFold(name, expected) {
+			return nil
+		}
+	}
+	runner.EmitIssue(
+		r,
+		fmt.Sprintf("The `%s` subresource resolves through the \"%s\" zone, but the zone group references %s", subresource, expected, quoteAll(referenced)),
+		attribute.Expr.Range(),
+	)
+	return nil
+}
+
+// zoneNames returns the names of the private DNS zones declared as resources or data sources, keyed by address
+func (r *AzurermPrivateEndpointDNSZoneGroupRule) zoneNames(runner tflint.Runner) (map[string]string, error) {
+	schema := &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "name"}}}
+
+	resources, err := runner.GetResourceContent(r.zoneType, schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	dataSources, err := getDataSourceContent(runner, r.zoneType, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for _, block := range append(resources.Blocks, dataSources...) {
+		attribute, exists := block.Body.Attributes["name"]
+		if !exists {
+			continue
+		}
+
+		address := block.Labels[0] + "." + block.Labels[1]
+		if block.Type == "data" {
+			address = "data." + address
+		}
+
+		var name string
+		err := runner.EvaluateExpr(attribute.Expr, &name, nil)
+		err = runner.EnsureNoError(err, func() error {
+			names[address] = name
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPrivateEndpointDNSZoneGroup(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Missing zone group",
+			Content: `
+resource "azurerm_private_endpoint" "kv" {
+  private_service_connection {
+    subresource_names = ["vault"]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPrivateEndpointDNSZoneGroupRule(),
+					Message: "`azurerm_private_endpoint.kv` has no `private_dns_zone_group` block, so its address is not registered in private DNS",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "Zone group referencing the wrong zone",
+			Content: `
+resource "azurerm_private_dns_zone" "file" {
+  name = "privatelink.file.core.windows.net"
+}
+
+resource "azurerm_private_endpoint" "blob" {
+  private_service_connection {
+    subresource_names = ["blob"]
+  }
+
+  private_dns_zone_group {
+    private_dns_zone_ids = [azurerm_private_dns_zone.file.id]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPrivateEndpointDNSZoneGroupRule(),
+					Message: "The `blob` subresource resolves through the \"privatelink.blob.core.windows.net\" zone, but the zone group references \"privatelink.file.core.windows.net\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 12, Column: 28},
+						End:      hcl.Pos{Line: 12, Column: 62},
+					},
+				},
+			},
+		},
+		{
+			Name: "Zone group referencing the right zone by ID",
+			Content: `
+resource "azurerm_private_endpoint" "blob" {
+  private_service_connection {
+    subresource_names = ["blob"]
+  }
+
+  private_dns_zone_group {
+    private_dns_zone_ids = ["/subscriptions/0000/resourceGroups/dns/providers/Microsoft.Network/privateDnsZones/privatelink.blob.core.windows.net"]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_private_endpoint_dns_zone_group" {
+  enabled = true
+}`
+	rule := NewAzurermPrivateEndpointDNSZoneGroupRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,130 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermPrivatednsZoneLinksRequiredRule checks that private DNS zones are linked to a virtual network
+type AzurermPrivatednsZoneLinksRequiredRule struct {
+	tflint.DefaultRule
+
+	zoneResourceType string
+	linkResourceType string
+}
+
+type azurermPrivatednsZoneLinksRequiredRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermPrivatednsZoneLinksRequiredRule returns new rule with default attributes
+func NewAzurermPrivatednsZoneLinksRequiredRule() *AzurermPrivatednsZoneLinksRequiredRule {
+	return &AzurermPrivatednsZoneLinksRequiredRule{
+		zoneResourceType: "azurerm_private_dns_zone",
+		linkResourceType: "azurerm_private_dns_zone_virtual_network_link",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermPrivatednsZoneLinksRequiredRule) Name() string {
+	return "azurerm_privatedns_zone_links_required"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermPrivatednsZoneLinksRequiredRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermPrivatednsZoneLinksRequiredRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermPrivatednsZoneLinksRequiredRule) Link() string {
+	return ""
+}
+
+// Check checks every private DNS zone is referenced by a virtual network link
+func (r *AzurermPrivatednsZoneLinksRequiredRule) Check(runner tflint.Runner) error {
+	config := azurermPrivatednsZoneLinksRequiredRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	links, err := runner.GetResourceContent(r.linkResourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "private_dns_zone_name"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// Zones can be linked by reference or by their literal name
+	linked := map[string]bool{}
+	linkedByName := false
+	for _, link := range links.Blocks {
+		attribute, exists := link.Body.Attributes["private_dns_zone_name"]
+		if !exists {
+			continue
+		}
+		if addresses := referencedResources(attribute.Expr); len(addresses) > 0 {
+			for _, address := range addresses {
+				linked[address] = true
+			}
+			continue
+		}
+
+		linkedByName = true
+		var name string
+		err := runner.EvaluateExpr(attribute.Expr, &name, nil)
+		err = runner.EnsureNoError(err, func() error {
+			linked["name:"+name] = true
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	zones, err := runner.GetResourceContent(r.zoneResourceType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "name"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones.Blocks {
+		address := zone.Labels[0] + "." + zone.Labels[1]
+		if linked[address] {
+			continue
+		}
+
+		name := ""
+		if attribute, exists := zone.Body.Attributes["name"]; exists {
+			err := runner.EvaluateExpr(attribute.Expr, &name, nil)
+			// A zone with an unknown name may be the one a link names, so it is only reported without such links
+			if errors.Is(err, tflint.ErrUnknownValue) && linkedByName {
+				continue
+			}
+			err = runner.EnsureNoError(err, func() error { return nil })
+			if err != nil {
+				return err
+			}
+		}
+		if name != "" && linked["name:"+name] {
+			continue
+		}
+
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is not linked to any virtual network, add an `%s` so private endpoints resolve", address, r.linkResourceType),
+			zone.DefRange,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermPrivatednsZoneLinksRequired(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Zone linked by reference",
+			Content: `
+resource "azurerm_private_dns_zone" "blob" {
+  name = "privatelink.blob.core.windows.net"
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "blob" {
+  private_dns_zone_name = azurerm_private_dns_zone.blob.name
+  virtual_network_id    = azurerm_virtual_network.hub.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Zone linked by literal name",
+			Content: `
+resource "azurerm_private_dns_zone" "blob" {
+  name = "privatelink.blob.core.windows.net"
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "blob" {
+  private_dns_zone_name = "privatelink.blob.core.windows.net"
+  virtual_network_id    = azurerm_virtual_network.hub.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Unlinked zone",
+			Content: `
+resource "azurerm_private_dns_zone" "blob" {
+  name = "privatelink.blob.core.windows.net"
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "blob" {
+  private_dns_zone_name = "privatelink.vaultcore.azure.net"
+  virtual_network_id    = azurerm_virtual_network.hub.id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPrivatednsZoneLinksRequiredRule(),
+					Message: "`azurerm_private_dns_zone.blob` is not linked to any virtual network, add an `azurerm_private_dns_zone_virtual_network_link` so private endpoints resolve",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 43},
+					},
+				},
+			},
+		},
+		{
+			Name: "Zone with an unknown name linked by name",
+			Content: `
+resource "azurerm_private_dns_zone" "blob" {
+  name = data.azurerm_private_dns_zone.shared.name
+}
+
+resource "azurerm_private_dns_zone_virtual_network_link" "blob" {
+  private_dns_zone_name = "privatelink.blob.core.windows.net"
+  virtual_network_id    = azurerm_virtual_network.hub.id
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Zone with an unknown name without links",
+			Content: `
+resource "azurerm_private_dns_zone" "blob" {
+  name = data.azurerm_private_dns_zone.shared.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermPrivatednsZoneLinksRequiredRule(),
+					Message: "`azurerm_private_dns_zone.blob` is not linked to any virtual network, add an `azurerm_private_dns_zone_virtual_network_link` so private endpoints resolve",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 43},
+					},
+				},
+			},
+		},
+	}
+
+	config := `
+rule "azurerm_privatedns_zone_links_required" {
+  enabled = true
+}`
+	rule := NewAzurermPrivatednsZoneLinksRequiredRule()
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermProviderFeaturesBlockHardeningRule checks the settings of the azurerm provider `features` block against policy
+type AzurermProviderFeaturesBlockHardeningRule struct {
+	tflint.DefaultRule
+
+	defaultSettings           map[string]bool
+	defaultProductionSettings map[string]bool
+	defaultPaths              []string
+	// providerDefaults are the values the provider uses for settings that are not set
+	providerDefaults map[string]bool
+}
+
+type azurermProviderFeaturesBlockHardeningRuleConfig struct {
+	Settings           map[string]bool `hclext:"settings,optional"`
+	ProductionSettings map[string]bool `hclext:"production_settings,optional"`
+	Paths              []string        `hclext:"paths,optional"`
+	Enforce            *bool           `hclext:"enforce,optional"`
+}
+
+// NewAzurermProviderFeaturesBlockHardeningRule returns new rule with default attributes
+func NewAzurermProviderFeaturesBlockHardeningRule() *AzurermProviderFeaturesBlockHardeningRule {
+	return &AzurermProviderFeaturesBlockHardeningRule{
+		defaultSettings: map[string]bool{
+			"resource_group.prevent_deletion_if_contains_resources": true,
+		},
+		defaultProductionSettings: map[string]bool{
+			"key_vault.purge_soft_delete_on_destroy": false,
+		},
+		defaultPaths: []string{"*prod*"},
+		providerDefaults: map[string]bool{
+			"key_vault.purge_soft_delete_on_destroy":                true,
+			"key_vault.recover_soft_deleted_key_vaults":             true,
+			"resource_group.prevent_deletion_if_contains_resources": true,
+			"virtual_machine.delete_os_disk_on_deletion":            true,
+			"log_analytics_workspace.permanently_delete_on_destroy": false,
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermProviderFeaturesBlockHardeningRule) Name() string {
+	return "azurerm_provider_features_block_hardening"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermProviderFeaturesBlockHardeningRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermProviderFeaturesBlockHardeningRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermProviderFeaturesBlockHardeningRule) Link() string {
+	return ""
+}
+
+// Check checks every azurerm provider configuration sets its `features` as the policy requires,
+// with stricter settings for providers declared in production paths
+func (r *AzurermProviderFeaturesBlockHardeningRule) Check(runner tflint.Runner) error {
+	config := azurermProviderFeaturesBlockHardeningRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	settings := r.defaultSettings
+	if config.Settings != nil {
+		settings = config.Settings
+	}
+	productionSettings := r.defaultProductionSettings
+	if config.ProductionSettings != nil {
+		productionSettings = config.ProductionSettings
+	}
+	paths := r.defaultPaths
+	if len(config.Paths) > 0 {
+		paths = config.Paths
+	}
+	for _, pattern := range paths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("paths: invalid glob %q: %s", pattern, err)
+		}
+	}
+
+	// Group the settings by their block inside `features` to build the schema
+	blocks := []string{}
+	attributes := map[string][]hclext.AttributeSchema{}
+	for _, policy := range []map[string]bool{settings, productionSettings} {
+		for _, setting := range sortedKeys(policy) {
+			block, attribute, ok := splitFeatureSetting(setting)
+			if !ok {
+				return fmt.Errorf("`%s` is not a valid setting of the `%s` rule, expected `block.attribute`", setting, r.Name())
+			}
+			if _, exists := attributes[block]; !exists {
+				blocks = append(blocks, block)
+			}
+			if !hasAttribute(&hclext.BodySchema{Attributes: attributes[block]}, attribute) {
+				attributes[block] = append(attributes[block], hclext.AttributeSchema{Name: attribute})
+			}
+		}
+	}
+	features := &hclext.BodySchema{}
+	for _, block := range blocks {
+		features.Blocks = append(features.Blocks, hclext.BlockSchema{Type: block, Body: &hclext.BodySchema{Attributes: attributes[block]}})
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "provider",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "alias"}},
+					Blocks:     []hclext.BlockSchema{{Type: "features", Body: features}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range content.Blocks {
+		if provider.Labels[0] != "azurerm" {
+			continue
+		}
+
+		required := map[string]bool{}
+		for setting, value := range settings {
+			required[setting] = value
+		}
+		if productionPath(provider.DefRange.Filename, paths) {
+			for setting, value := range productionSettings {
+				required[setting] = value
+			}
+		}
+
+		for _, feature := range provider.Body.Blocks {
+			for _, setting := range sortedKeys(required) {
+				if err := r.checkSetting(runner, feature, setting, required[setting]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkSetting checks a single `block.attribute` setting of the `features` block, falling back to the provider default when unset
+func (r *AzurermProviderFeaturesBlockHardeningRule) checkSetting(runner tflint.Runner, features *hclext.Block, setting string, want bool) error {
+	blockType, name, _ := splitFeatureSetting(setting)
+
+	for _, block := range features.Body.Blocks {
+		if block.Type != blockType {
+			continue
+		}
+		attribute, exists := block.Body.Attributes[name]
+		if !exists {
+			break
+		}
+
+		var val bool
+		err := evaluateBool(runner, attribute.Expr, &val)
+		return runner.EnsureNoError(err, func() error {
+			if val != want {
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("`features.%s` is set to %t, the policy requires %t", setting, val, want),
+					attribute.Expr.Range(),
+				)
+			}
+			return nil
+		})
+	}
+
+	if defaultValue, known := r.providerDefaults[setting]; known && defaultValue == want {
+		return nil
+	}
+	runner.EmitIssue(
+		r,
+		fmt.Sprintf("`features.%s` is not set, the policy requires %t", setting, want),
+		features.DefRange,
+	)
+	return nil
+}
+
+// splitFeatureSetting splits a `block.attribute` setting of the `features` block
+func splitFeatureSetting(setting string) (string, string, bool) {
+	parts := strings.Split(setting, ".")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermProviderFeaturesBlockHardening(t *testing.T) {
+	content := `
+provider "azurerm" {
+  features {
+    resource_group {
+      prevent_deletion_if_contains_resources = false
+    }
+  }
+}
+
+provider "azurerm" {
+  alias = "hub"
+
+  features {
+    key_vault {
+      purge_soft_delete_on_destroy = false
+    }
+  }
+}
+
+provider "azuread" {}`
+
+	cases := []struct {
+		Name     string
+		Filename string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name:     "Non-production path",
+			Filename: "dev.tf",
+			Config: `
+rule "azurerm_provider_features_block_hardening" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFeaturesBlockHardeningRule(),
+					Message: "`features.resource_group.prevent_deletion_if_contains_resources` is set to false, the policy requires true",
+					Range: hcl.Range{
+						Filename: "dev.tf",
+						Start:    hcl.Pos{Line: 5, Column: 48},
+						End:      hcl.Pos{Line: 5, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name:     "Production path",
+			Filename: "prod.tf",
+			Config: `
+rule "azurerm_provider_features_block_hardening" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFeaturesBlockHardeningRule(),
+					Message: "`features.key_vault.purge_soft_delete_on_destroy` is not set, the policy requires false",
+					Range: hcl.Range{
+						Filename: "prod.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 11},
+					},
+				},
+				{
+					Rule:    NewAzurermProviderFeaturesBlockHardeningRule(),
+					Message: "`features.resource_group.prevent_deletion_if_contains_resources` is set to false, the policy requires true",
+					Range: hcl.Range{
+						Filename: "prod.tf",
+						Start:    hcl.Pos{Line: 5, Column: 48},
+						End:      hcl.Pos{Line: 5, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name:     "Custom settings",
+			Filename: "main.tf",
+			Config: `
+rule "azurerm_provider_features_block_hardening" {
+  enabled = true
+  settings = {
+    "key_vault.purge_soft_delete_on_destroy" = true
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermProviderFeaturesBlockHardeningRule(),
+					Message: "`features.key_vault.purge_soft_delete_on_destroy` is set to false, the policy requires true",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 38},
+						End:      hcl.Pos{Line: 15, Column: 43},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermProviderFeaturesBlockHardeningRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{tc.Filename: content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
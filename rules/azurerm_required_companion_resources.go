@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermRequiredCompanionResourcesRule checks that resources are referenced by the companion resources
+// declared in its config
+type AzurermRequiredCompanionResourcesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermRequiredCompanionResourcesRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+
+	Companions []companionRequirement `hclext:"companion,block"`
+}
+
+// companionRequirement requires every resource of a type to be referenced by a companion resource
+// in one of the attributes, such as the `target_resource_id` of a diagnostic setting
+type companionRequirement struct {
+	Resource   string   `hclext:"resource"`
+	Companion  string   `hclext:"companion"`
+	Attributes []string `hclext:"attributes"`
+	// Message explains the requirement in the issues, e.g. a link to the policy
+	Message string `hclext:"message,optional"`
+}
+
+// NewAzurermRequiredCompanionResourcesRule returns new rule with default attributes
+func NewAzurermRequiredCompanionResourcesRule() *AzurermRequiredCompanionResourcesRule {
+	return &AzurermRequiredCompanionResourcesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermRequiredCompanionResourcesRule) Name() string {
+	return "azurerm_required_companion_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermRequiredCompanionResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermRequiredCompanionResourcesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermRequiredCompanionResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks every `companion` block of the config, reporting the resources of its type that no companion
+// resource references in the attributes, so that coverage policies such as diagnostic settings for every key vault
+// can be declared without a dedicated rule
+func (r *AzurermRequiredCompanionResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermRequiredCompanionResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, companion := range config.Companions {
+		if len(companion.Attributes) == 0 {
+			return fmt.Errorf("companion: `attributes` of the `%s` companion of `%s` is empty", companion.Companion, companion.Resource)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, companion := range config.Companions {
+		referenced, err := referencedByResources(runner, map[string][]string{companion.Companion: companion.Attributes})
+		if err != nil {
+			return err
+		}
+
+		resources, err := runner.GetResourceContent(companion.Resource, &hclext.BodySchema{}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			address := resource.Labels[0] + "." + resource.Labels[1]
+			if referenced[address] {
+				continue
+			}
+
+			message := fmt.Sprintf("`%s` is not referenced by any `%s` in `%s`", address, companion.Companion, strings.Join(companion.Attributes, "` or `"))
+			if companion.Message != "" {
+				message = fmt.Sprintf("%s: %s", message, companion.Message)
+			}
+			if err := runner.EmitIssue(r, message, resource.DefRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
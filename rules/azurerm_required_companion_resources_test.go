@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermRequiredCompanionResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Resources without companions",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  name = "kv-main"
+}
+
+resource "azurerm_key_vault" "shared" {
+  name = "kv-shared"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "main" {
+  target_resource_id = azurerm_key_vault.main.id
+}
+
+resource "azurerm_storage_account" "logs" {
+  name = "stlogs"
+}`,
+			Config: `
+rule "azurerm_required_companion_resources" {
+  enabled = true
+
+  companion {
+    resource   = "azurerm_key_vault"
+    companion  = "azurerm_monitor_diagnostic_setting"
+    attributes = ["target_resource_id"]
+    message    = "audit logs of key vaults must be collected"
+  }
+
+  companion {
+    resource   = "azurerm_storage_account"
+    companion  = "azurerm_management_lock"
+    attributes = ["scope"]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermRequiredCompanionResourcesRule(),
+					Message: "`azurerm_key_vault.shared` is not referenced by any `azurerm_monitor_diagnostic_setting` in `target_resource_id`: audit logs of key vaults must be collected",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 38},
+					},
+				},
+				{
+					Rule:    NewAzurermRequiredCompanionResourcesRule(),
+					Message: "`azurerm_storage_account.logs` is not referenced by any `azurerm_management_lock` in `scope`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 14, Column: 1},
+						End:      hcl.Pos{Line: 14, Column: 42},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermRequiredCompanionResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,157 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermResourceAttributeRequirementsRule checks resources against the attribute requirements declared in its config
+type AzurermResourceAttributeRequirementsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermResourceAttributeRequirementsRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+
+	Requirements []attributeRequirement `hclext:"requirement,block"`
+}
+
+// attributeRequirement requires resources of a type to set an attribute, optionally to one of the values
+// or to a value matching the pattern
+type attributeRequirement struct {
+	Resource string `hclext:"resource"`
+	// Attribute is the attribute name, or a path through nested blocks such as "network_rules.default_action"
+	Attribute string   `hclext:"attribute"`
+	Values    []string `hclext:"values,optional"`
+	Pattern   string   `hclext:"pattern,optional"`
+	// Message explains the requirement in the issues, e.g. a link to the policy
+	Message string `hclext:"message,optional"`
+
+	pattern *regexp.Regexp
+}
+
+// NewAzurermResourceAttributeRequirementsRule returns new rule with default attributes
+func NewAzurermResourceAttributeRequirementsRule() *AzurermResourceAttributeRequirementsRule {
+	return &AzurermResourceAttributeRequirementsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceAttributeRequirementsRule) Name() string {
+	return "azurerm_resource_attribute_requirements"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceAttributeRequirementsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceAttributeRequirementsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceAttributeRequirementsRule) Link() string {
+	return ""
+}
+
+// Check checks every `requirement` block of the config against the resources of its type,
+// so that simple organization policies can be enforced without a dedicated rule
+func (r *AzurermResourceAttributeRequirementsRule) Check(runner tflint.Runner) error {
+	config := azurermResourceAttributeRequirementsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for i, requirement := range config.Requirements {
+		if requirement.Pattern == "" {
+			continue
+		}
+		pattern, err := compilePattern(requirement.Pattern)
+		if err != nil {
+			return fmt.Errorf("requirement: invalid pattern %q for `%s.%s`: %s", requirement.Pattern, requirement.Resource, requirement.Attribute, err)
+		}
+		config.Requirements[i].pattern = pattern
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	for _, requirement := range config.Requirements {
+		path := strings.Split(requirement.Attribute, ".")
+		resources, err := runner.GetResourceContent(requirement.Resource, attributePathSchema(path), nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := attributeAtPath(resource.Body, path)
+			if !exists {
+				if err := runner.EmitIssue(r, requirement.message(resource, fmt.Sprintf("does not set `%s`", requirement.Attribute)), resource.DefRange); err != nil {
+					return err
+				}
+				continue
+			}
+			if requirement.Values == nil && requirement.pattern == nil {
+				continue
+			}
+
+			var value string
+			wantType := cty.String
+			err := runner.EvaluateExpr(attribute.Expr, &value, &tflint.EvaluateExprOption{WantType: &wantType})
+			err = runner.EnsureNoError(err, func() error {
+				var problem string
+				switch {
+				case requirement.Values != nil && !stringInSlice(value, requirement.Values):
+					problem = fmt.Sprintf("sets `%s` to \"%s\", which is not one of %s", requirement.Attribute, value, quoteAll(requirement.Values))
+				case requirement.pattern != nil && !requirement.pattern.MatchString(value):
+					problem = fmt.Sprintf("sets `%s` to \"%s\", which does not match the pattern `%s`", requirement.Attribute, value, requirement.Pattern)
+				default:
+					return nil
+				}
+				return runner.EmitIssue(r, requirement.message(resource, problem), attribute.Expr.Range())
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// message returns the issue message for the resource, followed by the message of the requirement if any
+func (q attributeRequirement) message(resource *hclext.Block, problem string) string {
+	message := fmt.Sprintf("`%s.%s` %s", resource.Labels[0], resource.Labels[1], problem)
+	if q.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, q.Message)
+	}
+	return message
+}
+
+// attributePathSchema returns the schema of the nested blocks along the path with the attribute at its end
+func attributePathSchema(path []string) *hclext.BodySchema {
+	if len(path) == 1 {
+		return &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: path[0]}}}
+	}
+	return &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: path[0], Body: attributePathSchema(path[1:])}},
+	}
+}
+
+// attributeAtPath returns the attribute at the end of the path through nested blocks,
+// which is looked up in the first block of each type
+func attributeAtPath(body *hclext.BodyContent, path []string) (*hclext.Attribute, bool) {
+	if len(path) == 1 {
+		attribute, exists := body.Attributes[path[0]]
+		return attribute, exists
+	}
+	for _, block := range body.Blocks {
+		if block.Type == path[0] {
+			return attributeAtPath(block.Body, path[1:])
+		}
+	}
+	return nil, false
+}
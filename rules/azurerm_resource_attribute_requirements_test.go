@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceAttributeRequirements(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Values, patterns and nested blocks",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  min_tls_version = "TLS1_0"
+
+  network_rules {
+    default_action = "Allow"
+  }
+}
+
+resource "azurerm_storage_account" "data" {
+  min_tls_version = "TLS1_2"
+}
+
+resource "azurerm_key_vault" "main" {
+  sku_name                  = "standard"
+  enable_rbac_authorization = true
+}`,
+			Config: `
+rule "azurerm_resource_attribute_requirements" {
+  enabled = true
+
+  requirement {
+    resource  = "azurerm_storage_account"
+    attribute = "min_tls_version"
+    values    = ["TLS1_2"]
+  }
+
+  requirement {
+    resource  = "azurerm_storage_account"
+    attribute = "network_rules.default_action"
+    values    = ["Deny"]
+    message   = "storage accounts must deny public network access by default"
+  }
+
+  requirement {
+    resource  = "azurerm_key_vault"
+    attribute = "sku_name"
+    pattern   = "^premium$"
+  }
+
+  requirement {
+    resource  = "azurerm_key_vault"
+    attribute = "enable_rbac_authorization"
+    values    = ["true"]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceAttributeRequirementsRule(),
+					Message: "`azurerm_storage_account.logs` sets `min_tls_version` to \"TLS1_0\", which is not one of \"TLS1_2\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 21},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceAttributeRequirementsRule(),
+					Message: "`azurerm_storage_account.logs` sets `network_rules.default_action` to \"Allow\", which is not one of \"Deny\": storage accounts must deny public network access by default",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 22},
+						End:      hcl.Pos{Line: 6, Column: 29},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceAttributeRequirementsRule(),
+					Message: "`azurerm_storage_account.data` does not set `network_rules.default_action`: storage accounts must deny public network access by default",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 42},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceAttributeRequirementsRule(),
+					Message: "`azurerm_key_vault.main` sets `sku_name` to \"standard\", which does not match the pattern `^premium$`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 15, Column: 31},
+						End:      hcl.Pos{Line: 15, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "No requirements",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  min_tls_version = "TLS1_0"
+}`,
+			Config: `
+rule "azurerm_resource_attribute_requirements" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermResourceAttributeRequirementsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermResourceGroupNotEmptyRule checks that every resource group declared in a module is used by another resource
+type AzurermResourceGroupNotEmptyRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+}
+
+type azurermResourceGroupNotEmptyRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermResourceGroupNotEmptyRule returns new rule with default attributes
+func NewAzurermResourceGroupNotEmptyRule() *AzurermResourceGroupNotEmptyRule {
+	return &AzurermResourceGroupNotEmptyRule{
+		resourceType: "azurerm_resource_group",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceGroupNotEmptyRule) Name() string {
+	return "azurerm_resource_group_not_empty"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceGroupNotEmptyRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceGroupNotEmptyRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceGroupNotEmptyRule) Link() string {
+	return ""
+}
+
+// Check checks resource groups are referenced by the `resource_group_name` of another resource or passed to a module
+func (r *AzurermResourceGroupNotEmptyRule) Check(runner tflint.Runner) error {
+	config := azurermResourceGroupNotEmptyRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "resource_group_name"}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	referenced := map[string]bool{}
+	groups := hclext.Blocks{}
+	for _, resource := range content.Blocks {
+		if resource.Labels[0] == r.resourceType {
+			groups = append(groups, resource)
+			continue
+		}
+		if attribute, exists := resource.Body.Attributes["resource_group_name"]; exists {
+			for _, address := range referencedResources(attribute.Expr) {
+				referenced[address] = true
+			}
+		}
+	}
+	if len(groups) == 0 {
+		return nil
+	}
+
+	// Module inputs have arbitrary names, so references are collected from the source of module calls
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedFileNames(files) {
+		for _, block := range fileBlocks(files[name], "module", "name") {
+			for _, attribute := range blockAttributes(block.Body) {
+				for _, address := range referencedResources(attribute.Expr) {
+					referenced[address] = true
+				}
+			}
+		}
+	}
+
+	for _, group := range groups {
+		address := group.Labels[0] + "." + group.Labels[1]
+		if referenced[address] {
+			continue
+		}
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("`%s` is not referenced by the `resource_group_name` of any resource in the module, it may be a leftover from a refactor", address),
+			group.DefRange,
+		)
+	}
+
+	return nil
+}
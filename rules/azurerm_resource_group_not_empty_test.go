@@ -0,0 +1,68 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceGroupNotEmpty(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unused resource group",
+			Content: `
+resource "azurerm_resource_group" "used" {
+  name = "used"
+}
+
+resource "azurerm_resource_group" "passed" {
+  name = "passed"
+}
+
+resource "azurerm_resource_group" "leftover" {
+  name = "leftover"
+}
+
+resource "azurerm_storage_account" "main" {
+  resource_group_name = azurerm_resource_group.used.name
+}
+
+module "network" {
+  source = "./network"
+  rg     = azurerm_resource_group.passed.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceGroupNotEmptyRule(),
+					Message: "`azurerm_resource_group.leftover` is not referenced by the `resource_group_name` of any resource in the module, it may be a leftover from a refactor",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 45},
+					},
+				},
+			},
+		},
+	}
+
+	config := `
+rule "azurerm_resource_group_not_empty" {
+  enabled = true
+}`
+	rule := NewAzurermResourceGroupNotEmptyRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
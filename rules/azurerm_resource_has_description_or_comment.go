@@ -0,0 +1,153 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermResourceHasDescriptionOrCommentRule checks that configurable resources document their intent
+type AzurermResourceHasDescriptionOrCommentRule struct {
+	tflint.DefaultRule
+
+	// defaultResourceTypes maps the checked resource types to whether they have a `description` argument
+	defaultResourceTypes map[string]bool
+}
+
+type azurermResourceHasDescriptionOrCommentRuleConfig struct {
+	ResourceTypes []string `hclext:"resource_types,optional"`
+	Enforce       *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermResourceHasDescriptionOrCommentRule returns new rule with default attributes
+func NewAzurermResourceHasDescriptionOrCommentRule() *AzurermResourceHasDescriptionOrCommentRule {
+	return &AzurermResourceHasDescriptionOrCommentRule{
+		defaultResourceTypes: map[string]bool{
+			"azurerm_policy_definition":                      true,
+			"azurerm_policy_set_definition":                  true,
+			"azurerm_management_group_policy_assignment":     true,
+			"azurerm_subscription_policy_assignment":         true,
+			"azurerm_resource_group_policy_assignment":       true,
+			"azurerm_resource_policy_assignment":             true,
+			"azurerm_role_definition":                        true,
+			"azurerm_monitor_action_group":                   false,
+			"azurerm_monitor_metric_alert":                   true,
+			"azurerm_monitor_activity_log_alert":             true,
+			"azurerm_monitor_scheduled_query_rules_alert_v2": true,
+			"azurerm_network_security_rule":                  true,
+			"azurerm_management_lock":                        false,
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceHasDescriptionOrCommentRule) Name() string {
+	return "azurerm_resource_has_description_or_comment"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceHasDescriptionOrCommentRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceHasDescriptionOrCommentRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceHasDescriptionOrCommentRule) Link() string {
+	return ""
+}
+
+// Check checks that policies, role definitions, alerts and similar resources have a non-empty `description`
+// or a comment just above the block, so that auditors can trace why they exist
+func (r *AzurermResourceHasDescriptionOrCommentRule) Check(runner tflint.Runner) error {
+	config := azurermResourceHasDescriptionOrCommentRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resourceTypes := make([]string, 0, len(r.defaultResourceTypes))
+	for resourceType := range r.defaultResourceTypes {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	if len(config.ResourceTypes) > 0 {
+		resourceTypes = config.ResourceTypes
+	}
+
+	comments := map[string]map[int]bool{}
+	for _, resourceType := range resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "description"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			if attribute, exists := resource.Body.Attributes["description"]; exists {
+				if description, ok := literalString(attribute.Expr); !ok || strings.TrimSpace(description) != "" {
+					continue
+				}
+			}
+
+			filename := resource.DefRange.Filename
+			if _, lexed := comments[filename]; !lexed {
+				file, err := runner.GetFile(filename)
+				if err != nil {
+					return err
+				}
+				comments[filename] = commentedLines(file, filename)
+			}
+			if comments[filename][resource.DefRange.Start.Line] {
+				continue
+			}
+
+			missing := "leading comment"
+			if r.defaultResourceTypes[resourceType] || len(config.ResourceTypes) > 0 {
+				missing = "`description` or leading comment"
+			}
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` has no %s, add one explaining why the resource exists so that auditors can trace its intent", resource.Labels[0], resource.Labels[1], missing),
+				resource.DefRange,
+			)
+		}
+	}
+
+	return nil
+}
+
+// commentedLines returns the lines of the file directly preceded by a comment, without a blank line in between.
+// Comments are only available in the native syntax, so no line is commented in JSON files.
+func commentedLines(file *hcl.File, filename string) map[int]bool {
+	lines := map[int]bool{}
+	if file == nil || strings.HasSuffix(filename, ".json") {
+		return lines
+	}
+
+	tokens, _ := hclsyntax.LexConfig(file.Bytes, filename, hcl.InitialPos)
+	for i, token := range tokens {
+		if token.Type != hclsyntax.TokenComment || i+1 >= len(tokens) {
+			continue
+		}
+		// Line comments include their newline, while block comments are followed by a newline token
+		next := tokens[i+1]
+		if next.Type == hclsyntax.TokenNewline {
+			if strings.HasSuffix(string(token.Bytes), "\n") || i+2 >= len(tokens) {
+				continue
+			}
+			next = tokens[i+2]
+		}
+		lines[next.Range.Start.Line] = true
+	}
+	return lines
+}
@@ -0,0 +1,106 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceHasDescriptionOrComment(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Undocumented resources",
+			Content: `
+resource "azurerm_policy_definition" "deny_public_ip" {
+  description = ""
+}
+
+# Pages the on-call engineer
+
+resource "azurerm_monitor_action_group" "oncall" {}`,
+			Config: `
+rule "azurerm_resource_has_description_or_comment" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceHasDescriptionOrCommentRule(),
+					Message: "`azurerm_monitor_action_group.oncall` has no leading comment, add one explaining why the resource exists so that auditors can trace its intent",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 49},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceHasDescriptionOrCommentRule(),
+					Message: "`azurerm_policy_definition.deny_public_ip` has no `description` or leading comment, add one explaining why the resource exists so that auditors can trace its intent",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 54},
+					},
+				},
+			},
+		},
+		{
+			Name: "Documented resources",
+			Content: `
+resource "azurerm_role_definition" "reader" {
+  description = var.description
+}
+
+# Pages the on-call engineer
+resource "azurerm_monitor_action_group" "oncall" {}
+
+/* Locks the shared DNS zones */
+resource "azurerm_management_lock" "dns" {}`,
+			Config: `
+rule "azurerm_resource_has_description_or_comment" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Custom resource types",
+			Content: `
+resource "azurerm_policy_definition" "deny_public_ip" {}
+
+resource "azurerm_key_vault" "main" {}`,
+			Config: `
+rule "azurerm_resource_has_description_or_comment" {
+  enabled        = true
+  resource_types = ["azurerm_key_vault"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceHasDescriptionOrCommentRule(),
+					Message: "`azurerm_key_vault.main` has no `description` or leading comment, add one explaining why the resource exists so that auditors can trace its intent",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 36},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceHasDescriptionOrCommentRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -3,15 +3,19 @@
 package rules
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
-	"github.com/zclconf/go-cty/cty"
 )
 
 // AzurermResourceMissingTagsRule checks whether resources are tagged correctly
@@ -20,14 +24,56 @@ type AzurermResourceMissingTagsRule struct {
 }
 
 type azurermResourceTagsRuleConfig struct {
-	Tags    []string `hclext:"tags"`
-	Exclude []string `hclext:"exclude,optional"`
+	Tags     []string            `hclext:"tags,optional"`
+	Values   map[string][]string `hclext:"values,optional"`
+	Patterns map[string]string   `hclext:"patterns,optional"`
+	Defaults map[string]string   `hclext:"defaults,optional"`
+	Exclude  []string            `hclext:"exclude,optional"`
+	// ExcludeResources lists resource addresses or address globs, e.g. "azurerm_storage_account.tmp_*"
+	ExcludeResources  []string `hclext:"exclude_resources,optional"`
+	UnknownTagsAction string   `hclext:"unknown_tags_action,optional"`
+	ExemptTag         string   `hclext:"exempt_tag,optional"`
+	IssuePerTag       bool     `hclext:"issue_per_tag,optional"`
+	NestedLocations   bool     `hclext:"nested_locations,optional"`
+	// CustomResources maps the resource types of other providers to the attribute holding their tags,
+	// e.g. { databricks_cluster = "custom_tags" }, which are checked along with the azurerm resource types
+	CustomResources map[string]string `hclext:"custom_resources,optional"`
+	// DiscoverResources also checks declared azurerm resource types that are missing from the generated resource list
+	DiscoverResources bool   `hclext:"discover_resources,optional"`
+	Severity          string `hclext:"severity,optional"`
+	// MaxNestingDepth bounds how many merge() calls and local values the tags are built from, defaulting to 5
+	MaxNestingDepth int `hclext:"max_nesting_depth,optional"`
+	// Workers is the number of resource types checked concurrently, which are checked one at a time by default
+	Workers int `hclext:"workers,optional"`
+	// Message is a template for the missing tags message with the {address}, {type}, {name} and {tags} placeholders
+	Message string `hclext:"message,optional"`
+	Enforce *bool  `hclext:"enforce,optional"`
+
+	Exemptions    []ruleExemption          `hclext:"exemption,block"`
+	ResourceGroup *resourceGroupTagsConfig `hclext:"resource_group,block"`
+}
+
+// resourceGroupTagsConfig lists tags required on resource groups in addition to the tags required on every resource,
+// since resource groups are the root that tag inheritance policies copy tags from
+type resourceGroupTagsConfig struct {
+	Tags []string `hclext:"tags"`
 }
 
 const (
 	tagsAttributeName = "tags"
+	// defaultExemptTag is the tag that waives the rule for a resource in code, with the reason as its value
+	defaultExemptTag = "tflint_exempt"
+	// defaultMaxNestingDepth is the default of `max_nesting_depth`
+	defaultMaxNestingDepth = 5
 )
 
+// unknownTagsActions are the severities of the issue reported for tags that are unknown at lint time,
+// such as tags computed from a data source. Unknown tags are not reported with the "ignore" action, the default.
+var unknownTagsActions = map[string]tflint.Severity{
+	"warn":  tflint.WARNING,
+	"error": tflint.ERROR,
+}
+
 // NewAzurermResourceMissingTagsRule returns new rules for all resources that support tags
 func NewAzurermResourceMissingTagsRule() *AzurermResourceMissingTagsRule {
 	return &AzurermResourceMissingTagsRule{}
@@ -57,60 +103,513 @@ func (r *AzurermResourceMissingTagsRule) Link() string {
 // Check checks resources for missing tags
 func (r *AzurermResourceMissingTagsRule) Check(runner tflint.Runner) error {
 	config := azurermResourceTagsRuleConfig{}
-	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Tags == nil {
+		return fmt.Errorf("`tags` is not set for the `%s` rule in .tflint.hcl or the base config", r.Name())
+	}
+	if err := validateExemptions(config.Exemptions); err != nil {
+		return err
+	}
+	if config.ExemptTag == "" {
+		config.ExemptTag = defaultExemptTag
+	}
+	if config.UnknownTagsAction == "" {
+		config.UnknownTagsAction = "ignore"
+	}
+	if _, valid := unknownTagsActions[config.UnknownTagsAction]; !valid && config.UnknownTagsAction != "ignore" {
+		return fmt.Errorf("unknown_tags_action: \"%s\" is not a valid action, expected one of \"ignore\", \"warn\", \"error\"", config.UnknownTagsAction)
+	}
+	if config.MaxNestingDepth == 0 {
+		config.MaxNestingDepth = defaultMaxNestingDepth
+	}
+	if config.MaxNestingDepth < 0 || config.MaxNestingDepth > maxLocalDepth {
+		return fmt.Errorf("max_nesting_depth: %d is out of range, expected a depth from 1 to %d", config.MaxNestingDepth, maxLocalDepth)
+	}
+	if config.Workers < 0 || config.Workers > maxWorkers {
+		return fmt.Errorf("workers: %d is out of range, expected from 1 to %d workers", config.Workers, maxWorkers)
+	}
+	for resourceType, attribute := range config.CustomResources {
+		if !hclsyntax.ValidIdentifier(attribute) {
+			return fmt.Errorf("custom_resources: \"%s\" is not a valid attribute name for `%s`", attribute, resourceType)
+		}
+	}
+	for _, pattern := range config.ExcludeResources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exclude_resources: invalid glob %q: %s", pattern, err)
+		}
+	}
+	patterns := make(map[string]*regexp.Regexp, len(config.Patterns))
+	for tag, source := range config.Patterns {
+		pattern, err := compilePattern(source)
+		if err != nil {
+			return fmt.Errorf("patterns: invalid pattern %q for the \"%s\" tag: %s", source, tag, err)
+		}
+		patterns[tag] = pattern
+	}
+	runner, err := withSeverity(runner, r, config.Severity)
+	if err != nil {
 		return err
 	}
+	runner = withEnforcement(runner, config.Enforce)
 
-	for _, resourceType := range Resources {
-		// Skip this resource if its type is excluded in configuration
-		if stringInSlice(resourceType, config.Exclude) {
+	// Stale exemptions are reported once each, whether or not resources of their type are declared
+	for _, exemption := range config.Exemptions {
+		if expired, _ := exemption.expired(); !expired {
 			continue
 		}
-
-		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
-			Attributes: []hclext.AttributeSchema{{Name: tagsAttributeName}},
-		}, nil)
+		location, err := ruleConfigBlockRange(runner, r.Name(), "exemption", "resource", exemption.Resource)
 		if err != nil {
 			return err
 		}
+		runner.EmitIssue(r, exemption.message(), location)
+	}
 
-		for _, resource := range resources.Blocks {
-			if attribute, ok := resource.Body.Attributes[tagsAttributeName]; ok {
-				logger.Debug("Walk `%s` attribute", resource.Labels[0]+"."+resource.Labels[1]+"."+tagsAttributeName)
-				resourceTags := make(map[string]string)
-				wantType := cty.Map(cty.String)
-				err := runner.EvaluateExpr(attribute.Expr, &resourceTags, &tflint.EvaluateExprOption{WantType: &wantType})
-				err = runner.EnsureNoError(err, func() error {
-					r.emitIssue(runner, resourceTags, config, attribute.Expr.Range())
-					return nil
-				})
-				if err != nil {
-					return err
-				}
-			} else {
-				logger.Debug("Walk `%s` resource", resource.Labels[0]+"."+resource.Labels[1])
-				r.emitIssue(runner, map[string]string{}, config, resource.DefRange)
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	// The content of every resource is requested at once, so the number of calls does not grow with the
+	// hundreds of taggable resource types, but only the declared ones are walked
+	schema := &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: tagsAttributeName}}}
+	attributes := map[string]bool{tagsAttributeName: true}
+	for _, resourceType := range sortedKeys(config.CustomResources) {
+		if attribute := config.CustomResources[resourceType]; !attributes[attribute] {
+			attributes[attribute] = true
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: attribute})
+		}
+	}
+	declared, err := resourcesByType(runner, schema)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes := taggableResources()
+	if config.DiscoverResources {
+		resourceTypes = discoveredResources(declared)
+	}
+	if len(config.CustomResources) > 0 {
+		resourceTypes = append([]string{}, resourceTypes...)
+		for _, resourceType := range sortedKeys(config.CustomResources) {
+			if !stringInSlice(resourceType, resourceTypes) {
+				resourceTypes = append(resourceTypes, resourceType)
+			}
+		}
+	}
+	checked := []string{}
+	for _, resourceType := range resourceTypes {
+		// Skip this resource if its type is excluded in configuration, or not declared in the module
+		if _, exists := declared[resourceType]; stringInSlice(resourceType, config.Exclude) || !exists {
+			continue
+		}
+		// Skip this resource if it has an exemption that has not expired yet
+		if _, active := findExemption(config.Exemptions, resourceType); active {
+			continue
+		}
+		checked = append(checked, resourceType)
+	}
+	err = forEachParallel(runner, checked, config.Workers, func(runner tflint.Runner, resourceType string) error {
+		return r.checkResources(runner, resourceType, declared[resourceType], locals, patterns, config)
+	})
+	if err != nil {
+		return err
+	}
+
+	return r.checkStackComponents(runner, config.Tags)
+}
+
+// tagsAttribute returns the name of the attribute holding the tags of the resource type
+func (c azurermResourceTagsRuleConfig) tagsAttribute(resourceType string) string {
+	if attribute, exists := c.CustomResources[resourceType]; exists {
+		return attribute
+	}
+	return tagsAttributeName
+}
+
+// checkResources checks the tags of the declared resources of a type
+func (r *AzurermResourceMissingTagsRule) checkResources(runner tflint.Runner, resourceType string, blocks hclext.Blocks, locals map[string]*hcl.Attribute, patterns map[string]*regexp.Regexp, config azurermResourceTagsRuleConfig) error {
+	required := config.Tags
+	if resourceType == "azurerm_resource_group" && config.ResourceGroup != nil {
+		required = append(append([]string{}, config.Tags...), config.ResourceGroup.Tags...)
+	}
+
+	if behaviorEnabled(overrideFilesMerged) {
+		blocks = mergeOverrides(blocks)
+	}
+	for _, resource := range blocks {
+		// Skip this resource if its address is excluded in configuration
+		if excludedResource(resource, config.ExcludeResources) {
+			continue
+		}
+
+		attributeName := config.tagsAttribute(resourceType)
+		attribute, ok := resource.Body.Attributes[attributeName]
+		if !ok && attributeName == tagsAttributeName && behaviorEnabled(nestedBlockTags) {
+			attribute, ok = nestedTagsAttribute(runner, resource)
+		}
+		if !ok {
+			logger.Debug("Walk `%s` resource", resource.Labels[0]+"."+resource.Labels[1])
+			r.emitIssue(runner, resource, true, map[string]string{}, required, resource.DefRange, nil, config)
+		} else {
+			logger.Debug("Walk `%s` attribute", resource.Labels[0]+"."+resource.Labels[1]+"."+attributeName)
+			if r.emitNestingIssues(runner, resource, attribute.Expr, locals, config.MaxNestingDepth) {
+				continue
+			}
+			resourceTags, literals, err := evaluateTags(runner, attribute.Expr, locals)
+			if errors.Is(err, tflint.ErrUnknownValue) {
+				r.emitUnknownTagsIssue(runner, config.UnknownTagsAction, resource, attribute.Expr.Range())
+				continue
+			}
+			err = runner.EnsureNoError(err, func() error {
+				r.checkTags(runner, resource, resourceTags, literals, required, config, patterns, attribute.Expr, locals)
+				return nil
+			})
+			if err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func (r *AzurermResourceMissingTagsRule) emitIssue(runner tflint.Runner, tags map[string]string, config azurermResourceTagsRuleConfig, location hcl.Range) {
-	var missing []string
-	for _, tag := range config.Tags {
-		if _, ok := tags[tag]; !ok {
-			missing = append(missing, fmt.Sprintf("\"%s\"", tag))
+// emitIssue reports the required tags missing from the tags of the resource, which has no tags attribute if absent is set
+func (r *AzurermResourceMissingTagsRule) emitIssue(runner tflint.Runner, resource *hclext.Block, absent bool, tags map[string]string, required []string, location hcl.Range, expr hcl.Expression, config azurermResourceTagsRuleConfig) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+
+	var missingTags []string
+	descriptions := map[string]string{}
+	for _, tag := range required {
+		if _, ok := tags[tag]; !ok && !stringInSlice(tag, missingTags) {
+			missingTags = append(missingTags, tag)
+			if s := suggestion(tag, keys); s != "" && behaviorEnabled(tagKeySuggestions) {
+				descriptions[tag] = fmt.Sprintf("\"%s\" (did you mean \"%s\"?)", tag, s)
+			} else {
+				descriptions[tag] = fmt.Sprintf("\"%s\"", tag)
+			}
+		}
+	}
+	if len(missingTags) == 0 {
+		return
+	}
+	sort.Strings(missingTags)
+
+	// TODO: `tflint --fix` cannot apply these fixes until the SDK is upgraded past v0.11, whose plugin protocol
+	// cannot carry them (see fixRunner), so they only reach the debug log for now
+	fix := func(tags []string) *issueFix {
+		if absent {
+			return absentTagsFix(runner, resource, config.tagsAttribute(resource.Labels[0]), tags, config.Defaults)
+		}
+		return missingTagsFix(runner, expr, tags, config.Defaults)
+	}
+
+	// Separate issues let annotation consumers such as code scanning track each missing tag on its own
+	if config.IssuePerTag {
+		for _, tag := range missingTags {
+			issue := missingTagsMessage(config.Message, resource, absent, true, []string{descriptions[tag]})
+			emitIssueWithFix(runner, r, issue, location, fix([]string{tag}))
+		}
+		return
+	}
+
+	missing := make([]string, len(missingTags))
+	for i, tag := range missingTags {
+		missing[i] = descriptions[tag]
+	}
+	issue := missingTagsMessage(config.Message, resource, absent, false, missing)
+	emitIssueWithFix(runner, r, issue, location, fix(missingTags))
+}
+
+// missingTagsMessage renders the message reporting the missing tags of the resource with the `message` template,
+// or the default message if no template is configured
+func missingTagsMessage(template string, resource *hclext.Block, absent bool, single bool, missing []string) string {
+	address := resource.Labels[0] + "." + resource.Labels[1]
+	list := strings.Join(missing, ", ")
+	if template != "" {
+		return strings.NewReplacer(
+			"{address}", address,
+			"{type}", resource.Labels[0],
+			"{name}", resource.Labels[1],
+			"{tags}", list,
+		).Replace(template)
+	}
+
+	subject := fmt.Sprintf("`%s` is missing", address)
+	if absent {
+		subject = fmt.Sprintf("`%s` has no tags, so it is missing", address)
+	}
+	if single {
+		return fmt.Sprintf("%s the %s tag.", subject, list)
+	}
+	return fmt.Sprintf("%s the following tags: %s.", subject, list)
+}
+
+// checkTags reports missing tags and invalid tag values of the resource, unless it carries the exemption tag.
+// Values are only validated when they are known, literals holds the known values of tags.
+func (r *AzurermResourceMissingTagsRule) checkTags(runner tflint.Runner, resource *hclext.Block, tags map[string]string, literals map[string]string, required []string, config azurermResourceTagsRuleConfig, patterns map[string]*regexp.Regexp, expr hcl.Expression, locals map[string]*hcl.Attribute) {
+	if reason := strings.TrimSpace(literals[config.ExemptTag]); reason != "" {
+		runner.EmitIssue(
+			&severityRule{Rule: r, severity: tflint.NOTICE},
+			fmt.Sprintf("`%s.%s` is exempt from the required tags by its `%s` tag: %s", resource.Labels[0], resource.Labels[1], config.ExemptTag, reason),
+			expr.Range(),
+		)
+		return
+	}
+
+	maps := mapLiterals(expr, locals)
+	switch {
+	case config.NestedLocations && len(maps) > 0:
+		// Each map the tags are merged from gets the issue, so that the tag can be added where it fits best
+		for _, m := range maps {
+			r.emitIssue(runner, resource, false, tags, required, m.Range(), m, config)
+		}
+	case len(maps) > 0:
+		// The last map takes precedence in merge(), so it is the innermost one specific to the resource
+		innermost := maps[len(maps)-1]
+		r.emitIssue(runner, resource, false, tags, required, innermost.Range(), innermost, config)
+	default:
+		r.emitIssue(runner, resource, false, tags, required, expr.Range(), expr, config)
+	}
+	r.emitValueIssues(runner, literals, config.Values, expr.Range())
+	r.emitPatternIssues(runner, literals, patterns, expr.Range())
+}
+
+// excludedResource returns whether the address of the resource matches any of the globs
+func excludedResource(resource *hclext.Block, globs []string) bool {
+	address := resource.Labels[0] + "." + resource.Labels[1]
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, address); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// emitUnknownTagsIssue reports tags that cannot be checked because their value is unknown, as configured by the action
+func (r *AzurermResourceMissingTagsRule) emitUnknownTagsIssue(runner tflint.Runner, action string, resource *hclext.Block, location hcl.Range) {
+	severity, report := unknownTagsActions[action]
+	if !report {
+		logger.Debug("Skip `%s` with unknown tags", resource.Labels[0]+"."+resource.Labels[1])
+		return
+	}
+	runner.EmitIssue(
+		&severityRule{Rule: r, severity: severity},
+		fmt.Sprintf("The tags of `%s.%s` are unknown at lint time, so the required tags cannot be checked.", resource.Labels[0], resource.Labels[1]),
+		location,
+	)
+}
+
+// emitNestingIssues reports tags built from more merge() calls and local values than the maximum depth, which also
+// catches cycles between locals, and tags with object or list values, since Azure tags are flat key/value pairs.
+// It returns whether any issue was reported, in which case the tags are not checked any further.
+func (r *AzurermResourceMissingTagsRule) emitNestingIssues(runner tflint.Runner, resource *hclext.Block, expr hcl.Expression, locals map[string]*hcl.Attribute, maxDepth int) bool {
+	if depth := nestingDepth(expr, locals); depth > maxDepth {
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("The tags of `%s.%s` are built from merge() calls and local values nested deeper than the `max_nesting_depth` of %d, so the required tags cannot be checked.", resource.Labels[0], resource.Labels[1], maxDepth),
+			expr.Range(),
+		)
+		return true
+	}
+
+	reported := false
+	for _, literal := range mapLiterals(expr, locals) {
+		for _, item := range literal.Items {
+			switch item.ValueExpr.(type) {
+			case *hclsyntax.ObjectConsExpr, *hclsyntax.TupleConsExpr:
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("The \"%s\" tag of `%s.%s` has a nested value, but Azure tags are flat key/value pairs of strings.", settingKey(item.KeyExpr), resource.Labels[0], resource.Labels[1]),
+					item.ValueExpr.Range(),
+				)
+				reported = true
+			}
+		}
+	}
+	return reported
+}
+
+// emitValueIssues reports tags whose value is not one of the allowed values, e.g. a typo like "Porduction"
+func (r *AzurermResourceMissingTagsRule) emitValueIssues(runner tflint.Runner, tags map[string]string, values map[string][]string, location hcl.Range) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := tags[key]
+		if !ok || stringInSlice(value, values[key]) {
+			continue
 		}
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("The resource has the value \"%s\" for the \"%s\" tag, which is not one of the allowed values: %s.%s", value, key, quoteAll(values[key]), didYouMean(value, values[key])),
+			location,
+		)
+	}
+}
+
+// emitPatternIssues reports tags whose value does not match the pattern configured for the tag
+func (r *AzurermResourceMissingTagsRule) emitPatternIssues(runner tflint.Runner, tags map[string]string, patterns map[string]*regexp.Regexp, location hcl.Range) {
+	keys := make([]string, 0, len(patterns))
+	for key := range patterns {
+		keys = append(keys, key)
 	}
-	if len(missing) > 0 {
-		sort.Strings(missing)
-		wanted := strings.Join(missing, ", ")
-		issue := fmt.Sprintf("The resource is missing the following tags: %s.", wanted)
-		runner.EmitIssue(r, issue, location)
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value, ok := tags[key]
+		if !ok || patterns[key].MatchString(value) {
+			continue
+		}
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("The resource has the value \"%s\" for the \"%s\" tag, which does not match the pattern `%s`.", value, key, patterns[key]),
+			location,
+		)
 	}
 }
 
+// missingTagsFix suggests adding the missing tags with their default values to a literal tags map
+func missingTagsFix(runner tflint.Runner, expr hcl.Expression, missing []string, defaults map[string]string) *issueFix {
+	if _, ok := expr.(*hclsyntax.ObjectConsExpr); !ok {
+		return nil
+	}
+	file, err := runner.GetFile(expr.Range().Filename)
+	if err != nil || file == nil {
+		return nil
+	}
+
+	items := missingTagItems(missing, defaults)
+	src := string(expr.Range().SliceBytes(file.Bytes))
+	closing := strings.LastIndex(src, "}")
+	if closing < 0 {
+		return nil
+	}
+
+	var replacement string
+	if !strings.Contains(src, "\n") {
+		if existing := strings.TrimSpace(src[1:closing]); existing != "" {
+			items = append([]string{existing}, items...)
+		}
+		replacement = fmt.Sprintf("{ %s }", strings.Join(items, ", "))
+	} else {
+		// Insert the tags on their own lines just above the closing brace, one level deeper than it
+		lineStart := strings.LastIndex(src[:closing], "\n") + 1
+		indent := src[lineStart:closing]
+		var b strings.Builder
+		b.WriteString(src[:lineStart])
+		for _, item := range items {
+			b.WriteString(indent + "  " + item + lineEnding([]byte(src)))
+		}
+		b.WriteString(src[lineStart:])
+		replacement = b.String()
+	}
+	return &issueFix{Range: expr.Range(), Replacement: replacement}
+}
+
+// nestedTagsAttribute returns the first `tags` attribute set in a nested block of the resource, such as the
+// `content` of a dynamic block, for resources that do not set tags at the top level.
+// Only the native syntax is walked, since nested blocks cannot be told apart from attributes in JSON without a schema.
+func nestedTagsAttribute(runner tflint.Runner, resource *hclext.Block) (*hclext.Attribute, bool) {
+	file, err := runner.GetFile(resource.DefRange.Filename)
+	if err != nil || file == nil {
+		return nil, false
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	var walk func(blocks hclsyntax.Blocks) *hclsyntax.Attribute
+	walk = func(blocks hclsyntax.Blocks) *hclsyntax.Attribute {
+		for _, block := range blocks {
+			if attribute, exists := block.Body.Attributes[tagsAttributeName]; exists {
+				return attribute
+			}
+			if attribute := walk(block.Body.Blocks); attribute != nil {
+				return attribute
+			}
+		}
+		return nil
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || block.DefRange().Start.Byte != resource.DefRange.Start.Byte {
+			continue
+		}
+		if attribute := walk(block.Body.Blocks); attribute != nil {
+			return &hclext.Attribute{Name: attribute.Name, Expr: attribute.Expr, Range: attribute.SrcRange, NameRange: attribute.NameRange}, true
+		}
+	}
+	return nil, false
+}
+
+// absentTagsFix suggests adding a tags map with the missing tags and their default values to a resource without tags
+func absentTagsFix(runner tflint.Runner, resource *hclext.Block, attribute string, missing []string, defaults map[string]string) *issueFix {
+	file, err := runner.GetFile(resource.DefRange.Filename)
+	if err != nil || file == nil {
+		return nil
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || block.DefRange().Start.Byte != resource.DefRange.Start.Byte {
+			continue
+		}
+
+		bodyRange := hcl.RangeBetween(block.OpenBraceRange, block.CloseBraceRange)
+		src := string(bodyRange.SliceBytes(file.Bytes))
+		eol := lineEnding(file.Bytes)
+		outer := lineIndent(file.Bytes, block.DefRange().Start)
+		indent := outer + "  "
+
+		var tags strings.Builder
+		tags.WriteString(indent + attribute + " = {" + eol)
+		for _, item := range missingTagItems(missing, defaults) {
+			tags.WriteString(indent + "  " + item + eol)
+		}
+		tags.WriteString(indent + "}" + eol)
+
+		var replacement string
+		if !strings.Contains(src, "\n") {
+			// A single-line block holds at most one argument, which moves to its own line
+			replacement = "{" + eol
+			if existing := strings.TrimSpace(src[1 : len(src)-1]); existing != "" {
+				replacement += indent + existing + eol
+			}
+			replacement += tags.String() + outer + "}"
+		} else {
+			lineStart := strings.LastIndex(src, "\n") + 1
+			replacement = src[:lineStart] + tags.String() + src[lineStart:]
+		}
+		return &issueFix{Range: bodyRange, Replacement: replacement}
+	}
+	return nil
+}
+
+// missingTagItems renders the missing tags as sorted map items, with their default values or empty strings
+func missingTagItems(missing []string, defaults map[string]string) []string {
+	sort.Strings(missing)
+	items := make([]string, len(missing))
+	for i, tag := range missing {
+		key := tag
+		if !hclsyntax.ValidIdentifier(tag) {
+			key = strconv.Quote(tag)
+		}
+		items[i] = fmt.Sprintf("%s = %s", key, strconv.Quote(defaults[tag]))
+	}
+	return items
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -118,4 +617,4 @@ func stringInSlice(a string, list []string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
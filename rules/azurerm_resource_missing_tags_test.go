@@ -1,10 +1,20 @@
 package rules
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
 
 func Test_AzurermResourceMissingTags(t *testing.T) {
@@ -33,7 +43,7 @@ rule "azurerm_resource_missing_tags" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewAzurermResourceMissingTagsRule(),
-					Message: "The resource is missing the following tags: \"Bar\", \"Foo\".",
+					Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"Bar\" (did you mean \"bar\"?), \"Foo\" (did you mean \"foo\"?).",
 					Range: hcl.Range{
 						Filename: "module.tf",
 						Start:    hcl.Pos{Line: 5, Column: 10},
@@ -42,7 +52,7 @@ rule "azurerm_resource_missing_tags" {
 				},
 			},
 		},
-				{
+		{
 			Name: "Wanted tags: Bar,Foo, found: bar,foo",
 			Content: `
 resource "azurerm_resource_group" "az_rg_1" {
@@ -61,7 +71,7 @@ rule "azurerm_resource_missing_tags" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewAzurermResourceMissingTagsRule(),
-					Message: "The resource is missing the following tags: \"Bar\", \"Foo\".",
+					Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"Bar\" (did you mean \"bar\"?), \"Foo\" (did you mean \"foo\"?).",
 					Range: hcl.Range{
 						Filename: "module.tf",
 						Start:    hcl.Pos{Line: 5, Column: 10},
@@ -85,7 +95,91 @@ rule "azurerm_resource_missing_tags" {
 			Expected: helper.Issues{
 				{
 					Rule:    NewAzurermResourceMissingTagsRule(),
-					Message: "The resource is missing the following tags: \"Bar\", \"Foo\".",
+					Message: "`azurerm_resource_group.az_rg_1` has no tags, so it is missing the following tags: \"Bar\", \"Foo\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 44},
+					},
+				},
+			},
+		},
+		{
+			Name: "Dry run reports a notice instead of failing",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  name = "test_rg"
+  location = "West Europe"
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+  enforce = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    &severityRule{Rule: NewAzurermResourceMissingTagsRule(), severity: tflint.NOTICE},
+					Message: "Dry run (enforce = false), this rule would report: `azurerm_resource_group.az_rg_1` has no tags, so it is missing the following tags: \"Foo\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 44},
+					},
+				},
+			},
+		},
+		{
+			Name: "Active exemption skips the resource type",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  name = "test_rg"
+  location = "West Europe"
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+
+  exemption {
+    resource = "azurerm_resource_group"
+    expires  = "2022-06-30"
+    owner    = "platform-team"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Expired exemption is reported and ignored",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  name = "test_rg"
+  location = "West Europe"
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+
+  exemption {
+    resource = "azurerm_resource_group"
+    expires  = "2022-05-31"
+    owner    = "platform-team"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "The exemption for `azurerm_resource_group` owned by platform-team expired on 2022-05-31 and is no longer applied.",
+					Range: hcl.Range{
+						Filename: ".tflint.hcl",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 12},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.az_rg_1` has no tags, so it is missing the following tags: \"Foo\".",
 					Range: hcl.Range{
 						Filename: "module.tf",
 						Start:    hcl.Pos{Line: 2, Column: 1},
@@ -94,6 +188,39 @@ rule "azurerm_resource_missing_tags" {
 				},
 			},
 		},
+		{
+			Name: "Expired exemption is reported once without resources of its type",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = { Foo = "bar" }
+}
+
+resource "azurerm_resource_group" "az_rg_2" {
+  tags = { Foo = "bar" }
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+
+  exemption {
+    resource = "azurerm_key_vault"
+    expires  = "2022-05-31"
+    owner    = "security-team"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "The exemption for `azurerm_key_vault` owned by security-team expired on 2022-05-31 and is no longer applied.",
+					Range: hcl.Range{
+						Filename: ".tflint.hcl",
+						Start:    hcl.Pos{Line: 6, Column: 3},
+						End:      hcl.Pos{Line: 6, Column: 12},
+					},
+				},
+			},
+		},
 		{
 			Name: "Tags are correct",
 			Content: `
@@ -114,10 +241,163 @@ rule "azurerm_resource_missing_tags" {
 		},
 	}
 
+	defer func(original func() time.Time) { now = original }(now)
+	now = func() time.Time { return time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC) }
+
 	rule := NewAzurermResourceMissingTagsRule()
 
 	for _, tc := range cases {
-		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+		runner := &configFileRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config}), config: tc.Config}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceMissingTags_SuggestedFix(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Multi-line tags map",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = {
+    Foo = "bar"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"ManagedBy\". Suggested fix: replace 3:10-5:4 with \"{\\n    Foo = \\\"bar\\\"\\n    ManagedBy = \\\"\\\"\\n  }\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "Single-line tags map",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = { Foo = "bar" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"ManagedBy\". Suggested fix: replace 3:10-3:25 with \"{ Foo = \\\"bar\\\", ManagedBy = \\\"\\\" }\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "Resource without tags",
+			Content: `
+resource "azurerm_resource_group" "az_rg_1" {
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.az_rg_1` has no tags, so it is missing the following tags: \"Foo\", \"ManagedBy\". Suggested fix: replace 2:45-3:2 with \"{\\n  tags = {\\n    Foo = \\\"\\\"\\n    ManagedBy = \\\"\\\"\\n  }\\n}\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 44},
+					},
+				},
+			},
+		},
+	}
+
+	config := `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["ManagedBy", "Foo"]
+}`
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceMissingTags_OverrideFiles(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Files    map[string]string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Tags added in an override file",
+			Files: map[string]string{
+				"main.tf": `
+resource "azurerm_resource_group" "az_rg_1" {
+  name = "test_rg"
+}`,
+				"main_override.tf": `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = {
+    Foo = "bar"
+  }
+}`,
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Tags replaced in an override file",
+			Files: map[string]string{
+				"main.tf": `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = {
+    Foo = "bar"
+  }
+}`,
+				"override.tf": `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = {}
+}`,
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"Foo\".",
+					Range: hcl.Range{
+						Filename: "override.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 12},
+					},
+				},
+			},
+		},
+	}
+
+	config := `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+}`
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		tc.Files[".tflint.hcl"] = config
+		runner := helper.TestRunner(t, tc.Files)
 
 		if err := rule.Check(runner); err != nil {
 			t.Fatalf("Unexpected error occurred: %s", err)
@@ -125,4 +405,1329 @@ rule "azurerm_resource_missing_tags" {
 
 		helper.AssertIssues(t, tc.Expected, runner.Issues)
 	}
-}
\ No newline at end of file
+}
+
+func Test_AzurermResourceMissingTags_RulesetVersion(t *testing.T) {
+	if err := pinRulesetVersion("0.1.0", "0.2.0"); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	defer pinRulesetVersion("", "0.2.0")
+
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "az_rg_1" {
+  tags = {
+    foo = "bar"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Foo"]
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.az_rg_1` is missing the following tags: \"Foo\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_ResourceGroup(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform"
+  }
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = {
+    Owner = "platform"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Owner"]
+
+  resource_group {
+    tags = ["Owner", "CostCenter"]
+  }
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"CostCenter\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Stacks(t *testing.T) {
+	dir := t.TempDir()
+
+	stacks := map[string]string{
+		"components.tfstack.hcl": `
+component "storage" {
+  source = "./storage"
+
+  inputs = {
+    name = "example"
+    tags = {
+      Owner = "platform"
+    }
+  }
+}
+
+component "network" {
+  source = "./network"
+
+  inputs = {
+    tags = var.tags
+  }
+}`,
+		"deployments.tfdeploy.hcl": `
+deployment "production" {
+  inputs = {
+    tags = {
+      Owner      = "platform"
+      CostCenter = "1234"
+    }
+  }
+}`,
+		"broken.tfstack.hcl": `component "broken" {`,
+	}
+	for name, content := range stacks {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runner := helper.TestRunner(t, map[string]string{
+		filepath.Join(dir, "module.tf"): `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner      = "platform"
+    CostCenter = "1234"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags = ["Owner", "CostCenter"]
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "The component `storage` is missing the following tags in `inputs.tags`: \"CostCenter\".",
+			Range: hcl.Range{
+				Filename: filepath.Join(dir, "components.tfstack.hcl"),
+				Start:    hcl.Pos{Line: 7, Column: 12},
+				End:      hcl.Pos{Line: 9, Column: 6},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Values(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Environment = "Porduction"
+  }
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = {
+    Environment = "NonProd"
+    Owner       = "platform"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Environment"]
+  values = {
+    Environment = ["Production", "NonProd"]
+    Owner       = ["networking"]
+  }
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "The resource has the value \"platform\" for the \"Owner\" tag, which is not one of the allowed values: \"networking\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 9, Column: 10},
+				End:      hcl.Pos{Line: 12, Column: 4},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "The resource has the value \"Porduction\" for the \"Environment\" tag, which is not one of the allowed values: \"Production\", \"NonProd\". Did you mean \"Production\"?",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Patterns(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    CostCenter = "CC-12345"
+  }
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = {
+    CostCenter = "CC-1234"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["CostCenter"]
+  patterns = {
+    CostCenter = "^CC-[0-9]{4}$"
+  }
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "The resource has the value \"CC-12345\" for the \"CostCenter\" tag, which does not match the pattern `^CC-[0-9]{4}$`.",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Locals(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+locals {
+  common_tags = merge(local.owner_tags, {
+    Environment = var.environment
+  })
+  owner_tags = {
+    Owner = "platform"
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.common_tags, {
+    Name = "main"
+  })
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = local.owner_tags
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_key_vault.main` is missing the following tags: \"Environment\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 6, Column: 16},
+				End:      hcl.Pos{Line: 8, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Variables(t *testing.T) {
+	runner := &unknownValueRunner{helper.TestRunner(t, map[string]string{
+		"module.tf": `
+variable "tags" {
+  default = {
+    Owner = "platform"
+  }
+}
+
+variable "extra_tags" {
+  default = {
+    Environment = "Porduction"
+  }
+}
+
+data "azurerm_resource_group" "shared" {
+  name = "shared"
+}
+
+locals {
+  common_tags = {
+    Owner = "platform"
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = var.tags
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = merge(local.common_tags, var.extra_tags)
+}
+
+resource "azurerm_storage_account" "main" {
+  tags = merge(local.common_tags, data.azurerm_resource_group.shared.tags)
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+  values = {
+    Environment = ["Production"]
+  }
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "The resource has the value \"Porduction\" for the \"Environment\" tag, which is not one of the allowed values: \"Production\". Did you mean \"Production\"?",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 29, Column: 10},
+				End:      hcl.Pos{Line: 29, Column: 50},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"Environment\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 25, Column: 10},
+				End:      hcl.Pos{Line: 25, Column: 18},
+			},
+		},
+	}, runner.Issues)
+}
+
+// unknownValueRunner evaluates expressions referencing data sources as unknown values, like TFLint does
+type unknownValueRunner struct {
+	*helper.Runner
+}
+
+func (r *unknownValueRunner) EvaluateExpr(expr hcl.Expression, ret interface{}, opts *tflint.EvaluateExprOption) error {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == "data" {
+			return tflint.ErrUnknownValue
+		}
+	}
+	return r.Runner.EvaluateExpr(expr, ret, opts)
+}
+
+func (r *unknownValueRunner) EnsureNoError(err error, proc func() error) error {
+	if errors.Is(err, tflint.ErrUnknownValue) {
+		return nil
+	}
+	return r.Runner.EnsureNoError(err, proc)
+}
+
+func Test_AzurermResourceMissingTags_UnknownTagsAction(t *testing.T) {
+	content := `
+resource "azurerm_resource_group" "main" {
+  tags = data.azurerm_resource_group.shared.tags
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Ignored by default",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Reported as an error",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled             = true
+  tags                = ["Owner"]
+  unknown_tags_action = "error"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    &severityRule{Rule: NewAzurermResourceMissingTagsRule(), severity: tflint.ERROR},
+					Message: "The tags of `azurerm_resource_group.main` are unknown at lint time, so the required tags cannot be checked.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 49},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": tc.Config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+		if len(runner.Issues) > 0 && runner.Issues[0].Rule.Severity() != tflint.ERROR {
+			t.Errorf("Unexpected severity: %s", runner.Issues[0].Rule.Severity())
+		}
+	}
+}
+
+func Test_AzurermResourceMissingTags_ExcludeResources(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "legacy_rg" {}
+
+resource "azurerm_resource_group" "main" {}
+
+resource "azurerm_key_vault" "tmp_build" {}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled           = true
+  tags              = ["Owner"]
+  exclude_resources = ["azurerm_resource_group.legacy_rg", "azurerm_key_vault.tmp_*"]
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` has no tags, so it is missing the following tags: \"Owner\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 4, Column: 1},
+				End:      hcl.Pos{Line: 4, Column: 41},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_LineEndings(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": "\ufeffresource \"azurerm_resource_group\" \"main\" {\r\n  tags = {\r\n    Owner = \"platform\"\r\n  }\r\n}\r\n",
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"Environment\". Suggested fix: replace 2:10-4:4 with \"{\\r\\n    Owner = \\\"platform\\\"\\r\\n    Environment = \\\"\\\"\\r\\n  }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 2, Column: 10},
+				End:      hcl.Pos{Line: 4, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_ExemptTag(t *testing.T) {
+	content := `
+resource "azurerm_resource_group" "legacy" {
+  tags = {
+    tflint_exempt = "JIRA-1234"
+  }
+}
+
+resource "azurerm_key_vault" "legacy" {
+  tags = {
+    waiver = "JIRA-5678"
+  }
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Default exemption tag",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_key_vault.legacy` is missing the following tags: \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 10},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+				{
+					Rule:    &severityRule{Rule: NewAzurermResourceMissingTagsRule(), severity: tflint.NOTICE},
+					Message: "`azurerm_resource_group.legacy` is exempt from the required tags by its `tflint_exempt` tag: JIRA-1234",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "Custom exemption tag",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled    = true
+  tags       = ["Owner"]
+  exempt_tag = "waiver"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    &severityRule{Rule: NewAzurermResourceMissingTagsRule(), severity: tflint.NOTICE},
+					Message: "`azurerm_key_vault.legacy` is exempt from the required tags by its `waiver` tag: JIRA-5678",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 10},
+						End:      hcl.Pos{Line: 11, Column: 4},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.legacy` is missing the following tags: \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 5, Column: 4},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceMissingTags_IssuePerTag(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    owner = "platform"
+  }
+}
+
+resource "azurerm_key_vault" "main" {}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled       = true
+  tags          = ["Owner", "Environment"]
+  issue_per_tag = true
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_key_vault.main` has no tags, so it is missing the \"Environment\" tag.",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 8, Column: 1},
+				End:      hcl.Pos{Line: 8, Column: 36},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_key_vault.main` has no tags, so it is missing the \"Owner\" tag.",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 8, Column: 1},
+				End:      hcl.Pos{Line: 8, Column: 36},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the \"Environment\" tag.",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the \"Owner\" (did you mean \"owner\"?) tag.",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_NestedLocations(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+locals {
+  component_tags = {
+    web = {
+      Environment = "prod"
+    }
+    db = {
+      Environment = "prod"
+    }
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.component_tags.web, { Name = "web" })
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled          = true
+  tags             = ["Owner", "Environment"]
+  nested_locations = true
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"Owner\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 4, Column: 11},
+				End:      hcl.Pos{Line: 6, Column: 6},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"Owner\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 14, Column: 42},
+				End:      hcl.Pos{Line: 14, Column: 58},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Severity(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected tflint.Severity
+		Err      string
+	}{
+		{
+			Name: "default",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+			Expected: tflint.NOTICE,
+		},
+		{
+			Name: "error",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled  = true
+  tags     = ["Owner"]
+  severity = "ERROR"
+}`,
+			Expected: tflint.ERROR,
+		},
+		{
+			Name: "invalid",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled  = true
+  tags     = ["Owner"]
+  severity = "error"
+}`,
+			Err: "severity: \"error\" is not a valid severity, expected one of \"ERROR\", \"WARNING\", \"NOTICE\"",
+		},
+	}
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{
+			"module.tf":   `resource "azurerm_resource_group" "main" {}`,
+			".tflint.hcl": tc.Config,
+		})
+
+		err := NewAzurermResourceMissingTagsRule().Check(runner)
+		if tc.Err != "" {
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("Expected error %q in %s, got %v", tc.Err, tc.Name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error occurred in %s: %s", tc.Name, err)
+		}
+		if len(runner.Issues) != 1 {
+			t.Fatalf("Expected one issue in %s, got %d", tc.Name, len(runner.Issues))
+		}
+		if severity := runner.Issues[0].Rule.Severity(); severity != tc.Expected {
+			t.Fatalf("Expected severity %s in %s, got %s", tc.Expected, tc.Name, severity)
+		}
+	}
+}
+
+func Test_AzurermResourceMissingTags_InnermostMap(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+locals {
+  common_tags = {
+    Owner = "platform"
+  }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.common_tags, { Name = "main" })
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` is missing the following tags: \"Environment\". Suggested fix: replace 9:35-9:52 with \"{ Name = \\\"main\\\", Environment = \\\"\\\" }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 9, Column: 35},
+				End:      hcl.Pos{Line: 9, Column: 52},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Message(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_key_vault" "logs" {
+  tags = {
+    Environment = "prod"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment", "CostCenter"]
+  message = "{address} ({type} {name}) is missing tags: {tags}"
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "azurerm_key_vault.logs (azurerm_key_vault logs) is missing tags: \"CostCenter\", \"Owner\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_Defaults(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" { name = "main" }
+
+resource "azurerm_key_vault" "main" {
+  tags = { Environment = "prod" }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled  = true
+  tags     = ["Owner", "Environment"]
+  defaults = { Owner = "UNKNOWN" }
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_key_vault.main` is missing the following tags: \"Owner\". Suggested fix: replace 5:10-5:34 with \"{ Environment = \\\"prod\\\", Owner = \\\"UNKNOWN\\\" }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 5, Column: 10},
+				End:      hcl.Pos{Line: 5, Column: 34},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_resource_group.main` has no tags, so it is missing the following tags: \"Environment\", \"Owner\". Suggested fix: replace 2:42-2:59 with \"{\\n  name = \\\"main\\\"\\n  tags = {\\n    Environment = \\\"\\\"\\n    Owner = \\\"UNKNOWN\\\"\\n  }\\n}\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1},
+				End:      hcl.Pos{Line: 2, Column: 41},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_NestedBlocks(t *testing.T) {
+	runner := helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_key_vault" "main" {
+  dynamic "profile" {
+    for_each = var.profiles
+    content {
+      tags = {
+        Owner = "platform"
+      }
+    }
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+	})
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_key_vault.main` is missing the following tags: \"Environment\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 6, Column: 14},
+				End:      hcl.Pos{Line: 8, Column: 8},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_AzurermResourceMissingTags_NestingDepth(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Nested tag value",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = { team = "platform" }
+  }
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "The \"Owner\" tag of `azurerm_resource_group.main` has a nested value, but Azure tags are flat key/value pairs of strings.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 13},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "Cyclic locals",
+			Content: `
+locals {
+  a = merge(local.b, { Owner = "platform" })
+  b = merge(local.a, { Environment = "prod" })
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = local.a
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "The tags of `azurerm_resource_group.main` are built from merge() calls and local values nested deeper than the `max_nesting_depth` of 5, so the required tags cannot be checked.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 10},
+						End:      hcl.Pos{Line: 8, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured depth",
+			Content: `
+locals {
+  common_tags = { Owner = "platform" }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.common_tags, { Environment = "prod" })
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled           = true
+  tags              = ["Owner", "Environment"]
+  max_nesting_depth = 1
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "The tags of `azurerm_resource_group.main` are built from merge() calls and local values nested deeper than the `max_nesting_depth` of 1, so the required tags cannot be checked.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 10},
+						End:      hcl.Pos{Line: 7, Column: 60},
+					},
+				},
+			},
+		},
+		{
+			Name: "Within depth",
+			Content: `
+locals {
+  common_tags = { Owner = "platform" }
+}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.common_tags, { Environment = "prod" })
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceMissingTags_DiscoverResources(t *testing.T) {
+	content := `
+resource "azurerm_future_widget" "main" {
+  name = "widget"
+  tags = { Environment = "prod" }
+}
+
+resource "azurerm_future_gadget" "main" {
+  name = "gadget"
+}
+
+resource "azurerm_subnet" "main" {
+  name = "subnet"
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Generated resource list",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Discovered resources",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled            = true
+  tags               = ["Owner"]
+  discover_resources = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_future_widget.main` is missing the following tags: \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 10},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceMissingTagsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceMissingTags_ContentCalls(t *testing.T) {
+	runner := &contentCallRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = { Owner = "platform" }
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = { Owner = "platform" }
+}
+
+resource "azurerm_storage_account" "main" {
+  name = "storage"
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if runner.resourceContentCalls != 0 {
+		t.Errorf("expected the resources to be read in a single module content call, got %d resource content calls", runner.resourceContentCalls)
+	}
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`azurerm_storage_account.main` has no tags, so it is missing the following tags: \"Owner\".",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 10, Column: 1},
+				End:      hcl.Pos{Line: 10, Column: 42},
+			},
+		},
+	}, runner.Issues)
+}
+
+// contentCallRunner counts the GetResourceContent calls, which are one request to TFLint each
+type contentCallRunner struct {
+	*helper.Runner
+	resourceContentCalls int
+}
+
+func (r *contentCallRunner) GetResourceContent(name string, schema *hclext.BodySchema, opts *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	r.resourceContentCalls++
+	return r.Runner.GetResourceContent(name, schema, opts)
+}
+
+func Test_AzurermResourceMissingTags_Workers(t *testing.T) {
+	content := workspaceContent(20, 5)
+	sequential := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`})
+	parallel := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+  workers = 4
+}`})
+
+	rule := NewAzurermResourceMissingTagsRule()
+	if err := rule.Check(sequential); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if err := rule.Check(parallel); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	if len(sequential.Issues) != 50 {
+		t.Fatalf("expected 50 issues, got %d", len(sequential.Issues))
+	}
+	helper.AssertIssues(t, sequential.Issues, parallel.Issues)
+}
+
+// Benchmark_AzurermResourceMissingTags compares checking resource types one at a time and with a worker pool.
+// Expressions are evaluated by TFLint over RPC, so the runner adds the latency of a round trip to each evaluation.
+func Benchmark_AzurermResourceMissingTags(b *testing.B) {
+	content := workspaceContent(50, 10)
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			testRunner := benchmarkRunner(b, map[string]string{"module.tf": content, ".tflint.hcl": fmt.Sprintf(`
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+  values  = { Environment = ["production", "staging"] }
+  workers = %d
+}`, workers)})
+			runner := &latencyRunner{Runner: testRunner, latency: 50 * time.Microsecond}
+			rule := NewAzurermResourceMissingTagsRule()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				testRunner.Issues = nil
+				if err := rule.Check(runner); err != nil {
+					b.Fatalf("Unexpected error occurred: %s", err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkRunner returns a helper.TestRunner for benchmarks. helper.TestRunner only reports errors to a *testing.T,
+// so the files are parsed and the config decoded here first, reporting the errors to tb, after which it cannot fail.
+func benchmarkRunner(tb testing.TB, files map[string]string) *helper.Runner {
+	tb.Helper()
+
+	parser := hclparse.NewParser()
+	for name, src := range files {
+		file, diags := parser.ParseHCL([]byte(src), name)
+		if diags.HasErrors() {
+			tb.Fatal(diags)
+		}
+		if name == ".tflint.hcl" {
+			var config helper.Config
+			if diags := gohcl.DecodeBody(file.Body, nil, &config); diags.HasErrors() {
+				tb.Fatal(diags)
+			}
+		}
+	}
+
+	return helper.TestRunner(&testing.T{}, files)
+}
+
+// workspaceContent returns a module with resources of the given number of taggable types, every other one
+// missing the Environment tag
+func workspaceContent(resourceTypes int, resourcesPerType int) string {
+	var content strings.Builder
+	content.WriteString(`
+variable "tags" {
+  default = {
+    Owner = "platform"
+  }
+}
+
+variable "production_tags" {
+  default = {
+    Owner       = "platform"
+    Environment = "production"
+  }
+}
+`)
+	for i, resourceType := range taggableResources()[:resourceTypes] {
+		for j := 0; j < resourcesPerType; j++ {
+			tags := "var.production_tags"
+			if (i*resourcesPerType+j)%2 == 1 {
+				tags = "var.tags"
+			}
+			fmt.Fprintf(&content, "\nresource %q \"r%d\" {\n  tags = %s\n}\n", resourceType, j, tags)
+		}
+	}
+	return content.String()
+}
+
+// latencyRunner delays expression evaluation like a request from the plugin to TFLint
+type latencyRunner struct {
+	*helper.Runner
+	latency time.Duration
+}
+
+func (r *latencyRunner) EvaluateExpr(expr hcl.Expression, ret interface{}, opts *tflint.EvaluateExprOption) error {
+	time.Sleep(r.latency)
+	return r.Runner.EvaluateExpr(expr, ret, opts)
+}
+
+func Test_AzurermResourceMissingTags_CustomResources(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "databricks_cluster" "main" {
+  custom_tags = {
+    Owner       = "platform"
+    Environment = "production"
+  }
+}
+
+resource "databricks_cluster" "shared" {
+  cluster_name = "shared"
+}
+
+resource "databricks_job" "nightly" {
+  tags = {
+    Owner = "platform"
+  }
+}`,
+		".tflint.hcl": `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+  custom_resources = {
+    databricks_cluster = "custom_tags"
+    databricks_job     = "tags"
+  }
+}`,
+	})}
+
+	if err := NewAzurermResourceMissingTagsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`databricks_cluster.shared` has no tags, so it is missing the following tags: \"Environment\", \"Owner\". Suggested fix: replace 9:40-11:2 with \"{\\n  cluster_name = \\\"shared\\\"\\n  custom_tags = {\\n    Environment = \\\"\\\"\\n    Owner = \\\"\\\"\\n  }\\n}\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 9, Column: 1},
+				End:      hcl.Pos{Line: 9, Column: 39},
+			},
+		},
+		{
+			Rule:    NewAzurermResourceMissingTagsRule(),
+			Message: "`databricks_job.nightly` is missing the following tags: \"Environment\". Suggested fix: replace 14:10-16:4 with \"{\\n    Owner = \\\"platform\\\"\\n    Environment = \\\"\\\"\\n  }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 14, Column: 10},
+				End:      hcl.Pos{Line: 16, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// cafPrefixes are the resource name prefixes of the abbreviations recommended by the Cloud Adoption Framework.
+// Virtual machines have no hyphen after the prefix since Windows computer names are limited to 15 characters,
+// and storage accounts and container registries since their names do not allow hyphens.
+var cafPrefixes = map[string]string{
+	"azurerm_api_management":             "apim-",
+	"azurerm_application_gateway":        "agw-",
+	"azurerm_application_insights":       "appi-",
+	"azurerm_bastion_host":               "bas-",
+	"azurerm_container_app":              "ca-",
+	"azurerm_container_app_environment":  "cae-",
+	"azurerm_container_registry":         "cr",
+	"azurerm_cosmosdb_account":           "cosmos-",
+	"azurerm_data_factory":               "adf-",
+	"azurerm_eventhub_namespace":         "evhns-",
+	"azurerm_firewall":                   "afw-",
+	"azurerm_key_vault":                  "kv-",
+	"azurerm_kubernetes_cluster":         "aks-",
+	"azurerm_linux_function_app":         "func-",
+	"azurerm_linux_virtual_machine":      "vm-",
+	"azurerm_linux_web_app":              "app-",
+	"azurerm_log_analytics_workspace":    "log-",
+	"azurerm_logic_app_workflow":         "logic-",
+	"azurerm_mssql_database":             "sqldb-",
+	"azurerm_mssql_server":               "sql-",
+	"azurerm_nat_gateway":                "ng-",
+	"azurerm_network_interface":          "nic-",
+	"azurerm_network_security_group":     "nsg-",
+	"azurerm_postgresql_flexible_server": "psql-",
+	"azurerm_private_endpoint":           "pep-",
+	"azurerm_public_ip":                  "pip-",
+	"azurerm_redis_cache":                "redis-",
+	"azurerm_resource_group":             "rg-",
+	"azurerm_route_table":                "rt-",
+	"azurerm_service_plan":               "asp-",
+	"azurerm_servicebus_namespace":       "sbns-",
+	"azurerm_storage_account":            "st",
+	"azurerm_subnet":                     "snet-",
+	"azurerm_user_assigned_identity":     "id-",
+	"azurerm_virtual_network":            "vnet-",
+	"azurerm_virtual_network_gateway":    "vgw-",
+	"azurerm_windows_function_app":       "func-",
+	"azurerm_windows_virtual_machine":    "vm",
+	"azurerm_windows_web_app":            "app-",
+}
+
+// AzurermResourceNamingConventionRule checks that resource names follow the naming convention of their type
+type AzurermResourceNamingConventionRule struct {
+	tflint.DefaultRule
+}
+
+type azurermResourceNamingConventionRuleConfig struct {
+	// CAFPrefixes requires the Cloud Adoption Framework prefixes, which `prefixes` overrides by type, true by default
+	CAFPrefixes *bool `hclext:"caf_prefixes,optional"`
+	// Prefixes, Suffixes and Patterns map resource types to the prefix, suffix and pattern their names must have,
+	// e.g. { azurerm_resource_group = "^rg-[a-z0-9-]+$" }. An empty prefix removes the CAF prefix of a type.
+	Prefixes map[string]string `hclext:"prefixes,optional"`
+	Suffixes map[string]string `hclext:"suffixes,optional"`
+	Patterns map[string]string `hclext:"patterns,optional"`
+	Enforce  *bool             `hclext:"enforce,optional"`
+}
+
+// NewAzurermResourceNamingConventionRule returns new rule with default attributes
+func NewAzurermResourceNamingConventionRule() *AzurermResourceNamingConventionRule {
+	return &AzurermResourceNamingConventionRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceNamingConventionRule) Name() string {
+	return "azurerm_resource_naming_convention"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceNamingConventionRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceNamingConventionRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceNamingConventionRule) Link() string {
+	return ""
+}
+
+// Check checks the `name` of resources against the prefix, suffix and pattern configured for their type,
+// with the Cloud Adoption Framework abbreviations as the default prefixes
+func (r *AzurermResourceNamingConventionRule) Check(runner tflint.Runner) error {
+	config := azurermResourceNamingConventionRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+
+	prefixes := map[string]string{}
+	if config.CAFPrefixes == nil || *config.CAFPrefixes {
+		for resourceType, prefix := range cafPrefixes {
+			prefixes[resourceType] = prefix
+		}
+	}
+	for resourceType, prefix := range config.Prefixes {
+		prefixes[resourceType] = prefix
+	}
+	patterns := make(map[string]*regexp.Regexp, len(config.Patterns))
+	for resourceType, source := range config.Patterns {
+		pattern, err := compilePattern(source)
+		if err != nil {
+			return fmt.Errorf("patterns: invalid pattern %q for `%s`: %s", source, resourceType, err)
+		}
+		patterns[resourceType] = pattern
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	configured := map[string]bool{}
+	for _, settings := range []map[string]string{prefixes, config.Suffixes, config.Patterns} {
+		for resourceType := range settings {
+			configured[resourceType] = true
+		}
+	}
+	resourceTypes := make([]string, 0, len(configured))
+	for resourceType := range configured {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	resources, err := resourcesByType(runner, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "name"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range resourceTypes {
+		for _, resource := range resources[resourceType] {
+			attribute, exists := resource.Body.Attributes["name"]
+			if !exists {
+				continue
+			}
+
+			var name string
+			err := runner.EvaluateExpr(attribute.Expr, &name, nil)
+			err = runner.EnsureNoError(err, func() error {
+				var problems []string
+				if prefix := prefixes[resourceType]; !strings.HasPrefix(name, prefix) {
+					problems = append(problems, fmt.Sprintf("does not start with \"%s\"", prefix))
+				}
+				if suffix := config.Suffixes[resourceType]; !strings.HasSuffix(name, suffix) {
+					problems = append(problems, fmt.Sprintf("does not end with \"%s\"", suffix))
+				}
+				if pattern, exists := patterns[resourceType]; exists && !pattern.MatchString(name) {
+					problems = append(problems, fmt.Sprintf("does not match the pattern `%s`", pattern))
+				}
+				if len(problems) == 0 {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` is named \"%s\", which %s", resource.Labels[0], resource.Labels[1], name, strings.Join(problems, " and ")),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceNamingConvention(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "CAF prefixes",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  name = "platform-rg"
+}
+
+resource "azurerm_storage_account" "logs" {
+  name = "stplatformlogs"
+}
+
+resource "azurerm_key_vault" "main" {
+  name = "kv-platform"
+}`,
+			Config: `
+rule "azurerm_resource_naming_convention" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNamingConventionRule(),
+					Message: "`azurerm_resource_group.main` is named \"platform-rg\", which does not start with \"rg-\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "Configured conventions",
+			Content: `
+variable "environment" {
+  default = "prod"
+}
+
+resource "azurerm_resource_group" "main" {
+  name = "rg-Platform-${var.environment}"
+}
+
+resource "azurerm_storage_account" "logs" {
+  name = "st-platform-logs"
+}
+
+resource "azurerm_key_vault" "main" {
+  name = "keyvault-platform"
+}
+
+resource "azurerm_virtual_network" "hub" {
+  name = "hub"
+}`,
+			Config: `
+rule "azurerm_resource_naming_convention" {
+  enabled = true
+  prefixes = {
+    azurerm_key_vault = ""
+  }
+  suffixes = {
+    azurerm_resource_group = "-prod"
+  }
+  patterns = {
+    azurerm_resource_group  = "^rg-[a-z0-9-]+$"
+    azurerm_storage_account = "^st[a-z0-9]{3,22}$"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNamingConventionRule(),
+					Message: "`azurerm_resource_group.main` is named \"rg-Platform-prod\", which does not match the pattern `^rg-[a-z0-9-]+$`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 10},
+						End:      hcl.Pos{Line: 7, Column: 42},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceNamingConventionRule(),
+					Message: "`azurerm_storage_account.logs` is named \"st-platform-logs\", which does not match the pattern `^st[a-z0-9]{3,22}$`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 11, Column: 10},
+						End:      hcl.Pos{Line: 11, Column: 28},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceNamingConventionRule(),
+					Message: "`azurerm_virtual_network.hub` is named \"hub\", which does not start with \"vnet-\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 19, Column: 10},
+						End:      hcl.Pos{Line: 19, Column: 15},
+					},
+				},
+			},
+		},
+		{
+			Name: "Without CAF prefixes",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  name = "platform"
+}`,
+			Config: `
+rule "azurerm_resource_naming_convention" {
+  enabled      = true
+  caf_prefixes = false
+  suffixes = {
+    azurerm_resource_group = "-rg"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceNamingConventionRule(),
+					Message: "`azurerm_resource_group.main` is named \"platform\", which does not end with \"-rg\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 20},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermResourceNamingConventionRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
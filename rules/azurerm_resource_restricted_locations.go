@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/region"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermResourceRestrictedLocationsRule checks that resources are deployed to the allowed locations
+type AzurermResourceRestrictedLocationsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermResourceRestrictedLocationsRuleConfig struct {
+	// Locations are the allowed region names or display names, e.g. ["westeurope", "North Europe"]
+	Locations []string `hclext:"locations,optional"`
+	Enforce   *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermResourceRestrictedLocationsRule returns new rule with default attributes
+func NewAzurermResourceRestrictedLocationsRule() *AzurermResourceRestrictedLocationsRule {
+	return &AzurermResourceRestrictedLocationsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceRestrictedLocationsRule) Name() string {
+	return "azurerm_resource_restricted_locations"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceRestrictedLocationsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceRestrictedLocationsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceRestrictedLocationsRule) Link() string {
+	return ""
+}
+
+// Check checks the `location` of every azurerm resource against the allowed locations to enforce data residency.
+// Locations copied from another resource, like `azurerm_resource_group.main.location`, are resolved to the location
+// of that resource, and other expressions are evaluated, which resolves variable defaults and tfvars.
+func (r *AzurermResourceRestrictedLocationsRule) Check(runner tflint.Runner) error {
+	config := azurermResourceRestrictedLocationsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if len(config.Locations) == 0 {
+		return fmt.Errorf("`locations` is not set for the `%s` rule in .tflint.hcl or the base config", r.Name())
+	}
+	allowed := map[string]bool{}
+	allowedNames := []string{}
+	for _, location := range config.Locations {
+		name, _, known := region.Lookup(location)
+		if !known {
+			return fmt.Errorf("locations: \"%s\" is not a known Azure region", location)
+		}
+		if !allowed[name] {
+			allowed[name] = true
+			allowedNames = append(allowedNames, name)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := resourcesByType(runner, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "location"}},
+	})
+	if err != nil {
+		return err
+	}
+
+	locations := map[string]hcl.Expression{}
+	resourceTypes := []string{}
+	for resourceType, blocks := range resources {
+		for _, resource := range blocks {
+			if attribute, exists := resource.Body.Attributes["location"]; exists {
+				locations[resourceType+"."+resource.Labels[1]] = attribute.Expr
+			}
+		}
+		if strings.HasPrefix(resourceType, "azurerm_") {
+			resourceTypes = append(resourceTypes, resourceType)
+		}
+	}
+	sort.Strings(resourceTypes)
+
+	for _, resourceType := range resourceTypes {
+		for _, resource := range resources[resourceType] {
+			attribute, exists := resource.Body.Attributes["location"]
+			if !exists {
+				continue
+			}
+
+			var location string
+			err := runner.EvaluateExpr(referencedLocation(attribute.Expr, locations), &location, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if allowed[region.Normalize(location)] {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` is located in \"%s\", which is not one of the allowed locations: %s", resource.Labels[0], resource.Labels[1], location, quoteAll(allowedNames)),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// referencedLocation follows references to the location of other resources in the module, such as
+// `azurerm_resource_group.main.location`, and returns the expression the location is set to
+func referencedLocation(expr hcl.Expression, locations map[string]hcl.Expression) hcl.Expression {
+	for depth := 0; depth < maxLocalDepth; depth++ {
+		traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+		if !ok || len(traversal.Traversal) != 3 {
+			return expr
+		}
+		name, isName := traversal.Traversal[1].(hcl.TraverseAttr)
+		attribute, isAttribute := traversal.Traversal[2].(hcl.TraverseAttr)
+		if !isName || !isAttribute || attribute.Name != "location" {
+			return expr
+		}
+		referenced, exists := locations[traversal.Traversal.RootName()+"."+name.Name]
+		if !exists {
+			return expr
+		}
+		expr = referenced
+	}
+	return expr
+}
@@ -0,0 +1,189 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceRestrictedLocations(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Locations outside the allowlist",
+			Content: `
+variable "location" {
+  default = "East US"
+}
+
+resource "azurerm_resource_group" "main" {
+  location = var.location
+}
+
+resource "azurerm_storage_account" "logs" {
+  location = azurerm_resource_group.main.location
+}
+
+resource "azurerm_key_vault" "main" {
+  location = "North Europe"
+}
+
+resource "azurerm_virtual_network" "hub" {
+  location = data.azurerm_resource_group.shared.location
+}`,
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = ["westeurope", "northeurope"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceRestrictedLocationsRule(),
+					Message: "`azurerm_resource_group.main` is located in \"East US\", which is not one of the allowed locations: \"westeurope\", \"northeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14},
+						End:      hcl.Pos{Line: 7, Column: 26},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceRestrictedLocationsRule(),
+					Message: "`azurerm_storage_account.logs` is located in \"East US\", which is not one of the allowed locations: \"westeurope\", \"northeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 11, Column: 14},
+						End:      hcl.Pos{Line: 11, Column: 50},
+					},
+				},
+			},
+		},
+		{
+			Name: "Allowed locations",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  location = "West Europe"
+}
+
+resource "azurerm_key_vault" "main" {
+  location = "northeurope"
+}`,
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = ["westeurope", "North Europe"]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Disallowed location",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  location = "uksouth"
+}`,
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = ["westeurope"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceRestrictedLocationsRule(),
+					Message: "`azurerm_key_vault.main` is located in \"uksouth\", which is not one of the allowed locations: \"westeurope\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 14},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "Unknown location",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  location = data.azurerm_resource_group.shared.location
+}
+
+resource "azurerm_storage_account" "logs" {
+  location = azurerm_resource_group.main.location
+}`,
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = ["westeurope"]
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermResourceRestrictedLocationsRule()
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})}
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermResourceRestrictedLocations_InvalidConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(baseConfigEnv, "")
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected string
+	}{
+		{
+			Name: "Missing locations",
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled = true
+}`,
+			Expected: "`locations` is not set for the `azurerm_resource_restricted_locations` rule in .tflint.hcl or the base config",
+		},
+		{
+			Name: "Empty locations",
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = []
+}`,
+			Expected: "`locations` is not set for the `azurerm_resource_restricted_locations` rule in .tflint.hcl or the base config",
+		},
+		{
+			Name: "Unknown region",
+			Config: `
+rule "azurerm_resource_restricted_locations" {
+  enabled   = true
+  locations = ["westeurop"]
+}`,
+			Expected: "locations: \"westeurop\" is not a known Azure region",
+		},
+	}
+
+	rule := NewAzurermResourceRestrictedLocationsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"module.tf": `
+resource "azurerm_resource_group" "main" {
+  location = "westeurope"
+}`, ".tflint.hcl": tc.Config})
+
+			err := rule.Check(runner)
+			if err == nil || err.Error() != tc.Expected {
+				t.Fatalf("Expected error %q, got %v", tc.Expected, err)
+			}
+		})
+	}
+}
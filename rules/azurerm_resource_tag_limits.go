@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermResourceTagLimitsRule checks that tags stay within the limits Azure enforces on deployment
+type AzurermResourceTagLimitsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermResourceTagLimitsRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// Azure tag limits, see https://learn.microsoft.com/azure/azure-resource-manager/management/tag-resources#limitations
+const (
+	maxTagsPerResource = 50
+	maxTagKeyLength    = 512
+	maxTagValueLength  = 256
+	// invalidTagKeyCharacters are the characters Azure rejects in tag names
+	invalidTagKeyCharacters = `<>%&\?/`
+)
+
+// NewAzurermResourceTagLimitsRule returns new rule with default attributes
+func NewAzurermResourceTagLimitsRule() *AzurermResourceTagLimitsRule {
+	return &AzurermResourceTagLimitsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermResourceTagLimitsRule) Name() string {
+	return "azurerm_resource_tag_limits"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermResourceTagLimitsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermResourceTagLimitsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermResourceTagLimitsRule) Link() string {
+	return ""
+}
+
+// Check checks that resources have at most 50 tags, that tag names are at most 512 characters long without
+// the characters `<>%&\?/`, and that tag values are at most 256 characters long, which Azure only rejects on deployment
+func (r *AzurermResourceTagLimitsRule) Check(runner tflint.Runner) error {
+	config := azurermResourceTagLimitsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+	declared, err := declaredResourceTypes(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range taggableResources() {
+		if !declared[resourceType] {
+			continue
+		}
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: tagsAttributeName}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes[tagsAttributeName]
+			if !exists {
+				continue
+			}
+			items, resolved := resolveMapItems(runner, attribute.Expr, locals)
+			if !resolved {
+				continue
+			}
+
+			if len(items) > maxTagsPerResource {
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` has %d tags, but Azure allows at most %d tags per resource.", resource.Labels[0], resource.Labels[1], len(items), maxTagsPerResource),
+					attribute.Expr.Range(),
+				); err != nil {
+					return err
+				}
+			}
+
+			keys := make([]string, 0, len(items))
+			for key := range items {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				var problems []string
+				if length := utf8.RuneCountInString(key); length > maxTagKeyLength {
+					problems = append(problems, fmt.Sprintf("a name of %d characters, longer than the limit of %d", length, maxTagKeyLength))
+				}
+				if i := strings.IndexAny(key, invalidTagKeyCharacters); i >= 0 {
+					problems = append(problems, fmt.Sprintf("the character %q in its name, which is not allowed in tag names", key[i]))
+				}
+				if value, ok := literalString(items[key]); ok {
+					if length := utf8.RuneCountInString(value); length > maxTagValueLength {
+						problems = append(problems, fmt.Sprintf("a value of %d characters, longer than the limit of %d", length, maxTagValueLength))
+					}
+				}
+
+				for _, problem := range problems {
+					if err := runner.EmitIssue(
+						r,
+						fmt.Sprintf("The \"%s\" tag of `%s.%s` has %s.", key, resource.Labels[0], resource.Labels[1], problem),
+						items[key].Range(),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
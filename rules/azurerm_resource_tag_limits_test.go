@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermResourceTagLimits(t *testing.T) {
+	var manyTags strings.Builder
+	for i := 0; i < 51; i++ {
+		fmt.Fprintf(&manyTags, "    tag%02d = \"value\"\n", i)
+	}
+	longValue := strings.Repeat("a", 257)
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Too many tags",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+` + manyTags.String() + `  }
+}`,
+			Config: `
+rule "azurerm_resource_tag_limits" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagLimitsRule(),
+					Message: "`azurerm_resource_group.main` has 51 tags, but Azure allows at most 50 tags per resource.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 55, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "Invalid names and values",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  tags = {
+    "Cost/Center" = "1234"
+    Description   = "` + longValue + `"
+  }
+}`,
+			Config: `
+rule "azurerm_resource_tag_limits" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceTagLimitsRule(),
+					Message: "The \"Cost/Center\" tag of `azurerm_key_vault.main` has the character '/' in its name, which is not allowed in tag names.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 21},
+						End:      hcl.Pos{Line: 4, Column: 27},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceTagLimitsRule(),
+					Message: "The \"Description\" tag of `azurerm_key_vault.main` has a value of 257 characters, longer than the limit of 256.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 21},
+						End:      hcl.Pos{Line: 5, Column: 280},
+					},
+				},
+			},
+		},
+		{
+			Name: "Within limits",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Environment = "prod"
+    CostCenter  = "1234"
+  }
+}`,
+			Config: `
+rule "azurerm_resource_tag_limits" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermResourceTagLimitsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
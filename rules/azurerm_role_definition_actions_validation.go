@@ -0,0 +1,125 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermRoleDefinitionActionsValidationRule checks the permission actions of custom role definitions
+type AzurermRoleDefinitionActionsValidationRule struct {
+	tflint.DefaultRule
+}
+
+type azurermRoleDefinitionActionsValidationRuleConfig struct {
+	AllowWildcard bool  `hclext:"allow_wildcard,optional"`
+	Enforce       *bool `hclext:"enforce,optional"`
+}
+
+// providerOperation matches provider operations such as `Microsoft.Compute/virtualMachines/*/read`
+// and the `*/read` style operations across all providers
+var providerOperation = regexp.MustCompile(`^(\*|[A-Za-z][A-Za-z0-9]*(\.[A-Za-z][A-Za-z0-9]*)+)(/[A-Za-z0-9*][A-Za-z0-9.*_-]*)+$`)
+
+// roleDefinitionActionAttributes are the attributes of the permissions block holding operations
+var roleDefinitionActionAttributes = []string{"actions", "not_actions", "data_actions", "not_data_actions"}
+
+// NewAzurermRoleDefinitionActionsValidationRule returns new rule with default attributes
+func NewAzurermRoleDefinitionActionsValidationRule() *AzurermRoleDefinitionActionsValidationRule {
+	return &AzurermRoleDefinitionActionsValidationRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermRoleDefinitionActionsValidationRule) Name() string {
+	return "azurerm_role_definition_actions_validation"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermRoleDefinitionActionsValidationRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermRoleDefinitionActionsValidationRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermRoleDefinitionActionsValidationRule) Link() string {
+	return ""
+}
+
+// Check checks that the operations of role definition permissions follow the provider operations format,
+// catching typos that Azure would only reject at apply time, and that roles do not grant the `*` wildcard
+func (r *AzurermRoleDefinitionActionsValidationRule) Check(runner tflint.Runner) error {
+	config := azurermRoleDefinitionActionsValidationRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	attributes := make([]hclext.AttributeSchema, len(roleDefinitionActionAttributes))
+	for i, name := range roleDefinitionActionAttributes {
+		attributes[i] = hclext.AttributeSchema{Name: name}
+	}
+	resources, err := runner.GetResourceContent("azurerm_role_definition", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "permissions", Body: &hclext.BodySchema{Attributes: attributes}},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		for _, permissions := range resource.Body.Blocks {
+			for _, name := range roleDefinitionActionAttributes {
+				attribute, exists := permissions.Body.Attributes[name]
+				if !exists {
+					continue
+				}
+				elements, diags := hcl.ExprList(attribute.Expr)
+				if diags.HasErrors() {
+					continue
+				}
+
+				for _, element := range elements {
+					operation, ok := literalString(element)
+					if !ok {
+						continue
+					}
+					if problem := roleOperationProblem(name, operation, config.AllowWildcard); problem != "" {
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` has the operation \"%s\" in `permissions.%s`, which %s", resource.Labels[0], resource.Labels[1], operation, name, problem),
+							element.Range(),
+						)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// roleOperationProblem describes what is wrong with an operation of the attribute, or returns an empty string
+func roleOperationProblem(attribute string, operation string, allowWildcard bool) string {
+	if operation == "*" {
+		if allowWildcard || strings.HasPrefix(attribute, "not_") {
+			return ""
+		}
+		return "grants every operation, list the operations the role needs instead"
+	}
+	if !providerOperation.MatchString(operation) {
+		return "is not a provider operation like `Microsoft.Compute/virtualMachines/read`"
+	}
+	namespace := strings.SplitN(strings.SplitN(operation, "/", 2)[0], ".", 2)[0]
+	if s := suggestion(namespace, []string{"Microsoft"}); s != "" && !strings.EqualFold(s, namespace) {
+		return fmt.Sprintf("has the provider namespace \"%s\", did you mean \"%s\"?", namespace, s)
+	}
+	return ""
+}
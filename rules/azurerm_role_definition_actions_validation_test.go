@@ -0,0 +1,107 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermRoleDefinitionActionsValidation(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Invalid operations",
+			Content: `
+resource "azurerm_role_definition" "operator" {
+  permissions {
+    actions = [
+      "*",
+      "Microsft.Compute/virtualMachines/start/action",
+      "Microsoft.Compute/virtualMachines/",
+    ]
+    not_actions = ["*"]
+  }
+}`,
+			Config: `
+rule "azurerm_role_definition_actions_validation" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermRoleDefinitionActionsValidationRule(),
+					Message: "`azurerm_role_definition.operator` has the operation \"*\" in `permissions.actions`, which grants every operation, list the operations the role needs instead",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 7},
+						End:      hcl.Pos{Line: 5, Column: 10},
+					},
+				},
+				{
+					Rule:    NewAzurermRoleDefinitionActionsValidationRule(),
+					Message: "`azurerm_role_definition.operator` has the operation \"Microsft.Compute/virtualMachines/start/action\" in `permissions.actions`, which has the provider namespace \"Microsft\", did you mean \"Microsoft\"?",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 7},
+						End:      hcl.Pos{Line: 6, Column: 54},
+					},
+				},
+				{
+					Rule:    NewAzurermRoleDefinitionActionsValidationRule(),
+					Message: "`azurerm_role_definition.operator` has the operation \"Microsoft.Compute/virtualMachines/\" in `permissions.actions`, which is not a provider operation like `Microsoft.Compute/virtualMachines/read`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 7},
+						End:      hcl.Pos{Line: 7, Column: 43},
+					},
+				},
+			},
+		},
+		{
+			Name: "Valid operations",
+			Content: `
+resource "azurerm_role_definition" "reader" {
+  permissions {
+    actions      = ["*/read", "Microsoft.Storage/storageAccounts/*/read", "microsoft.insights/alertRules/*"]
+    data_actions = ["Microsoft.Storage/storageAccounts/blobServices/containers/blobs/read"]
+  }
+}`,
+			Config: `
+rule "azurerm_role_definition_actions_validation" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Allowed wildcard",
+			Content: `
+resource "azurerm_role_definition" "break_glass" {
+  permissions {
+    actions = ["*"]
+  }
+}`,
+			Config: `
+rule "azurerm_role_definition_actions_validation" {
+  enabled        = true
+  allow_wildcard = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermRoleDefinitionActionsValidationRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
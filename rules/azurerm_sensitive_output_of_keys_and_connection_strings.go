@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermSensitiveOutputOfKeysAndConnectionStringsRule checks that outputs do not expose keys and connection strings
+type AzurermSensitiveOutputOfKeysAndConnectionStringsRule struct {
+	tflint.DefaultRule
+
+	// secrets are the secret attributes of each resource type, secretAttributes by default
+	secrets map[string][]string
+}
+
+type azurermSensitiveOutputOfKeysAndConnectionStringsRuleConfig struct {
+	// AllowOutputs are the names or globs of the outputs allowed to expose secrets when they are marked sensitive
+	AllowOutputs []string `hclext:"allow_outputs,optional"`
+	Enforce      *bool    `hclext:"enforce,optional"`
+}
+
+// secretAttributes maps resource types to their attributes holding keys, connection strings and credentials
+var secretAttributes = map[string][]string{
+	"azurerm_application_insights":    {"instrumentation_key", "connection_string"},
+	"azurerm_cognitive_account":       {"primary_access_key", "secondary_access_key"},
+	"azurerm_container_registry":      {"admin_password"},
+	"azurerm_cosmosdb_account":        {"primary_key", "secondary_key", "primary_readonly_key", "secondary_readonly_key", "connection_strings", "primary_sql_connection_string", "secondary_sql_connection_string"},
+	"azurerm_eventhub_namespace":      {"default_primary_key", "default_secondary_key", "default_primary_connection_string", "default_secondary_connection_string"},
+	"azurerm_kubernetes_cluster":      {"kube_config", "kube_config_raw", "kube_admin_config", "kube_admin_config_raw"},
+	"azurerm_log_analytics_workspace": {"primary_shared_key", "secondary_shared_key"},
+	"azurerm_redis_cache":             {"primary_access_key", "secondary_access_key", "primary_connection_string", "secondary_connection_string"},
+	"azurerm_search_service":          {"primary_key", "secondary_key"},
+	"azurerm_servicebus_namespace":    {"default_primary_key", "default_secondary_key", "default_primary_connection_string", "default_secondary_connection_string"},
+	"azurerm_storage_account":         {"primary_access_key", "secondary_access_key", "primary_connection_string", "secondary_connection_string", "primary_blob_connection_string", "secondary_blob_connection_string"},
+}
+
+// NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule returns new rule with default attributes
+func NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule() *AzurermSensitiveOutputOfKeysAndConnectionStringsRule {
+	return &AzurermSensitiveOutputOfKeysAndConnectionStringsRule{
+		secrets: secretAttributes,
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermSensitiveOutputOfKeysAndConnectionStringsRule) Name() string {
+	return "azurerm_sensitive_output_of_keys_and_connection_strings"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermSensitiveOutputOfKeysAndConnectionStringsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermSensitiveOutputOfKeysAndConnectionStringsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermSensitiveOutputOfKeysAndConnectionStringsRule) Link() string {
+	return ""
+}
+
+// Check checks outputs referencing the keys and connection strings of resources and data sources.
+// Outputs are stored in the state and read by other configurations, so an output may only expose a secret
+// when it is marked `sensitive = true` and allowed in `allow_outputs`.
+func (r *AzurermSensitiveOutputOfKeysAndConnectionStringsRule) Check(runner tflint.Runner) error {
+	config := azurermSensitiveOutputOfKeysAndConnectionStringsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, pattern := range config.AllowOutputs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("allow_outputs: invalid glob %q: %s", pattern, err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "value"}, {Name: "sensitive"}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, output := range content.Blocks {
+		value, exists := output.Body.Attributes["value"]
+		if !exists {
+			continue
+		}
+		name := output.Labels[0]
+
+		err := evaluateOptionalBool(runner, output.Body, "sensitive", false, func(sensitive bool) error {
+			if sensitive && matchesGlob(name, config.AllowOutputs) {
+				return nil
+			}
+			for _, traversal := range value.Expr.Variables() {
+				address, attribute := referencedAttribute(traversal)
+				if attribute == "" || !stringInSlice(attribute, r.secrets[addressType(address)]) {
+					continue
+				}
+
+				remedy := "mark it `sensitive = true` and allow it in `allow_outputs` if it must be exposed"
+				if sensitive {
+					remedy = "allow it in `allow_outputs` if it must be exposed"
+				}
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("output \"%s\" exposes `%s` of `%s`, %s", name, attribute, address, remedy),
+					traversal.SourceRange(),
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermSensitiveOutputOfKeysAndConnectionStrings(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Secrets in outputs",
+			Content: `
+output "storage_key" {
+  value = azurerm_storage_account.main.primary_access_key
+}
+
+output "kube_config" {
+  value     = azurerm_kubernetes_cluster.main[0].kube_config_raw
+  sensitive = true
+}
+
+output "redis" {
+  value = {
+    host              = data.azurerm_redis_cache.main.hostname
+    connection_string = data.azurerm_redis_cache.main.primary_connection_string
+  }
+}
+
+output "storage_name" {
+  value = azurerm_storage_account.main.name
+}`,
+			Config: `
+rule "azurerm_sensitive_output_of_keys_and_connection_strings" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule(),
+					Message: "output \"storage_key\" exposes `primary_access_key` of `azurerm_storage_account.main`, mark it `sensitive = true` and allow it in `allow_outputs` if it must be exposed",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 11},
+						End:      hcl.Pos{Line: 3, Column: 58},
+					},
+				},
+				{
+					Rule:    NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule(),
+					Message: "output \"kube_config\" exposes `kube_config_raw` of `azurerm_kubernetes_cluster.main`, allow it in `allow_outputs` if it must be exposed",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 15},
+						End:      hcl.Pos{Line: 7, Column: 65},
+					},
+				},
+				{
+					Rule:    NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule(),
+					Message: "output \"redis\" exposes `primary_connection_string` of `data.azurerm_redis_cache.main`, mark it `sensitive = true` and allow it in `allow_outputs` if it must be exposed",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 14, Column: 25},
+						End:      hcl.Pos{Line: 14, Column: 80},
+					},
+				},
+			},
+		},
+		{
+			Name: "Allowed outputs",
+			Content: `
+output "kube_config" {
+  value     = azurerm_kubernetes_cluster.main.kube_config_raw
+  sensitive = true
+}
+
+output "kube_admin_config" {
+  value = azurerm_kubernetes_cluster.main.kube_admin_config_raw
+}`,
+			Config: `
+rule "azurerm_sensitive_output_of_keys_and_connection_strings" {
+  enabled       = true
+  allow_outputs = ["kube_*"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule(),
+					Message: "output \"kube_admin_config\" exposes `kube_admin_config_raw` of `azurerm_kubernetes_cluster.main`, mark it `sensitive = true` and allow it in `allow_outputs` if it must be exposed",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 11},
+						End:      hcl.Pos{Line: 8, Column: 64},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,172 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermSentinelAndDefenderPlanCoverageRule checks that landing-zone root modules enable the required Defender plans
+type AzurermSentinelAndDefenderPlanCoverageRule struct {
+	tflint.DefaultRule
+
+	pricingType  string
+	sentinelType string
+	plans        []string
+}
+
+type azurermSentinelAndDefenderPlanCoverageRuleConfig struct {
+	Plans           []string `hclext:"plans,optional"`
+	RequireSentinel bool     `hclext:"require_sentinel,optional"`
+	Enforce         *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermSentinelAndDefenderPlanCoverageRule returns new rule with default attributes
+func NewAzurermSentinelAndDefenderPlanCoverageRule() *AzurermSentinelAndDefenderPlanCoverageRule {
+	return &AzurermSentinelAndDefenderPlanCoverageRule{
+		pricingType:  "azurerm_security_center_subscription_pricing",
+		sentinelType: "azurerm_sentinel_log_analytics_workspace_onboarding",
+		plans: []string{
+			"AppServices",
+			"Arm",
+			"Containers",
+			"KeyVaults",
+			"SqlServers",
+			"StorageAccounts",
+			"VirtualMachines",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermSentinelAndDefenderPlanCoverageRule) Name() string {
+	return "azurerm_sentinel_and_defender_plan_coverage"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermSentinelAndDefenderPlanCoverageRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermSentinelAndDefenderPlanCoverageRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermSentinelAndDefenderPlanCoverageRule) Link() string {
+	return ""
+}
+
+// Check checks modules configuring the azurerm provider enable every required Defender plan on the Standard tier
+func (r *AzurermSentinelAndDefenderPlanCoverageRule) Check(runner tflint.Runner) error {
+	config := azurermSentinelAndDefenderPlanCoverageRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	plans := r.plans
+	if len(config.Plans) > 0 {
+		plans = config.Plans
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "provider", LabelNames: []string{"name"}, Body: &hclext.BodySchema{}},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	// Only root modules configure providers, child modules inherit them
+	var provider *hclext.Block
+	for _, block := range content.Blocks {
+		if block.Labels[0] == "azurerm" {
+			provider = block
+			break
+		}
+	}
+	if provider == nil {
+		return nil
+	}
+
+	pricings, err := runner.GetResourceContent(r.pricingType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "tier"},
+			{Name: "resource_type"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	covered := map[string]bool{}
+	for _, pricing := range pricings.Blocks {
+		attribute, exists := pricing.Body.Attributes["resource_type"]
+		if !exists {
+			continue
+		}
+
+		var resourceType string
+		err := runner.EvaluateExpr(attribute.Expr, &resourceType, nil)
+		err = runner.EnsureNoError(err, func() error {
+			tier, exists := pricing.Body.Attributes["tier"]
+			if !exists {
+				return nil
+			}
+			var value string
+			err := runner.EvaluateExpr(tier.Expr, &value, nil)
+			return runner.EnsureNoError(err, func() error {
+				if strings.EqualFold(value, "Standard") {
+					covered[strings.ToLower(resourceType)] = true
+				} else {
+					runner.EmitIssue(
+						r,
+						fmt.Sprintf("The %s Defender plan is on the \"%s\" tier, use \"Standard\"", resourceType, value),
+						tier.Expr.Range(),
+					)
+					// Only reported once, at the pricing resource
+					covered[strings.ToLower(resourceType)] = true
+				}
+				return nil
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	missing := []string{}
+	for _, plan := range plans {
+		if !covered[strings.ToLower(plan)] {
+			missing = append(missing, plan)
+		}
+	}
+	if len(missing) > 0 {
+		runner.EmitIssue(
+			r,
+			fmt.Sprintf("The module configures the azurerm provider but does not enable the following Defender plans with `%s`: %s.", r.pricingType, strings.Join(missing, ", ")),
+			provider.DefRange,
+		)
+	}
+
+	if config.RequireSentinel {
+		onboardings, err := runner.GetResourceContent(r.sentinelType, &hclext.BodySchema{}, nil)
+		if err != nil {
+			return err
+		}
+		if len(onboardings.Blocks) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("The module configures the azurerm provider but does not onboard a workspace to Sentinel with `%s`", r.sentinelType),
+				provider.DefRange,
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermSentinelAndDefenderPlanCoverage(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Child module",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  name = "main"
+}`,
+			Config: `
+rule "azurerm_sentinel_and_defender_plan_coverage" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Missing and free plans",
+			Content: `
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_security_center_subscription_pricing" "vms" {
+  tier          = "Standard"
+  resource_type = "VirtualMachines"
+}
+
+resource "azurerm_security_center_subscription_pricing" "kv" {
+  tier          = "Free"
+  resource_type = "KeyVaults"
+}`,
+			Config: `
+rule "azurerm_sentinel_and_defender_plan_coverage" {
+  enabled          = true
+  plans            = ["VirtualMachines", "KeyVaults", "StorageAccounts"]
+  require_sentinel = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSentinelAndDefenderPlanCoverageRule(),
+					Message: "The KeyVaults Defender plan is on the \"Free\" tier, use \"Standard\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 12, Column: 19},
+						End:      hcl.Pos{Line: 12, Column: 25},
+					},
+				},
+				{
+					Rule:    NewAzurermSentinelAndDefenderPlanCoverageRule(),
+					Message: "The module configures the azurerm provider but does not enable the following Defender plans with `azurerm_security_center_subscription_pricing`: StorageAccounts.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 19},
+					},
+				},
+				{
+					Rule:    NewAzurermSentinelAndDefenderPlanCoverageRule(),
+					Message: "The module configures the azurerm provider but does not onboard a workspace to Sentinel with `azurerm_sentinel_log_analytics_workspace_onboarding`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 19},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermSentinelAndDefenderPlanCoverageRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
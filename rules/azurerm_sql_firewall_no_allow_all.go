@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermSQLFirewallNoAllowAllRule checks that database firewall rules do not open the server to every address
+type AzurermSQLFirewallNoAllowAllRule struct {
+	tflint.DefaultRule
+
+	resourceTypes []string
+}
+
+type azurermSQLFirewallNoAllowAllRuleConfig struct {
+	AllowAzureServices *bool `hclext:"allow_azure_services,optional"`
+	Enforce            *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermSQLFirewallNoAllowAllRule returns new rule with default attributes
+func NewAzurermSQLFirewallNoAllowAllRule() *AzurermSQLFirewallNoAllowAllRule {
+	return &AzurermSQLFirewallNoAllowAllRule{
+		resourceTypes: []string{
+			"azurerm_sql_firewall_rule",
+			"azurerm_mssql_firewall_rule",
+			"azurerm_postgresql_firewall_rule",
+			"azurerm_postgresql_flexible_server_firewall_rule",
+			"azurerm_mysql_firewall_rule",
+			"azurerm_mysql_flexible_server_firewall_rule",
+			"azurerm_mariadb_firewall_rule",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermSQLFirewallNoAllowAllRule) Name() string {
+	return "azurerm_sql_firewall_no_allow_all"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermSQLFirewallNoAllowAllRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermSQLFirewallNoAllowAllRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermSQLFirewallNoAllowAllRule) Link() string {
+	return ""
+}
+
+// Check checks firewall rules do not span all addresses
+func (r *AzurermSQLFirewallNoAllowAllRule) Check(runner tflint.Runner) error {
+	config := azurermSQLFirewallNoAllowAllRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+	allowAzureServices := config.AllowAzureServices == nil || *config.AllowAzureServices
+
+	for _, resourceType := range r.resourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "start_ip_address"}, {Name: "end_ip_address"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			startAttribute, startExists := resource.Body.Attributes["start_ip_address"]
+			endAttribute, endExists := resource.Body.Attributes["end_ip_address"]
+			if !startExists || !endExists {
+				continue
+			}
+
+			var start, end string
+			err := runner.EvaluateExpr(startAttribute.Expr, &start, nil)
+			err = runner.EnsureNoError(err, func() error {
+				err := runner.EvaluateExpr(endAttribute.Expr, &end, nil)
+				return runner.EnsureNoError(err, func() error {
+					switch {
+					case start == "0.0.0.0" && end == "255.255.255.255":
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` allows access from every IP address", resource.Labels[0], resource.Labels[1]),
+							resource.DefRange,
+						)
+					case start == "0.0.0.0" && end == "0.0.0.0" && !allowAzureServices:
+						runner.EmitIssue(
+							r,
+							fmt.Sprintf("`%s.%s` allows access from all Azure services, which is forbidden by configuration", resource.Labels[0], resource.Labels[1]),
+							resource.DefRange,
+						)
+					}
+					return nil
+				})
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
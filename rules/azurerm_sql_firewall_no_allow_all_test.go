@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermSQLFirewallNoAllowAll(t *testing.T) {
+	content := `
+resource "azurerm_mssql_firewall_rule" "all" {
+  start_ip_address = "0.0.0.0"
+  end_ip_address   = "255.255.255.255"
+}
+
+resource "azurerm_postgresql_flexible_server_firewall_rule" "azure" {
+  start_ip_address = "0.0.0.0"
+  end_ip_address   = "0.0.0.0"
+}
+
+resource "azurerm_mysql_flexible_server_firewall_rule" "office" {
+  start_ip_address = "203.0.113.10"
+  end_ip_address   = "203.0.113.20"
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Azure services allowed by default",
+			Config: `
+rule "azurerm_sql_firewall_no_allow_all" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSQLFirewallNoAllowAllRule(),
+					Message: "`azurerm_mssql_firewall_rule.all` allows access from every IP address",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			Name: "Azure services forbidden",
+			Config: `
+rule "azurerm_sql_firewall_no_allow_all" {
+  enabled = true
+  allow_azure_services = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermSQLFirewallNoAllowAllRule(),
+					Message: "`azurerm_mssql_firewall_rule.all` allows access from every IP address",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermSQLFirewallNoAllowAllRule(),
+					Message: "`azurerm_postgresql_flexible_server_firewall_rule.azure` allows access from all Azure services, which is forbidden by configuration",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 1},
+						End:      hcl.Pos{Line: 7, Column: 68},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermSQLFirewallNoAllowAllRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,290 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermStaticSiteAndCdnCustomDomainHTTPSRule checks that CDN, Front Door and static site custom domains serve HTTPS with a modern TLS version.
+// Static site custom domains get a managed certificate, but only once the domain validates, so a TXT token validation must be published.
+type AzurermStaticSiteAndCdnCustomDomainHTTPSRule struct {
+	tflint.DefaultRule
+
+	// Blocks of which at least one configures HTTPS on a CDN endpoint custom domain
+	cdnHTTPSBlocks []string
+	// Static site custom domain resources, which validate in the same way
+	staticSiteResourceTypes []string
+}
+
+type azurermStaticSiteAndCdnCustomDomainHTTPSRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule returns new rule with default attributes
+func NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule() *AzurermStaticSiteAndCdnCustomDomainHTTPSRule {
+	return &AzurermStaticSiteAndCdnCustomDomainHTTPSRule{
+		cdnHTTPSBlocks:          []string{"cdn_managed_https", "user_managed_https"},
+		staticSiteResourceTypes: []string{"azurerm_static_site_custom_domain", "azurerm_static_web_app_custom_domain"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) Name() string {
+	return "azurerm_static_site_and_cdn_custom_domain_https"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) Link() string {
+	return ""
+}
+
+// Check checks custom domains serve HTTPS with TLS 1.2 and static site custom domains can validate
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) Check(runner tflint.Runner) error {
+	config := azurermStaticSiteAndCdnCustomDomainHTTPSRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	if err := r.checkCdnEndpointDomains(runner); err != nil {
+		return err
+	}
+	if err := r.checkFrontDoorDomains(runner); err != nil {
+		return err
+	}
+	return r.checkStaticSiteDomains(runner)
+}
+
+// checkCdnEndpointDomains checks CDN endpoint custom domains configure HTTPS without allowing TLS 1.0
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) checkCdnEndpointDomains(runner tflint.Runner) error {
+	schema := &hclext.BodySchema{}
+	for _, blockType := range r.cdnHTTPSBlocks {
+		schema.Blocks = append(schema.Blocks, hclext.BlockSchema{
+			Type: blockType,
+			Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "tls_version"}}},
+		})
+	}
+
+	resources, err := runner.GetResourceContent("azurerm_cdn_endpoint_custom_domain", schema, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+		if len(resource.Body.Blocks) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` does not configure HTTPS, add a `%s` block", address, strings.Join(r.cdnHTTPSBlocks, "` or `")),
+				resource.DefRange,
+			)
+			continue
+		}
+
+		for _, https := range resource.Body.Blocks {
+			attribute, exists := https.Body.Attributes["tls_version"]
+			if !exists {
+				continue
+			}
+
+			var version string
+			err := runner.EvaluateExpr(attribute.Expr, &version, nil)
+			err = runner.EnsureNoError(err, func() error {
+				// TLS12 is the default, None and TLS10 both allow TLS 1.0 clients
+				if strings.EqualFold(version, "TLS12") {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s` sets `%s.tls_version = \"%s\"`, which allows TLS 1.0, use \"TLS12\"", address, https.Type, version),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkFrontDoorDomains checks the `tls` block of Front Door custom domains, which the provider requires
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) checkFrontDoorDomains(runner tflint.Runner) error {
+	resources, err := runner.GetResourceContent("azurerm_cdn_frontdoor_custom_domain", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "tls",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "certificate_type"},
+						{Name: "minimum_tls_version"},
+						{Name: "cdn_frontdoor_secret_id"},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+
+		for _, tls := range resource.Body.Blocks {
+			if attribute, exists := tls.Body.Attributes["minimum_tls_version"]; exists {
+				var version string
+				err := runner.EvaluateExpr(attribute.Expr, &version, nil)
+				err = runner.EnsureNoError(err, func() error {
+					if !strings.EqualFold(version, "TLS10") {
+						return nil
+					}
+					return runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s` allows TLS 1.0, set `tls.minimum_tls_version = \"TLS12\"`", address),
+						attribute.Expr.Range(),
+					)
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			attribute, exists := tls.Body.Attributes["certificate_type"]
+			if !exists {
+				continue
+			}
+			if _, exists := tls.Body.Attributes["cdn_frontdoor_secret_id"]; exists {
+				continue
+			}
+
+			var certificateType string
+			err := runner.EvaluateExpr(attribute.Expr, &certificateType, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if certificateType != "CustomerCertificate" {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s` uses a customer certificate but does not set `tls.cdn_frontdoor_secret_id`, so there is no certificate to serve", address),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkStaticSiteDomains checks static site custom domains validated by a TXT token publish that token,
+// without which the domain never validates and its managed certificate is never issued
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) checkStaticSiteDomains(runner tflint.Runner) error {
+	published, err := r.publishedValidationTokens(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range r.staticSiteResourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "validation_type"}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes["validation_type"]
+			if !exists {
+				continue
+			}
+			address := resource.Labels[0] + "." + resource.Labels[1]
+
+			var validationType string
+			err := runner.EvaluateExpr(attribute.Expr, &validationType, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if validationType != "dns-txt-token" || published[address] {
+					return nil
+				}
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s` validates with a TXT token, but no `azurerm_dns_txt_record` or output uses its `validation_token`, so the domain never validates and gets no certificate", address),
+					attribute.Expr.Range(),
+				)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// publishedValidationTokens returns the addresses of the resources whose `validation_token` is used
+// by a DNS TXT record in the module or exposed by an output for DNS managed elsewhere
+func (r *AzurermStaticSiteAndCdnCustomDomainHTTPSRule) publishedValidationTokens(runner tflint.Runner) (map[string]bool, error) {
+	published := map[string]bool{}
+	markPublished := func(attribute *hclext.Attribute) {
+		for _, traversal := range attribute.Expr.Variables() {
+			if address, name := referencedAttribute(traversal); name == "validation_token" {
+				published[address] = true
+			}
+		}
+	}
+
+	records, err := runner.GetResourceContent("azurerm_dns_txt_record", &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "record",
+				Body: &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "value"}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, resource := range records.Blocks {
+		for _, record := range resource.Body.Blocks {
+			if attribute, exists := record.Body.Attributes["value"]; exists {
+				markPublished(attribute)
+			}
+		}
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body:       &hclext.BodySchema{Attributes: []hclext.AttributeSchema{{Name: "value"}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, output := range content.Blocks {
+		if attribute, exists := output.Body.Attributes["value"]; exists {
+			markPublished(attribute)
+		}
+	}
+
+	return published, nil
+}
@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermStaticSiteAndCdnCustomDomainHTTPS(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "CDN custom domain without HTTPS",
+			Content: `
+resource "azurerm_cdn_endpoint_custom_domain" "main" {
+  host_name = "www.contoso.com"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+					Message: "`azurerm_cdn_endpoint_custom_domain.main` does not configure HTTPS, add a `cdn_managed_https` or `user_managed_https` block",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 53},
+					},
+				},
+			},
+		},
+		{
+			Name: "CDN custom domain with CDN managed HTTPS",
+			Content: `
+resource "azurerm_cdn_endpoint_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  cdn_managed_https {
+    certificate_type = "Dedicated"
+    protocol_type    = "ServerNameIndication"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "CDN custom domain with user managed HTTPS",
+			Content: `
+resource "azurerm_cdn_endpoint_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  user_managed_https {
+    key_vault_secret_id = azurerm_key_vault_certificate.www.secret_id
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "CDN custom domain allowing TLS 1.0",
+			Content: `
+resource "azurerm_cdn_endpoint_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  cdn_managed_https {
+    certificate_type = "Dedicated"
+    protocol_type    = "ServerNameIndication"
+    tls_version      = "TLS10"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+					Message: "`azurerm_cdn_endpoint_custom_domain.main` sets `cdn_managed_https.tls_version = \"TLS10\"`, which allows TLS 1.0, use \"TLS12\"",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 24},
+						End:      hcl.Pos{Line: 8, Column: 31},
+					},
+				},
+			},
+		},
+		{
+			Name: "Front Door custom domain allowing TLS 1.0",
+			Content: `
+resource "azurerm_cdn_frontdoor_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  tls {
+    certificate_type    = "ManagedCertificate"
+    minimum_tls_version = "TLS10"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+					Message: "`azurerm_cdn_frontdoor_custom_domain.main` allows TLS 1.0, set `tls.minimum_tls_version = \"TLS12\"`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 7, Column: 27},
+						End:      hcl.Pos{Line: 7, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "Front Door custom domain with a customer certificate but no secret",
+			Content: `
+resource "azurerm_cdn_frontdoor_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  tls {
+    certificate_type = "CustomerCertificate"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+					Message: "`azurerm_cdn_frontdoor_custom_domain.main` uses a customer certificate but does not set `tls.cdn_frontdoor_secret_id`, so there is no certificate to serve",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 24},
+						End:      hcl.Pos{Line: 6, Column: 45},
+					},
+				},
+			},
+		},
+		{
+			Name: "Front Door custom domain with managed TLS",
+			Content: `
+resource "azurerm_cdn_frontdoor_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  tls {
+    certificate_type    = "ManagedCertificate"
+    minimum_tls_version = "TLS12"
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Front Door custom domain with a customer certificate",
+			Content: `
+resource "azurerm_cdn_frontdoor_custom_domain" "main" {
+  host_name = "www.contoso.com"
+
+  tls {
+    certificate_type        = "CustomerCertificate"
+    cdn_frontdoor_secret_id = azurerm_cdn_frontdoor_secret.www.id
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Static web app custom domain with an unpublished TXT token",
+			Content: `
+resource "azurerm_static_web_app_custom_domain" "www" {
+  static_web_app_id = azurerm_static_web_app.main.id
+  domain_name       = "www.contoso.com"
+  validation_type   = "dns-txt-token"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+					Message: "`azurerm_static_web_app_custom_domain.www` validates with a TXT token, but no `azurerm_dns_txt_record` or output uses its `validation_token`, so the domain never validates and gets no certificate",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 23},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+			},
+		},
+		{
+			Name: "Static site custom domain with a TXT record",
+			Content: `
+resource "azurerm_static_site_custom_domain" "www" {
+  static_site_id  = azurerm_static_site.main.id
+  domain_name     = "www.contoso.com"
+  validation_type = "dns-txt-token"
+}
+
+resource "azurerm_dns_txt_record" "www" {
+  name                = "_dnsauth.www"
+  zone_name           = "contoso.com"
+  resource_group_name = "dns"
+  ttl                 = 300
+
+  record {
+    value = azurerm_static_site_custom_domain.www.validation_token
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Static web app custom domain with the TXT token output",
+			Content: `
+resource "azurerm_static_web_app_custom_domain" "www" {
+  static_web_app_id = azurerm_static_web_app.main.id
+  domain_name       = "www.contoso.com"
+  validation_type   = "dns-txt-token"
+}
+
+output "validation_token" {
+  value = azurerm_static_web_app_custom_domain.www.validation_token
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Static web app custom domain with CNAME delegation",
+			Content: `
+resource "azurerm_static_web_app_custom_domain" "www" {
+  static_web_app_id = azurerm_static_web_app.main.id
+  domain_name       = "www.contoso.com"
+  validation_type   = "cname-delegation"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_static_site_and_cdn_custom_domain_https" {
+  enabled = true
+}`
+	rule := NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
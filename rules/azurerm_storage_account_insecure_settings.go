@@ -0,0 +1,116 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermStorageAccountInsecureSettingsRule checks that storage accounts enforce HTTPS and TLS 1.2
+// and do not allow public blobs
+type AzurermStorageAccountInsecureSettingsRule struct {
+	tflint.DefaultRule
+}
+
+type azurermStorageAccountInsecureSettingsRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// insecureTLSVersions are the `min_tls_version` values below TLS 1.2
+var insecureTLSVersions = []string{"TLS1_0", "TLS1_1"}
+
+// NewAzurermStorageAccountInsecureSettingsRule returns new rule with default attributes
+func NewAzurermStorageAccountInsecureSettingsRule() *AzurermStorageAccountInsecureSettingsRule {
+	return &AzurermStorageAccountInsecureSettingsRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermStorageAccountInsecureSettingsRule) Name() string {
+	return "azurerm_storage_account_insecure_settings"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermStorageAccountInsecureSettingsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermStorageAccountInsecureSettingsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermStorageAccountInsecureSettingsRule) Link() string {
+	return ""
+}
+
+// Check checks storage accounts for settings that disable secure transfer, allow TLS versions below 1.2
+// or allow anonymous access to blobs, which Azure Policy otherwise only reports after deployment.
+// Only explicit settings are reported, since the defaults of the provider are secure.
+func (r *AzurermStorageAccountInsecureSettingsRule) Check(runner tflint.Runner) error {
+	config := azurermStorageAccountInsecureSettingsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	resources, err := runner.GetResourceContent("azurerm_storage_account", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			// https_traffic_only_enabled replaced enable_https_traffic_only in azurerm v4
+			{Name: "enable_https_traffic_only"},
+			{Name: "https_traffic_only_enabled"},
+			{Name: "min_tls_version"},
+			{Name: "allow_nested_items_to_be_public"},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+
+		for _, name := range []string{"enable_https_traffic_only", "https_traffic_only_enabled"} {
+			err := evaluateOptionalBool(runner, resource.Body, name, true, func(enabled bool) error {
+				if enabled {
+					return nil
+				}
+				return runner.EmitIssue(r, fmt.Sprintf("`%s` allows HTTP traffic, set `%s = true` to require secure transfer", address, name), resource.Body.Attributes[name].Expr.Range())
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if attribute, exists := resource.Body.Attributes["min_tls_version"]; exists {
+			var version string
+			err := runner.EvaluateExpr(attribute.Expr, &version, nil)
+			err = runner.EnsureNoError(err, func() error {
+				if !stringInSlice(version, insecureTLSVersions) {
+					return nil
+				}
+				return runner.EmitIssue(r, fmt.Sprintf("`%s` allows \"%s\", set `min_tls_version = \"TLS1_2\"` to require TLS 1.2", address, version), attribute.Expr.Range())
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Public containers and blobs are allowed by default
+		err := evaluateOptionalBool(runner, resource.Body, "allow_nested_items_to_be_public", true, func(allowed bool) error {
+			if !allowed {
+				return nil
+			}
+			if attribute, exists := resource.Body.Attributes["allow_nested_items_to_be_public"]; exists {
+				return runner.EmitIssue(r, fmt.Sprintf("`%s` allows containers and blobs to be public, set `allow_nested_items_to_be_public = false`", address), attribute.Expr.Range())
+			}
+			return runner.EmitIssue(r, fmt.Sprintf("`%s` does not set `allow_nested_items_to_be_public = false`, so containers and blobs are allowed to be public", address), resource.DefRange)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
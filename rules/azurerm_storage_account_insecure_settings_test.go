@@ -0,0 +1,122 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermStorageAccountInsecureSettings(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Insecure settings",
+			Content: `
+resource "azurerm_storage_account" "legacy" {
+  enable_https_traffic_only       = false
+  min_tls_version                 = "TLS1_0"
+  allow_nested_items_to_be_public = true
+}
+
+resource "azurerm_storage_account" "v4" {
+  https_traffic_only_enabled = false
+  min_tls_version            = "TLS1_2"
+}
+
+resource "azurerm_storage_account" "defaults" {
+  name = "stdefaults"
+}`,
+			Config: `
+rule "azurerm_storage_account_insecure_settings" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.legacy` allows HTTP traffic, set `enable_https_traffic_only = true` to require secure transfer",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 37},
+						End:      hcl.Pos{Line: 3, Column: 42},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.legacy` allows \"TLS1_0\", set `min_tls_version = \"TLS1_2\"` to require TLS 1.2",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 37},
+						End:      hcl.Pos{Line: 4, Column: 45},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.legacy` allows containers and blobs to be public, set `allow_nested_items_to_be_public = false`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 37},
+						End:      hcl.Pos{Line: 5, Column: 41},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.v4` allows HTTP traffic, set `https_traffic_only_enabled = true` to require secure transfer",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 32},
+						End:      hcl.Pos{Line: 9, Column: 37},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.v4` does not set `allow_nested_items_to_be_public = false`, so containers and blobs are allowed to be public",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 40},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageAccountInsecureSettingsRule(),
+					Message: "`azurerm_storage_account.defaults` does not set `allow_nested_items_to_be_public = false`, so containers and blobs are allowed to be public",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 13, Column: 1},
+						End:      hcl.Pos{Line: 13, Column: 46},
+					},
+				},
+			},
+		},
+		{
+			Name: "Secure settings",
+			Content: `
+resource "azurerm_storage_account" "secure" {
+  https_traffic_only_enabled      = true
+  min_tls_version                 = "TLS1_2"
+  allow_nested_items_to_be_public = false
+}`,
+			Config: `
+rule "azurerm_storage_account_insecure_settings" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermStorageAccountInsecureSettingsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
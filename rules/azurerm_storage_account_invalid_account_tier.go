@@ -2,7 +2,9 @@ package rules
 
 import (
 	"fmt"
+	"strconv"
 
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/sku"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 )
@@ -15,6 +17,10 @@ type AzurermStorageAccountInvalidAccountTierRule struct {
 	attributeName string
 }
 
+type azurermStorageAccountInvalidAccountTierRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
 // NewAzurermStorageAccountInvalidAccountTierRule returns new rule with default attributes
 func NewAzurermStorageAccountInvalidAccountTierRule() *AzurermStorageAccountInvalidAccountTierRule {
 	return &AzurermStorageAccountInvalidAccountTierRule{
@@ -46,6 +52,12 @@ func (r *AzurermStorageAccountInvalidAccountTierRule) Link() string {
 
 // Check checks the pattern is valid
 func (r *AzurermStorageAccountInvalidAccountTierRule) Check(runner tflint.Runner) error {
+	config := azurermStorageAccountInvalidAccountTierRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
 	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
 		Attributes: []hclext.AttributeSchema{{Name: r.attributeName}},
 	}, nil)
@@ -63,17 +75,17 @@ func (r *AzurermStorageAccountInvalidAccountTierRule) Check(runner tflint.Runner
 		err := runner.EvaluateExpr(attribute.Expr, &val, nil)
 
 		err = runner.EnsureNoError(err, func() error {
-			found := false
-			for _, item := range validAccountTier {
-				if item == val {
-					found = true
+			if !sku.Valid(sku.StorageAccountTier, val) {
+				var fix *issueFix
+				if s := suggestion(val, sku.Names(sku.StorageAccountTier)); s != "" {
+					fix = &issueFix{Range: attribute.Expr.Range(), Replacement: strconv.Quote(s)}
 				}
-			}
-			if !found {
-				runner.EmitIssue(
+				emitIssueWithFix(
+					runner,
 					r,
-					fmt.Sprintf(`"%s" is an invalid value as Account Tier`, val),
+					fmt.Sprintf(`"%s" is an invalid value as Account Tier.%s`, val, didYouMean(val, sku.Names(sku.StorageAccountTier))),
 					attribute.Expr.Range(),
+					fix,
 				)
 			}
 			return nil
@@ -84,4 +96,4 @@ func (r *AzurermStorageAccountInvalidAccountTierRule) Check(runner tflint.Runner
 	}
 
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,86 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermStorageAccountInvalidAccountTier(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Typo in account tier",
+			Content: `
+resource "azurerm_storage_account" "sa" {
+  account_tier = "standard"
+}`,
+			Config: `
+rule "azurerm_storage_account_invalid_account_tier" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageAccountInvalidAccountTierRule(),
+					Message: "\"standard\" is an invalid value as Account Tier. Did you mean \"Standard\"?",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 18},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "Unknown account tier",
+			Content: `
+resource "azurerm_storage_account" "sa" {
+  account_tier = "Basic"
+}`,
+			Config: `
+rule "azurerm_storage_account_invalid_account_tier" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageAccountInvalidAccountTierRule(),
+					Message: "\"Basic\" is an invalid value as Account Tier.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 18},
+						End:      hcl.Pos{Line: 3, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "Valid account tier",
+			Content: `
+resource "azurerm_storage_account" "sa" {
+  account_tier = "Premium"
+}`,
+			Config: `
+rule "azurerm_storage_account_invalid_account_tier" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermStorageAccountInvalidAccountTierRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
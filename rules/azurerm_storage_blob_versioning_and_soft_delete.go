@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermStorageBlobVersioningAndSoftDeleteRule checks that storage accounts hosting blobs enable versioning and soft delete
+type AzurermStorageBlobVersioningAndSoftDeleteRule struct {
+	tflint.DefaultRule
+
+	resourceType string
+	blobUsages   map[string][]string
+}
+
+type azurermStorageBlobVersioningAndSoftDeleteRuleConfig struct {
+	AllAccounts bool  `hclext:"all_accounts,optional"`
+	Enforce     *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermStorageBlobVersioningAndSoftDeleteRule returns new rule with default attributes
+func NewAzurermStorageBlobVersioningAndSoftDeleteRule() *AzurermStorageBlobVersioningAndSoftDeleteRule {
+	return &AzurermStorageBlobVersioningAndSoftDeleteRule{
+		resourceType: "azurerm_storage_account",
+		// Resources storing blobs and the attributes referencing their storage account
+		blobUsages: map[string][]string{
+			"azurerm_storage_container":                 {"storage_account_name", "storage_account_id"},
+			"azurerm_storage_blob":                      {"storage_account_name"},
+			"azurerm_storage_data_lake_gen2_filesystem": {"storage_account_id"},
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) Name() string {
+	return "azurerm_storage_blob_versioning_and_soft_delete"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) Link() string {
+	return ""
+}
+
+// Check checks blob versioning and soft delete are enabled on storage accounts hosting blobs
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) Check(runner tflint.Runner) error {
+	config := azurermStorageBlobVersioningAndSoftDeleteRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	hostingBlobs, err := r.accountsHostingBlobs(runner)
+	if err != nil {
+		return err
+	}
+
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "blob_properties",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "versioning_enabled"}},
+					Blocks:     []hclext.BlockSchema{{Type: "delete_retention_policy", Body: &hclext.BodySchema{}}},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+		if !config.AllAccounts && !hostingBlobs[address] {
+			continue
+		}
+
+		properties := resource.Body.Blocks.ByType()["blob_properties"]
+		if len(properties) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` hosts blobs but has no `blob_properties` block enabling versioning and soft delete", address),
+				resource.DefRange,
+			)
+			continue
+		}
+
+		for _, block := range properties {
+			if len(block.Body.Blocks.ByType()["delete_retention_policy"]) == 0 {
+				runner.EmitIssue(r, "`blob_properties` has no `delete_retention_policy` block, so deleted blobs cannot be recovered", block.DefRange)
+			}
+
+			attribute, exists := block.Body.Attributes["versioning_enabled"]
+			if !exists {
+				runner.EmitIssue(r, "`blob_properties` does not set `versioning_enabled = true`", block.DefRange)
+				continue
+			}
+
+			var enabled bool
+			err := evaluateBool(runner, attribute.Expr, &enabled)
+			err = runner.EnsureNoError(err, func() error {
+				if !enabled {
+					runner.EmitIssue(r, "`versioning_enabled` should be true", attribute.Expr.Range())
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// accountsHostingBlobs returns the addresses of the storage accounts referenced by blob resources
+func (r *AzurermStorageBlobVersioningAndSoftDeleteRule) accountsHostingBlobs(runner tflint.Runner) (map[string]bool, error) {
+	return referencedByResources(runner, r.blobUsages)
+}
@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermStorageBlobVersioningAndSoftDelete(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Account hosting a container without blob properties",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  name = "logs"
+}
+
+resource "azurerm_storage_container" "logs" {
+  storage_account_name = azurerm_storage_account.logs.name
+}`,
+			Config: `
+rule "azurerm_storage_blob_versioning_and_soft_delete" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageBlobVersioningAndSoftDeleteRule(),
+					Message: "`azurerm_storage_account.logs` hosts blobs but has no `blob_properties` block enabling versioning and soft delete",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 42},
+					},
+				},
+			},
+		},
+		{
+			Name: "Versioning disabled and no retention policy",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  blob_properties {
+    versioning_enabled = false
+  }
+}`,
+			Config: `
+rule "azurerm_storage_blob_versioning_and_soft_delete" {
+  enabled = true
+  all_accounts = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageBlobVersioningAndSoftDeleteRule(),
+					Message: "`blob_properties` has no `delete_retention_policy` block, so deleted blobs cannot be recovered",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageBlobVersioningAndSoftDeleteRule(),
+					Message: "`versioning_enabled` should be true",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 26},
+						End:      hcl.Pos{Line: 4, Column: 31},
+					},
+				},
+			},
+		},
+		{
+			Name: "Account without blobs is skipped",
+			Content: `
+resource "azurerm_storage_account" "files" {
+  name = "files"
+}`,
+			Config: `
+rule "azurerm_storage_blob_versioning_and_soft_delete" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Versioning and soft delete enabled",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  blob_properties {
+    versioning_enabled = true
+
+    delete_retention_policy {
+      days = 7
+    }
+  }
+}
+
+resource "azurerm_storage_container" "logs" {
+  storage_account_id = azurerm_storage_account.logs.id
+}`,
+			Config: `
+rule "azurerm_storage_blob_versioning_and_soft_delete" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermStorageBlobVersioningAndSoftDeleteRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
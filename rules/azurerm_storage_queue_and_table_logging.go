@@ -0,0 +1,190 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermStorageQueueAndTableLoggingRule checks that storage accounts hosting queues and tables log their requests
+type AzurermStorageQueueAndTableLoggingRule struct {
+	tflint.DefaultRule
+
+	resourceType          string
+	diagnosticSettingType string
+	queueUsages           map[string][]string
+	tableUsages           map[string][]string
+	// Operations queue logging must record
+	loggedOperations []string
+}
+
+type azurermStorageQueueAndTableLoggingRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermStorageQueueAndTableLoggingRule returns new rule with default attributes
+func NewAzurermStorageQueueAndTableLoggingRule() *AzurermStorageQueueAndTableLoggingRule {
+	return &AzurermStorageQueueAndTableLoggingRule{
+		resourceType:          "azurerm_storage_account",
+		diagnosticSettingType: "azurerm_monitor_diagnostic_setting",
+		// Resources storing queues and tables and the attributes referencing their storage account
+		queueUsages: map[string][]string{
+			"azurerm_storage_queue": {"storage_account_name", "storage_account_id"},
+		},
+		tableUsages: map[string][]string{
+			"azurerm_storage_table": {"storage_account_name", "storage_account_id"},
+		},
+		loggedOperations: []string{"delete", "read", "write"},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermStorageQueueAndTableLoggingRule) Name() string {
+	return "azurerm_storage_queue_and_table_logging"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermStorageQueueAndTableLoggingRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermStorageQueueAndTableLoggingRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermStorageQueueAndTableLoggingRule) Link() string {
+	return ""
+}
+
+// Check checks storage accounts hosting queues configure `queue_properties.logging` or a diagnostic setting,
+// and storage accounts hosting tables configure a diagnostic setting for the table service
+func (r *AzurermStorageQueueAndTableLoggingRule) Check(runner tflint.Runner) error {
+	config := azurermStorageQueueAndTableLoggingRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	hostingQueues, err := referencedByResources(runner, r.queueUsages)
+	if err != nil {
+		return err
+	}
+	hostingTables, err := referencedByResources(runner, r.tableUsages)
+	if err != nil {
+		return err
+	}
+	if len(hostingQueues) == 0 && len(hostingTables) == 0 {
+		return nil
+	}
+
+	diagnosed, err := r.diagnosedServices(runner)
+	if err != nil {
+		return err
+	}
+
+	operations := &hclext.BodySchema{}
+	for _, operation := range r.loggedOperations {
+		operations.Attributes = append(operations.Attributes, hclext.AttributeSchema{Name: operation})
+	}
+	resources, err := runner.GetResourceContent(r.resourceType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "queue_properties",
+				Body: &hclext.BodySchema{Blocks: []hclext.BlockSchema{{Type: "logging", Body: operations}}},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		address := resource.Labels[0] + "." + resource.Labels[1]
+
+		if hostingTables[address] && !diagnosed[address+"/tableServices"] {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` hosts tables but no `%s` targets its table service, so table requests are not logged", address, r.diagnosticSettingType),
+				resource.DefRange,
+			)
+		}
+
+		if !hostingQueues[address] || diagnosed[address+"/queueServices"] {
+			continue
+		}
+		logging := hclext.Blocks{}
+		for _, properties := range resource.Body.Blocks {
+			logging = append(logging, properties.Body.Blocks...)
+		}
+		if len(logging) == 0 {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` hosts queues but has no `queue_properties.logging` block or diagnostic setting for its queue service", address),
+				resource.DefRange,
+			)
+			continue
+		}
+
+		for _, block := range logging {
+			for _, operation := range r.loggedOperations {
+				attribute, exists := block.Body.Attributes[operation]
+				if !exists {
+					runner.EmitIssue(r, fmt.Sprintf("Queue logging does not set `%s = true`", operation), block.DefRange)
+					continue
+				}
+
+				var enabled bool
+				err := evaluateBool(runner, attribute.Expr, &enabled)
+				err = runner.EnsureNoError(err, func() error {
+					if !enabled {
+						runner.EmitIssue(r, fmt.Sprintf("Queue logging should record %s requests", operation), attribute.Expr.Range())
+					}
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// diagnosedServices returns the storage services targeted by diagnostic settings,
+// keyed like "azurerm_storage_account.main/queueServices"
+func (r *AzurermStorageQueueAndTableLoggingRule) diagnosedServices(runner tflint.Runner) (map[string]bool, error) {
+	settings, err := runner.GetResourceContent(r.diagnosticSettingType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "target_resource_id"}},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	services := map[string]bool{}
+	for _, setting := range settings.Blocks {
+		attribute, exists := setting.Body.Attributes["target_resource_id"]
+		if !exists {
+			continue
+		}
+		file, err := runner.GetFile(attribute.Expr.Range().Filename)
+		if err != nil {
+			return nil, err
+		}
+		// The service is usually appended to the account ID, e.g. "${azurerm_storage_account.main.id}/queueServices/default"
+		source := string(attribute.Expr.Range().SliceBytes(file.Bytes))
+
+		for _, address := range referencedResources(attribute.Expr) {
+			for _, service := range []string{"queueServices", "tableServices"} {
+				if strings.Contains(source, service) {
+					services[address+"/"+service] = true
+				}
+			}
+		}
+	}
+	return services, nil
+}
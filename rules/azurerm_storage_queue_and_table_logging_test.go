@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermStorageQueueAndTableLogging(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Queue and table accounts",
+			Content: `
+resource "azurerm_storage_account" "queues" {
+  name = "queues"
+}
+
+resource "azurerm_storage_account" "partial" {
+  queue_properties {
+    logging {
+      delete = true
+      read   = false
+    }
+  }
+}
+
+resource "azurerm_storage_account" "tables" {
+  name = "tables"
+}
+
+resource "azurerm_storage_account" "unused" {
+  name = "unused"
+}
+
+resource "azurerm_storage_queue" "jobs" {
+  storage_account_name = azurerm_storage_account.queues.name
+}
+
+resource "azurerm_storage_queue" "events" {
+  storage_account_name = azurerm_storage_account.partial.name
+}
+
+resource "azurerm_storage_table" "state" {
+  storage_account_name = azurerm_storage_account.tables.name
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermStorageQueueAndTableLoggingRule(),
+					Message: "`azurerm_storage_account.queues` hosts queues but has no `queue_properties.logging` block or diagnostic setting for its queue service",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 44},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageQueueAndTableLoggingRule(),
+					Message: "Queue logging should record read requests",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 16},
+						End:      hcl.Pos{Line: 10, Column: 21},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageQueueAndTableLoggingRule(),
+					Message: "Queue logging does not set `write = true`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 5},
+						End:      hcl.Pos{Line: 8, Column: 12},
+					},
+				},
+				{
+					Rule:    NewAzurermStorageQueueAndTableLoggingRule(),
+					Message: "`azurerm_storage_account.tables` hosts tables but no `azurerm_monitor_diagnostic_setting` targets its table service, so table requests are not logged",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 15, Column: 1},
+						End:      hcl.Pos{Line: 15, Column: 44},
+					},
+				},
+			},
+		},
+		{
+			Name: "Diagnostic settings",
+			Content: `
+resource "azurerm_storage_account" "main" {
+  name = "main"
+}
+
+resource "azurerm_storage_queue" "jobs" {
+  storage_account_name = azurerm_storage_account.main.name
+}
+
+resource "azurerm_storage_table" "state" {
+  storage_account_name = azurerm_storage_account.main.name
+}
+
+resource "azurerm_monitor_diagnostic_setting" "queues" {
+  target_resource_id = "${azurerm_storage_account.main.id}/queueServices/default"
+}
+
+resource "azurerm_monitor_diagnostic_setting" "tables" {
+  target_resource_id = "${azurerm_storage_account.main.id}/tableServices/default"
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_storage_queue_and_table_logging" {
+  enabled = true
+}`
+	rule := NewAzurermStorageQueueAndTableLoggingRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,147 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule checks that tag values have no surrounding whitespace
+// and are spelled like their canonical values
+type AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule struct {
+	tflint.DefaultRule
+}
+
+type azurermTagValueNoTrailingWhitespaceOrCaseDriftRuleConfig struct {
+	Values  map[string][]string `hclext:"values,optional"`
+	Enforce *bool               `hclext:"enforce,optional"`
+}
+
+// NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule returns new rule with default attributes
+func NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule() *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule {
+	return &AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule) Name() string {
+	return "azurerm_tag_value_no_trailing_whitespace_or_case_drift"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule) Link() string {
+	return ""
+}
+
+// Check checks tag values for leading or trailing whitespace and for values that differ only by case
+// from a canonical value, since cost reports group "prod" and "Prod " separately
+func (r *AzurermTagValueNoTrailingWhitespaceOrCaseDriftRule) Check(runner tflint.Runner) error {
+	config := azurermTagValueNoTrailingWhitespaceOrCaseDriftRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+	declared, err := declaredResourceTypes(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, resourceType := range taggableResources() {
+		if !declared[resourceType] {
+			continue
+		}
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: tagsAttributeName}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes[tagsAttributeName]
+			if !exists {
+				continue
+			}
+			items, resolved := resolveMapItems(runner, attribute.Expr, locals)
+			if !resolved {
+				continue
+			}
+
+			keys := make([]string, 0, len(items))
+			for key := range items {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				value, ok := literalString(items[key])
+				if !ok {
+					continue
+				}
+				canonical := canonicalTagValue(value, config.Values[key])
+				if canonical == value {
+					continue
+				}
+
+				problem := "has leading or trailing whitespace"
+				if strings.TrimSpace(value) != canonical {
+					problem = fmt.Sprintf("differs only by case from the canonical value \"%s\"", canonical)
+				}
+				if err := emitIssueWithFix(
+					runner,
+					r,
+					fmt.Sprintf("The value %q of the \"%s\" tag of `%s.%s` %s.", value, key, resource.Labels[0], resource.Labels[1], problem),
+					items[key].Range(),
+					tagValueFix(items[key], canonical),
+				); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalTagValue returns the value without surrounding whitespace, spelled like the allowed value it matches ignoring case
+func canonicalTagValue(value string, allowed []string) string {
+	trimmed := strings.TrimSpace(value)
+	for _, candidate := range allowed {
+		if candidate == trimmed {
+			return trimmed
+		}
+	}
+	for _, candidate := range allowed {
+		if strings.EqualFold(candidate, trimmed) {
+			return candidate
+		}
+	}
+	return trimmed
+}
+
+// tagValueFix replaces a quoted literal value with the canonical value
+func tagValueFix(expr hcl.Expression, canonical string) *issueFix {
+	template, ok := expr.(*hclsyntax.TemplateExpr)
+	if !ok || !template.IsStringLiteral() {
+		return nil
+	}
+	return &issueFix{Range: expr.Range(), Replacement: strconv.Quote(canonical)}
+}
@@ -0,0 +1,133 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermTagValueNoTrailingWhitespaceOrCaseDrift(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Surrounding whitespace",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform "
+  }
+}`,
+			Config: `
+rule "azurerm_tag_value_no_trailing_whitespace_or_case_drift" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule(),
+					Message: "The value \"platform \" of the \"Owner\" tag of `azurerm_resource_group.main` has leading or trailing whitespace.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 13},
+						End:      hcl.Pos{Line: 4, Column: 24},
+					},
+				},
+			},
+		},
+		{
+			Name: "Case drift from a local",
+			Content: `
+locals {
+  common_tags = {
+    Environment = " Prod"
+  }
+}
+
+resource "azurerm_key_vault" "main" {
+  tags = merge(local.common_tags, { Owner = "platform" })
+}`,
+			Config: `
+rule "azurerm_tag_value_no_trailing_whitespace_or_case_drift" {
+  enabled = true
+  values = {
+    Environment = ["prod", "dev"]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule(),
+					Message: "The value \" Prod\" of the \"Environment\" tag of `azurerm_key_vault.main` differs only by case from the canonical value \"prod\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 19},
+						End:      hcl.Pos{Line: 4, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "Canonical values",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Environment = "prod"
+    Owner       = "Platform"
+  }
+}`,
+			Config: `
+rule "azurerm_tag_value_no_trailing_whitespace_or_case_drift" {
+  enabled = true
+  values = {
+    Environment = ["prod", "dev"]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_AzurermTagValueNoTrailingWhitespaceOrCaseDrift_SuggestedFix(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = { Environment = "PROD" }
+}`,
+		".tflint.hcl": `
+rule "azurerm_tag_value_no_trailing_whitespace_or_case_drift" {
+  enabled = true
+  values  = { Environment = ["prod"] }
+}`,
+	})}
+
+	if err := NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule(),
+			Message: "The value \"PROD\" of the \"Environment\" tag of `azurerm_resource_group.main` differs only by case from the canonical value \"prod\". Suggested fix: replace 3:26-3:32 with \"\\\"prod\\\"\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 26},
+				End:      hcl.Pos{Line: 3, Column: 32},
+			},
+		},
+	}, runner.Issues)
+}
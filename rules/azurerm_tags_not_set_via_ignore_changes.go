@@ -0,0 +1,132 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermTagsNotSetViaIgnoreChangesRule checks that resources do not ignore changes to their tags
+type AzurermTagsNotSetViaIgnoreChangesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermTagsNotSetViaIgnoreChangesRuleConfig struct {
+	// AllowResources are globs of resource addresses whose tags are remediated by Azure Policy
+	AllowResources []string `hclext:"allow_resources,optional"`
+	Enforce        *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermTagsNotSetViaIgnoreChangesRule returns new rule with default attributes
+func NewAzurermTagsNotSetViaIgnoreChangesRule() *AzurermTagsNotSetViaIgnoreChangesRule {
+	return &AzurermTagsNotSetViaIgnoreChangesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermTagsNotSetViaIgnoreChangesRule) Name() string {
+	return "azurerm_tags_not_set_via_ignore_changes"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermTagsNotSetViaIgnoreChangesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermTagsNotSetViaIgnoreChangesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermTagsNotSetViaIgnoreChangesRule) Link() string {
+	return ""
+}
+
+// Check checks that `lifecycle.ignore_changes` of taggable resources includes neither `tags` nor `all`,
+// which would let the tags in the code drift from the deployed ones unnoticed
+func (r *AzurermTagsNotSetViaIgnoreChangesRule) Check(runner tflint.Runner) error {
+	config := azurermTagsNotSetViaIgnoreChangesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	for _, pattern := range config.AllowResources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("allow_resources: invalid glob %q: %s", pattern, err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	taggable := map[string]bool{}
+	for _, resourceType := range taggableResources() {
+		taggable[resourceType] = true
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{
+							Type: "lifecycle",
+							Body: &hclext.BodySchema{
+								Attributes: []hclext.AttributeSchema{{Name: "ignore_changes"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range content.Blocks {
+		if !taggable[resource.Labels[0]] || excludedResource(resource, config.AllowResources) {
+			continue
+		}
+		for _, lifecycle := range resource.Body.Blocks {
+			attribute, exists := lifecycle.Body.Attributes["ignore_changes"]
+			if !exists {
+				continue
+			}
+			if message, location, ignored := ignoredTags(attribute.Expr); ignored {
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` %s, so tag drift goes unnoticed by the tagging policy; remove it, or allow the resource in `allow_resources` if Azure Policy remediates its tags", resource.Labels[0], resource.Labels[1], message),
+					location,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ignoredTags returns how an `ignore_changes` expression ignores the whole tags attribute and where, if it does.
+// Ignoring individual keys such as `tags["CreatedBy"]` is allowed.
+func ignoredTags(expr hcl.Expression) (string, hcl.Range, bool) {
+	if hcl.ExprAsKeyword(expr) == "all" {
+		return "ignores all changes including `tags`", expr.Range(), true
+	}
+	elements, diags := hcl.ExprList(expr)
+	if diags.HasErrors() {
+		return "", hcl.Range{}, false
+	}
+	for _, element := range elements {
+		name := hcl.ExprAsKeyword(element)
+		if name == "" {
+			// Terraform 0.11 style quoted attribute names
+			name, _ = literalString(element)
+		}
+		if name == tagsAttributeName {
+			return "ignores changes to `tags`", element.Range(), true
+		}
+	}
+	return "", hcl.Range{}, false
+}
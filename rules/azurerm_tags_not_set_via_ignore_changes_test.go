@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermTagsNotSetViaIgnoreChanges(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Ignored tags",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  lifecycle {
+    ignore_changes = [location, tags]
+  }
+}`,
+			Config: `
+rule "azurerm_tags_not_set_via_ignore_changes" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagsNotSetViaIgnoreChangesRule(),
+					Message: "`azurerm_resource_group.main` ignores changes to `tags`, so tag drift goes unnoticed by the tagging policy; remove it, or allow the resource in `allow_resources` if Azure Policy remediates its tags",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 33},
+						End:      hcl.Pos{Line: 4, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "Ignored all changes",
+			Content: `
+resource "azurerm_key_vault" "main" {
+  lifecycle {
+    ignore_changes = all
+  }
+}`,
+			Config: `
+rule "azurerm_tags_not_set_via_ignore_changes" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagsNotSetViaIgnoreChangesRule(),
+					Message: "`azurerm_key_vault.main` ignores all changes including `tags`, so tag drift goes unnoticed by the tagging policy; remove it, or allow the resource in `allow_resources` if Azure Policy remediates its tags",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 22},
+						End:      hcl.Pos{Line: 4, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "Individual keys and allowed resources",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  lifecycle {
+    ignore_changes = [tags["CreatedOn"]]
+  }
+}
+
+resource "azurerm_key_vault" "policy_managed" {
+  lifecycle {
+    ignore_changes = [tags]
+  }
+}`,
+			Config: `
+rule "azurerm_tags_not_set_via_ignore_changes" {
+  enabled         = true
+  allow_resources = ["azurerm_key_vault.policy_*"]
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermTagsNotSetViaIgnoreChangesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
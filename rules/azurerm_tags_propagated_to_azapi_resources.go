@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// AzurermTagsPropagatedToAzapiResourcesRule checks whether azapi resources have the required tags
+type AzurermTagsPropagatedToAzapiResourcesRule struct {
+	tflint.DefaultRule
+}
+
+type azurermTagsPropagatedToAzapiResourcesRuleConfig struct {
+	// Tags are the required tags, the `tags` of the `azurerm_resource_missing_tags` rule by default
+	Tags []string `hclext:"tags,optional"`
+	// ExcludeTypes lists resource types or type globs without API versions, e.g. "Microsoft.Network/virtualNetworks/*",
+	// for types that do not support tags
+	ExcludeTypes []string `hclext:"exclude_types,optional"`
+	Enforce      *bool    `hclext:"enforce,optional"`
+}
+
+// NewAzurermTagsPropagatedToAzapiResourcesRule returns new rule with default attributes
+func NewAzurermTagsPropagatedToAzapiResourcesRule() *AzurermTagsPropagatedToAzapiResourcesRule {
+	return &AzurermTagsPropagatedToAzapiResourcesRule{}
+}
+
+// Name returns the rule name
+func (r *AzurermTagsPropagatedToAzapiResourcesRule) Name() string {
+	return "azurerm_tags_propagated_to_azapi_resources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermTagsPropagatedToAzapiResourcesRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermTagsPropagatedToAzapiResourcesRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *AzurermTagsPropagatedToAzapiResourcesRule) Link() string {
+	return ""
+}
+
+// Check checks the tags of `azapi_resource` resources, set in their `tags` attribute or in the `tags` of their `body`,
+// for the required tags, so that resources the azurerm provider does not support yet are tagged like the others
+func (r *AzurermTagsPropagatedToAzapiResourcesRule) Check(runner tflint.Runner) error {
+	config := azurermTagsPropagatedToAzapiResourcesRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.Tags == nil {
+		tagsConfig := azurermResourceTagsRuleConfig{}
+		if err := decodeRuleConfig(runner, NewAzurermResourceMissingTagsRule().Name(), &tagsConfig); err != nil {
+			return err
+		}
+		config.Tags = tagsConfig.Tags
+	}
+	if config.Tags == nil {
+		return fmt.Errorf("`tags` is not set for the `%s` or `%s` rule in .tflint.hcl or the base config", r.Name(), NewAzurermResourceMissingTagsRule().Name())
+	}
+	for _, pattern := range config.ExcludeTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("exclude_types: invalid glob %q: %s", pattern, err)
+		}
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return err
+	}
+
+	resources, err := runner.GetResourceContent("azapi_resource", &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "type"}, {Name: tagsAttributeName}, {Name: "body"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resources.Blocks {
+		if attribute, exists := resource.Body.Attributes["type"]; exists {
+			if resourceType, ok := literalString(attribute.Expr); ok && matchesGlob(strings.SplitN(resourceType, "@", 2)[0], config.ExcludeTypes) {
+				continue
+			}
+		}
+
+		tags, location, found, err := azapiTags(runner, resource, locals)
+		if errors.Is(err, tflint.ErrUnknownValue) {
+			continue
+		}
+		err = runner.EnsureNoError(err, func() error {
+			if !found {
+				required := append([]string{}, config.Tags...)
+				sort.Strings(required)
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("`%s.%s` has no tags in `tags` or `body`, so it is missing the following tags: %s.", resource.Labels[0], resource.Labels[1], quoteAll(required)),
+					resource.DefRange,
+				)
+			}
+
+			var missing []string
+			for _, tag := range config.Tags {
+				if _, ok := tags[tag]; !ok && !stringInSlice(tag, missing) {
+					missing = append(missing, tag)
+				}
+			}
+			if len(missing) == 0 {
+				return nil
+			}
+			sort.Strings(missing)
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` is missing the following tags: %s.", resource.Labels[0], resource.Labels[1], quoteAll(missing)),
+				location,
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// azapiTags returns the tags of an azapi resource and the range of the expression setting them, from the `tags`
+// attribute or the `tags` of the body, which is an object, a `jsonencode()` call or a JSON string.
+// It returns false if the resource sets no tags, and an unknown value error if they cannot be determined.
+func azapiTags(runner tflint.Runner, resource *hclext.Block, locals map[string]*hcl.Attribute) (map[string]string, hcl.Range, bool, error) {
+	if attribute, exists := resource.Body.Attributes[tagsAttributeName]; exists {
+		tags, _, err := evaluateTags(runner, attribute.Expr, locals)
+		return tags, attribute.Expr.Range(), true, err
+	}
+
+	attribute, exists := resource.Body.Attributes["body"]
+	if !exists {
+		return nil, hcl.Range{}, false, nil
+	}
+	expr := attribute.Expr
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "jsonencode" && len(call.Args) == 1 {
+		expr = call.Args[0]
+	}
+	if items, resolved := resolveMapItems(runner, expr, locals); resolved {
+		tags, exists := items[tagsAttributeName]
+		if !exists {
+			return nil, hcl.Range{}, false, nil
+		}
+		resolvedTags, _, err := evaluateTags(runner, tags, locals)
+		return resolvedTags, tags.Range(), true, err
+	}
+
+	// Bodies that are not object literals, such as JSON heredocs, are evaluated
+	var body cty.Value
+	if err := runner.EvaluateExpr(expr, &body, &tflint.EvaluateExprOption{WantType: &cty.DynamicPseudoType}); err != nil {
+		return nil, hcl.Range{}, false, err
+	}
+	if !body.IsWhollyKnown() || body.IsNull() {
+		return nil, hcl.Range{}, false, tflint.ErrUnknownValue
+	}
+
+	var document map[string]interface{}
+	switch {
+	case body.Type().Equals(cty.String):
+		if err := json.Unmarshal([]byte(body.AsString()), &document); err != nil {
+			// Invalid bodies are reported by the azurerm_api_version_pins_in_azapi_resources rule
+			return nil, hcl.Range{}, false, tflint.ErrUnknownValue
+		}
+	case body.Type().IsObjectType() && body.Type().HasAttribute(tagsAttributeName):
+		value := body.GetAttr(tagsAttributeName)
+		if !value.CanIterateElements() {
+			return nil, hcl.Range{}, false, tflint.ErrUnknownValue
+		}
+		tags := map[string]string{}
+		for key, value := range value.AsValueMap() {
+			tags[key] = ""
+			if value.Type().Equals(cty.String) && !value.IsNull() {
+				tags[key] = value.AsString()
+			}
+		}
+		return tags, expr.Range(), true, nil
+	default:
+		return nil, hcl.Range{}, false, nil
+	}
+
+	values, exists := document[tagsAttributeName].(map[string]interface{})
+	if !exists {
+		return nil, hcl.Range{}, false, nil
+	}
+	tags := map[string]string{}
+	for key, value := range values {
+		tags[key], _ = value.(string)
+	}
+	return tags, expr.Range(), true, nil
+}
@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermTagsPropagatedToAzapiResources(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Tags in the attribute and the body",
+			Content: `
+locals {
+  common_tags = {
+    Owner = "platform"
+  }
+}
+
+resource "azapi_resource" "attribute" {
+  type = "Microsoft.App/managedEnvironments@2023-05-01"
+  tags = local.common_tags
+}
+
+resource "azapi_resource" "body" {
+  type = "Microsoft.App/containerApps@2023-05-01"
+  body = {
+    properties = {}
+    tags       = merge(local.common_tags, { Environment = "production" })
+  }
+}
+
+resource "azapi_resource" "json" {
+  type = "Microsoft.Web/sites@2023-01-01"
+  body = <<-EOT
+    {"properties": {}, "tags": {"Environment": "production"}}
+  EOT
+}
+
+resource "azapi_resource" "untagged" {
+  type = "Microsoft.Web/sites@2023-01-01"
+  body = {
+    properties = {}
+  }
+}
+
+resource "azapi_resource" "subnet" {
+  type = "Microsoft.Network/virtualNetworks/subnets@2023-04-01"
+  body = {
+    properties = {}
+  }
+}`,
+			Config: `
+rule "azurerm_tags_propagated_to_azapi_resources" {
+  enabled       = true
+  tags          = ["Owner", "Environment"]
+  exclude_types = ["Microsoft.Network/virtualNetworks/*"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagsPropagatedToAzapiResourcesRule(),
+					Message: "`azapi_resource.attribute` is missing the following tags: \"Environment\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 10, Column: 10},
+						End:      hcl.Pos{Line: 10, Column: 27},
+					},
+				},
+				{
+					Rule:    NewAzurermTagsPropagatedToAzapiResourcesRule(),
+					Message: "`azapi_resource.json` is missing the following tags: \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 23, Column: 10},
+						End:      hcl.Pos{Line: 25, Column: 6},
+					},
+				},
+				{
+					Rule:    NewAzurermTagsPropagatedToAzapiResourcesRule(),
+					Message: "`azapi_resource.untagged` has no tags in `tags` or `body`, so it is missing the following tags: \"Environment\", \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 28, Column: 1},
+						End:      hcl.Pos{Line: 28, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "Required tags of the azurerm_resource_missing_tags rule",
+			Content: `
+resource "azapi_resource" "app" {
+  type = "Microsoft.App/containerApps@2023-05-01"
+  body = {
+    tags = { Environment = "production" }
+  }
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}
+
+rule "azurerm_tags_propagated_to_azapi_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTagsPropagatedToAzapiResourcesRule(),
+					Message: "`azapi_resource.app` is missing the following tags: \"Owner\".",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 12},
+						End:      hcl.Pos{Line: 5, Column: 42},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewAzurermTagsPropagatedToAzapiResourcesRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
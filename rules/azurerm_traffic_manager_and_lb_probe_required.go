@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermTrafficManagerAndLbProbeRequiredRule checks that load balancer rules and traffic manager endpoints are health probed
+type AzurermTrafficManagerAndLbProbeRequiredRule struct {
+	tflint.DefaultRule
+
+	lbRuleType  string
+	profileType string
+}
+
+type azurermTrafficManagerAndLbProbeRequiredRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// NewAzurermTrafficManagerAndLbProbeRequiredRule returns new rule with default attributes
+func NewAzurermTrafficManagerAndLbProbeRequiredRule() *AzurermTrafficManagerAndLbProbeRequiredRule {
+	return &AzurermTrafficManagerAndLbProbeRequiredRule{
+		lbRuleType:  "azurerm_lb_rule",
+		profileType: "azurerm_traffic_manager_profile",
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) Name() string {
+	return "azurerm_traffic_manager_and_lb_probe_required"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) Link() string {
+	return ""
+}
+
+// Check checks load balancer rules reference a probe and traffic manager profiles probe their endpoints meaningfully
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) Check(runner tflint.Runner) error {
+	config := azurermTrafficManagerAndLbProbeRequiredRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	rules, err := runner.GetResourceContent(r.lbRuleType, &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "probe_id"}},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules.Blocks {
+		if _, exists := rule.Body.Attributes["probe_id"]; !exists {
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s.%s` has no `probe_id`, so traffic is sent to backends regardless of their health", rule.Labels[0], rule.Labels[1]),
+				rule.DefRange,
+			)
+		}
+	}
+
+	// The provider requires `monitor_config`, so check what it probes rather than whether it exists
+	profiles, err := runner.GetResourceContent(r.profileType, &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "monitor_config",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "protocol"},
+						{Name: "path"},
+						{Name: "interval_in_seconds"},
+						{Name: "tolerated_number_of_failures"},
+					},
+				},
+			},
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	for _, profile := range profiles.Blocks {
+		address := profile.Labels[0] + "." + profile.Labels[1]
+		for _, monitor := range profile.Body.Blocks {
+			if err := r.checkMonitorConfig(runner, address, monitor); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkMonitorConfig checks HTTP probes request a path and probes run and tolerate at least one failure
+func (r *AzurermTrafficManagerAndLbProbeRequiredRule) checkMonitorConfig(runner tflint.Runner, address string, monitor *hclext.Block) error {
+	if attribute, exists := monitor.Body.Attributes["protocol"]; exists {
+		var protocol string
+		err := runner.EvaluateExpr(attribute.Expr, &protocol, nil)
+		err = runner.EnsureNoError(err, func() error {
+			if !strings.EqualFold(protocol, "HTTP") && !strings.EqualFold(protocol, "HTTPS") {
+				return nil
+			}
+			if _, exists := monitor.Body.Attributes["path"]; exists {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` probes endpoints over %s without a `monitor_config.path`, so endpoint health cannot be checked", address, protocol),
+				attribute.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	settings := []struct {
+		attribute   string
+		consequence string
+	}{
+		{"interval_in_seconds", "endpoints are never probed"},
+		{"tolerated_number_of_failures", "a single failed probe takes an endpoint out of rotation"},
+	}
+	for _, setting := range settings {
+		attribute, exists := monitor.Body.Attributes[setting.attribute]
+		if !exists {
+			continue
+		}
+
+		var value int
+		err := runner.EvaluateExpr(attribute.Expr, &value, nil)
+		err = runner.EnsureNoError(err, func() error {
+			if value > 0 {
+				return nil
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("`%s` sets `monitor_config.%s = %d`, so %s", address, setting.attribute, value, setting.consequence),
+				attribute.Expr.Range(),
+			)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermTrafficManagerAndLbProbeRequired(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Load balancer rule without probe",
+			Content: `
+resource "azurerm_lb_rule" "http" {
+  protocol = "Tcp"
+}
+
+resource "azurerm_lb_rule" "https" {
+  probe_id = azurerm_lb_probe.https.id
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTrafficManagerAndLbProbeRequiredRule(),
+					Message: "`azurerm_lb_rule.http` has no `probe_id`, so traffic is sent to backends regardless of their health",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 34},
+					},
+				},
+			},
+		},
+		{
+			Name: "HTTPS probe without a path",
+			Content: `
+resource "azurerm_traffic_manager_profile" "main" {
+  traffic_routing_method = "Priority"
+
+  monitor_config {
+    protocol = "HTTPS"
+    port     = 443
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTrafficManagerAndLbProbeRequiredRule(),
+					Message: "`azurerm_traffic_manager_profile.main` probes endpoints over HTTPS without a `monitor_config.path`, so endpoint health cannot be checked",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 16},
+						End:      hcl.Pos{Line: 6, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "Probe with zero interval and tolerated failures",
+			Content: `
+resource "azurerm_traffic_manager_profile" "main" {
+  traffic_routing_method = "Priority"
+
+  monitor_config {
+    protocol                     = "TCP"
+    port                         = 443
+    interval_in_seconds          = 0
+    tolerated_number_of_failures = 0
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermTrafficManagerAndLbProbeRequiredRule(),
+					Message: "`azurerm_traffic_manager_profile.main` sets `monitor_config.interval_in_seconds = 0`, so endpoints are never probed",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 36},
+						End:      hcl.Pos{Line: 8, Column: 37},
+					},
+				},
+				{
+					Rule:    NewAzurermTrafficManagerAndLbProbeRequiredRule(),
+					Message: "`azurerm_traffic_manager_profile.main` sets `monitor_config.tolerated_number_of_failures = 0`, so a single failed probe takes an endpoint out of rotation",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 36},
+						End:      hcl.Pos{Line: 9, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "HTTPS probe with a path",
+			Content: `
+resource "azurerm_traffic_manager_profile" "main" {
+  traffic_routing_method = "Priority"
+
+  monitor_config {
+    protocol                     = "HTTPS"
+    port                         = 443
+    path                         = "/healthz"
+    interval_in_seconds          = 30
+    tolerated_number_of_failures = 3
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	config := `
+rule "azurerm_traffic_manager_and_lb_probe_required" {
+  enabled = true
+}`
+	rule := NewAzurermTrafficManagerAndLbProbeRequiredRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,149 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermVMExtensionAllowlistRule checks that VM extensions come from an approved publisher and type
+type AzurermVMExtensionAllowlistRule struct {
+	tflint.DefaultRule
+
+	extensionResourceTypes []string
+	scaleSetResourceTypes  []string
+}
+
+type azurermVMExtensionAllowlistRuleConfig struct {
+	Allow   []allowedVMExtension `hclext:"allow,block"`
+	Enforce *bool                `hclext:"enforce,optional"`
+}
+
+// allowedVMExtension approves a publisher, optionally limited to a single extension type
+type allowedVMExtension struct {
+	Publisher string `hclext:"publisher"`
+	Type      string `hclext:"type,optional"`
+}
+
+// NewAzurermVMExtensionAllowlistRule returns new rule with default attributes
+func NewAzurermVMExtensionAllowlistRule() *AzurermVMExtensionAllowlistRule {
+	return &AzurermVMExtensionAllowlistRule{
+		extensionResourceTypes: []string{
+			"azurerm_virtual_machine_extension",
+			"azurerm_virtual_machine_scale_set_extension",
+		},
+		// Scale sets that declare extensions inline with `extension` blocks
+		scaleSetResourceTypes: []string{
+			"azurerm_linux_virtual_machine_scale_set",
+			"azurerm_windows_virtual_machine_scale_set",
+			"azurerm_orchestrated_virtual_machine_scale_set",
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermVMExtensionAllowlistRule) Name() string {
+	return "azurerm_vm_extension_allowlist"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermVMExtensionAllowlistRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermVMExtensionAllowlistRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermVMExtensionAllowlistRule) Link() string {
+	return ""
+}
+
+// Check checks VM extensions against the configured allowlist
+func (r *AzurermVMExtensionAllowlistRule) Check(runner tflint.Runner) error {
+	config := azurermVMExtensionAllowlistRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if len(config.Allow) == 0 {
+		return fmt.Errorf("`allow` is not set for the `%s` rule in .tflint.hcl or the base config", r.Name())
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	extensionSchema := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "publisher"}, {Name: "type"}},
+	}
+
+	for _, resourceType := range r.extensionResourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, extensionSchema, nil)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources.Blocks {
+			if err := r.checkExtension(runner, config, resource); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, resourceType := range r.scaleSetResourceTypes {
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Blocks: []hclext.BlockSchema{{Type: "extension", Body: extensionSchema}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+		for _, resource := range resources.Blocks {
+			for _, extension := range resource.Body.Blocks {
+				if err := r.checkExtension(runner, config, extension); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *AzurermVMExtensionAllowlistRule) checkExtension(runner tflint.Runner, config azurermVMExtensionAllowlistRuleConfig, extension *hclext.Block) error {
+	publisherAttribute, publisherExists := extension.Body.Attributes["publisher"]
+	typeAttribute, typeExists := extension.Body.Attributes["type"]
+	if !publisherExists || !typeExists {
+		return nil
+	}
+
+	var publisher, extensionType string
+	err := runner.EvaluateExpr(publisherAttribute.Expr, &publisher, nil)
+	return runner.EnsureNoError(err, func() error {
+		err := runner.EvaluateExpr(typeAttribute.Expr, &extensionType, nil)
+		return runner.EnsureNoError(err, func() error {
+			// Entries without a type approve every extension of the publisher, so they are suggested by publisher alone
+			approvedExtensions, approvedPublishers := []string{}, []string{}
+			for _, allowed := range config.Allow {
+				if allowed.Publisher == publisher && (allowed.Type == "" || allowed.Type == extensionType) {
+					return nil
+				}
+				if allowed.Type == "" {
+					approvedPublishers = append(approvedPublishers, allowed.Publisher)
+				} else {
+					approvedExtensions = append(approvedExtensions, allowed.Publisher+"/"+allowed.Type)
+				}
+			}
+
+			extension := publisher + "/" + extensionType
+			suggestion := didYouMean(extension, approvedExtensions)
+			if suggestion == "" {
+				suggestion = didYouMean(publisher, approvedPublishers)
+			}
+			runner.EmitIssue(
+				r,
+				fmt.Sprintf("\"%s\" is not an approved VM extension.%s", extension, suggestion),
+				typeAttribute.Expr.Range(),
+			)
+			return nil
+		})
+	})
+}
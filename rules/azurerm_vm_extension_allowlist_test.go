@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermVMExtensionAllowlist(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Unapproved extension resource",
+			Content: `
+resource "azurerm_virtual_machine_extension" "agent" {
+  publisher = "Contoso.Agents"
+  type      = "Collector"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermVMExtensionAllowlistRule(),
+					Message: "\"Contoso.Agents/Collector\" is not an approved VM extension.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15},
+						End:      hcl.Pos{Line: 4, Column: 26},
+					},
+				},
+			},
+		},
+		{
+			Name: "Typo in an inline scale set extension",
+			Content: `
+resource "azurerm_linux_virtual_machine_scale_set" "vmss" {
+  extension {
+    publisher = "Microsoft.Azure.Monitor"
+    type      = "AzureMonitorLinuxAgnet"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermVMExtensionAllowlistRule(),
+					Message: "\"Microsoft.Azure.Monitor/AzureMonitorLinuxAgnet\" is not an approved VM extension. Did you mean \"Microsoft.Azure.Monitor/AzureMonitorLinuxAgent\"?",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 5, Column: 17},
+						End:      hcl.Pos{Line: 5, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "Approved publisher with any type",
+			Content: `
+resource "azurerm_virtual_machine_scale_set_extension" "script" {
+  publisher = "Microsoft.Azure.Extensions"
+  type      = "CustomScript"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Misspelled approved publisher",
+			Content: `
+resource "azurerm_virtual_machine_extension" "script" {
+  publisher = "Microsoft.Azure.Extensons"
+  type      = "CustomScript"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermVMExtensionAllowlistRule(),
+					Message: "\"Microsoft.Azure.Extensons/CustomScript\" is not an approved VM extension. Did you mean \"Microsoft.Azure.Extensions\"?",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15},
+						End:      hcl.Pos{Line: 4, Column: 29},
+					},
+				},
+			},
+		},
+	}
+
+	config := `
+rule "azurerm_vm_extension_allowlist" {
+  enabled = true
+
+  allow {
+    publisher = "Microsoft.Azure.Monitor"
+    type      = "AzureMonitorLinuxAgent"
+  }
+
+  allow {
+    publisher = "Microsoft.Azure.Extensions"
+  }
+}`
+	rule := NewAzurermVMExtensionAllowlistRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
@@ -0,0 +1,174 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/region"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// AzurermZoneRedundantSkuInSupportedRegionsRule checks that zone-redundant configurations are deployed
+// to regions with availability zones
+type AzurermZoneRedundantSkuInSupportedRegionsRule struct {
+	tflint.DefaultRule
+
+	settings map[string]zoneRedundantSetting
+}
+
+type azurermZoneRedundantSkuInSupportedRegionsRuleConfig struct {
+	Enforce *bool `hclext:"enforce,optional"`
+}
+
+// zoneRedundantSetting is the attribute of a resource type that makes it zone-redundant
+type zoneRedundantSetting struct {
+	attribute string
+	// values are the values of a string attribute that are zone-redundant, such as ZRS replication
+	values []string
+	// suffix is the suffix of zone-redundant SKUs, such as "AZ" in "VpnGw1AZ"
+	suffix string
+	// zones is set for list attributes that pin the resource to availability zones when not empty
+	zones bool
+	// flag is set for bool attributes that enable zone redundancy
+	flag bool
+}
+
+// NewAzurermZoneRedundantSkuInSupportedRegionsRule returns new rule with default attributes
+func NewAzurermZoneRedundantSkuInSupportedRegionsRule() *AzurermZoneRedundantSkuInSupportedRegionsRule {
+	return &AzurermZoneRedundantSkuInSupportedRegionsRule{
+		settings: map[string]zoneRedundantSetting{
+			"azurerm_storage_account":         {attribute: "account_replication_type", values: []string{"ZRS", "GZRS", "RAGZRS"}},
+			"azurerm_virtual_network_gateway": {attribute: "sku", suffix: "AZ"},
+			"azurerm_public_ip":               {attribute: "zones", zones: true},
+			"azurerm_application_gateway":     {attribute: "zones", zones: true},
+			"azurerm_firewall":                {attribute: "zones", zones: true},
+			"azurerm_nat_gateway":             {attribute: "zones", zones: true},
+			"azurerm_redis_cache":             {attribute: "zones", zones: true},
+			"azurerm_mssql_database":          {attribute: "zone_redundant", flag: true},
+			"azurerm_mssql_elasticpool":       {attribute: "zone_redundant", flag: true},
+			"azurerm_eventhub_namespace":      {attribute: "zone_redundant", flag: true},
+			"azurerm_service_plan":            {attribute: "zone_balancing_enabled", flag: true},
+		},
+	}
+}
+
+// Name returns the rule name
+func (r *AzurermZoneRedundantSkuInSupportedRegionsRule) Name() string {
+	return "azurerm_zone_redundant_sku_in_supported_regions"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *AzurermZoneRedundantSkuInSupportedRegionsRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *AzurermZoneRedundantSkuInSupportedRegionsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *AzurermZoneRedundantSkuInSupportedRegionsRule) Link() string {
+	return ""
+}
+
+// Check checks resources configured to be zone-redundant, such as ZRS storage accounts and AZ gateway SKUs,
+// against the availability zones of their location in the region catalog, since deployments fail in regions without zones
+func (r *AzurermZoneRedundantSkuInSupportedRegionsRule) Check(runner tflint.Runner) error {
+	config := azurermZoneRedundantSkuInSupportedRegionsRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	declared, err := declaredResourceTypes(runner)
+	if err != nil {
+		return err
+	}
+
+	resourceTypes := make([]string, 0, len(r.settings))
+	for resourceType := range r.settings {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		if !declared[resourceType] {
+			continue
+		}
+		setting := r.settings[resourceType]
+		resources, err := runner.GetResourceContent(resourceType, &hclext.BodySchema{
+			Attributes: []hclext.AttributeSchema{{Name: "location"}, {Name: setting.attribute}},
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, resource := range resources.Blocks {
+			attribute, exists := resource.Body.Attributes[setting.attribute]
+			if !exists {
+				continue
+			}
+			location, exists := resource.Body.Attributes["location"]
+			if !exists {
+				continue
+			}
+
+			var name string
+			err := runner.EvaluateExpr(location.Expr, &name, nil)
+			err = runner.EnsureNoError(err, func() error {
+				regionName, properties, known := region.Lookup(name)
+				if !known || properties.AvailabilityZones {
+					return nil
+				}
+				return setting.evaluate(runner, attribute.Expr, func(description string) error {
+					return runner.EmitIssue(
+						r,
+						fmt.Sprintf("`%s.%s` %s, which needs availability zones, but \"%s\" has none", resource.Labels[0], resource.Labels[1], description, regionName),
+						attribute.Expr.Range(),
+					)
+				})
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluate calls proc with a description of the setting if the expression makes the resource zone-redundant
+func (s zoneRedundantSetting) evaluate(runner tflint.Runner, expr hcl.Expression, proc func(description string) error) error {
+	switch {
+	case s.zones:
+		var zones []string
+		err := runner.EvaluateExpr(expr, &zones, nil)
+		return runner.EnsureNoError(err, func() error {
+			if len(zones) == 0 {
+				return nil
+			}
+			return proc(fmt.Sprintf("is pinned to the availability zones %s", quoteAll(zones)))
+		})
+	case s.flag:
+		var enabled bool
+		err := evaluateBool(runner, expr, &enabled)
+		return runner.EnsureNoError(err, func() error {
+			if !enabled {
+				return nil
+			}
+			return proc(fmt.Sprintf("enables `%s`", s.attribute))
+		})
+	default:
+		var value string
+		err := runner.EvaluateExpr(expr, &value, nil)
+		return runner.EnsureNoError(err, func() error {
+			if !stringInSlice(value, s.values) && (s.suffix == "" || !strings.HasSuffix(value, s.suffix)) {
+				return nil
+			}
+			return proc(fmt.Sprintf("sets `%s` to \"%s\"", s.attribute, value))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_AzurermZoneRedundantSkuInSupportedRegions(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Zone-redundant resources in regions without availability zones",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  location                 = "westcentralus"
+  account_replication_type = "ZRS"
+}
+
+resource "azurerm_virtual_network_gateway" "vpn" {
+  location = "UK West"
+  sku      = "VpnGw1AZ"
+}
+
+resource "azurerm_public_ip" "ingress" {
+  location = "westus"
+  zones    = ["1", "2", "3"]
+}
+
+resource "azurerm_mssql_database" "orders" {
+  location       = "northcentralus"
+  zone_redundant = true
+}`,
+			Config: `
+rule "azurerm_zone_redundant_sku_in_supported_regions" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermZoneRedundantSkuInSupportedRegionsRule(),
+					Message: "`azurerm_mssql_database.orders` enables `zone_redundant`, which needs availability zones, but \"northcentralus\" has none",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 19, Column: 20},
+						End:      hcl.Pos{Line: 19, Column: 24},
+					},
+				},
+				{
+					Rule:    NewAzurermZoneRedundantSkuInSupportedRegionsRule(),
+					Message: "`azurerm_public_ip.ingress` is pinned to the availability zones \"1\", \"2\", \"3\", which needs availability zones, but \"westus\" has none",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 14, Column: 14},
+						End:      hcl.Pos{Line: 14, Column: 29},
+					},
+				},
+				{
+					Rule:    NewAzurermZoneRedundantSkuInSupportedRegionsRule(),
+					Message: "`azurerm_storage_account.logs` sets `account_replication_type` to \"ZRS\", which needs availability zones, but \"westcentralus\" has none",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 30},
+						End:      hcl.Pos{Line: 4, Column: 35},
+					},
+				},
+				{
+					Rule:    NewAzurermZoneRedundantSkuInSupportedRegionsRule(),
+					Message: "`azurerm_virtual_network_gateway.vpn` sets `sku` to \"VpnGw1AZ\", which needs availability zones, but \"ukwest\" has none",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 14},
+						End:      hcl.Pos{Line: 9, Column: 24},
+					},
+				},
+			},
+		},
+		{
+			Name: "Regions with availability zones",
+			Content: `
+resource "azurerm_storage_account" "logs" {
+  location                 = "westeurope"
+  account_replication_type = "GZRS"
+}
+
+resource "azurerm_public_ip" "ingress" {
+  location = "ukwest"
+  zones    = []
+}
+
+resource "azurerm_storage_account" "archive" {
+  location                 = "westus"
+  account_replication_type = "LRS"
+}`,
+			Config: `
+rule "azurerm_zone_redundant_sku_in_supported_regions" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewAzurermZoneRedundantSkuInSupportedRegionsRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
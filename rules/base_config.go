@@ -0,0 +1,216 @@
+package rules
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// embeddedBaseConfig holds the organization defaults shipped in the plugin binary
+//
+//go:embed base_config.hcl
+var embeddedBaseConfig []byte
+
+const (
+	// baseConfigEnv names a base config file to load instead of the well-known path
+	baseConfigEnv = "TFLINT_MATT_CUSTOM_BASE_CONFIG"
+	// baseConfigPath is the well-known location of the base config, relative to the home directory
+	baseConfigPath = ".tflint.d/matt-custom.hcl"
+)
+
+// baseConfigSource is a base config file, in order of increasing precedence
+type baseConfigSource struct {
+	filename string
+	src      []byte
+}
+
+// baseConfigSources returns the embedded base config followed by the one found on disk, if any.
+// A file named by the environment variable must exist, the well-known path is optional.
+func baseConfigSources() ([]baseConfigSource, error) {
+	sources := []baseConfigSource{{filename: "base_config.hcl", src: embeddedBaseConfig}}
+
+	path, required := os.Getenv(baseConfigEnv), true
+	if path == "" {
+		required = false
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return sources, nil
+		}
+		path = filepath.Join(home, baseConfigPath)
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		if !required && errors.Is(err, fs.ErrNotExist) {
+			return sources, nil
+		}
+		return nil, fmt.Errorf("failed to read base config: %s", err)
+	}
+	return append(sources, baseConfigSource{filename: path, src: src}), nil
+}
+
+// decodeRuleConfig decodes the rule config into ret, layering the rule block of the repository's
+// .tflint.hcl over the base configs. Attributes set in a later layer replace those of earlier layers,
+// and block types set in a later layer replace all blocks of that type. Since required attributes may
+// come from any layer, rule configs declare them optional and validate them after decoding.
+func decodeRuleConfig(runner tflint.Runner, name string, ret interface{}) error {
+	sources, err := baseConfigSources()
+	if err != nil {
+		return err
+	}
+
+	schema := hclext.ImpliedBodySchema(ret)
+	baseSchema := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "enabled"}},
+		Blocks:     schema.Blocks,
+	}
+	for _, attribute := range schema.Attributes {
+		baseSchema.Attributes = append(baseSchema.Attributes, hclext.AttributeSchema{Name: attribute.Name})
+	}
+
+	for _, source := range sources {
+		file, diags := hclsyntax.ParseConfig(source.src, source.filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return diags
+		}
+		blocks, diags := ruleBlocks(file.Body, name)
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range blocks {
+			if err := decodeConfigLayer(ret, func() error {
+				body, diags := hclext.Content(block.Body, baseSchema)
+				if diags.HasErrors() {
+					return diags
+				}
+				if diags := hclext.DecodeBody(body, nil, ret); diags.HasErrors() {
+					return diags
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return decodeConfigLayer(ret, func() error {
+		return runner.DecodeRuleConfig(name, ret)
+	})
+}
+
+// decodeConfigLayer runs decode over the config decoded so far. Decoding leaves absent attributes
+// untouched but decodes blocks into the existing ones, so block fields are cleared beforehand
+// and restored if the layer does not set them.
+func decodeConfigLayer(ret interface{}, decode func() error) error {
+	val := reflect.ValueOf(ret).Elem()
+
+	saved := map[int]reflect.Value{}
+	for i := 0; i < val.NumField(); i++ {
+		if !strings.HasSuffix(val.Type().Field(i).Tag.Get("hclext"), ",block") {
+			continue
+		}
+		field := val.Field(i)
+		saved[i] = reflect.ValueOf(field.Interface())
+		field.Set(reflect.Zero(field.Type()))
+	}
+
+	if err := decode(); err != nil {
+		return err
+	}
+
+	for i, value := range saved {
+		if val.Field(i).IsZero() {
+			val.Field(i).Set(value)
+		}
+	}
+	return nil
+}
+
+// tflintConfigFile is the name of TFLint's config file in the working directory
+const tflintConfigFile = ".tflint.hcl"
+
+// ruleBlocks returns the rule blocks of the rule in the config body
+func ruleBlocks(body hcl.Body, name string) ([]*hcl.Block, hcl.Diagnostics) {
+	content, _, diags := body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "rule", LabelNames: []string{"name"}}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	blocks := []*hcl.Block{}
+	for _, block := range content.Blocks {
+		if block.Labels[0] == name {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+// ruleConfigBlocks returns the rule blocks of the rule in .tflint.hcl followed by those of the base configs,
+// in order of decreasing precedence. Decoded rule configs carry no ranges, so this is how issues about the
+// config find their location. .tflint.hcl is read with GetFile and is skipped if TFLint does not serve it.
+func ruleConfigBlocks(runner tflint.Runner, name string) ([]*hcl.Block, error) {
+	blocks := []*hcl.Block{}
+	if file, err := runner.GetFile(tflintConfigFile); err == nil && file != nil {
+		found, diags := ruleBlocks(file.Body, name)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		blocks = append(blocks, found...)
+	}
+
+	sources, err := baseConfigSources()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(sources) - 1; i >= 0; i-- {
+		file, diags := hclsyntax.ParseConfig(sources[i].src, sources[i].filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		found, diags := ruleBlocks(file.Body, name)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+		blocks = append(blocks, found...)
+	}
+	return blocks, nil
+}
+
+// ruleConfigBlockRange returns the range of the first block of the given type in the rule config whose attribute
+// is set to the value, or an empty range if it cannot be found
+func ruleConfigBlockRange(runner tflint.Runner, name string, blockType string, attribute string, value string) (hcl.Range, error) {
+	blocks, err := ruleConfigBlocks(runner, name)
+	if err != nil {
+		return hcl.Range{}, err
+	}
+
+	for _, block := range blocks {
+		content, _, diags := block.Body.PartialContent(&hcl.BodySchema{Blocks: []hcl.BlockHeaderSchema{{Type: blockType}}})
+		if diags.HasErrors() {
+			return hcl.Range{}, diags
+		}
+		for _, nested := range content.Blocks {
+			attributes, _ := nested.Body.JustAttributes()
+			if attr, exists := attributes[attribute]; exists {
+				if val, diags := attr.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String && val.IsWhollyKnown() && !val.IsNull() && val.AsString() == value {
+					return nested.DefRange, nil
+				}
+			}
+		}
+	}
+	return hcl.Range{}, nil
+}
@@ -0,0 +1,14 @@
+# Organization defaults for the rules of this ruleset.
+#
+# Rule blocks in this file are merged with the rule blocks of each repository's
+# .tflint.hcl, which override them attribute by attribute. Enabling rules is still
+# done in .tflint.hcl; `enabled` is ignored here.
+#
+# This file is embedded into the plugin binary, so organizations distributing a
+# custom build can ship their defaults by editing it before building. Defaults can
+# also be loaded at runtime from ~/.tflint.d/matt-custom.hcl, or from the path in
+# the TFLINT_MATT_CUSTOM_BASE_CONFIG environment variable.
+#
+# rule "azurerm_resource_missing_tags" {
+#   tags = ["Owner", "Environment"]
+# }
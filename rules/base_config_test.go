@@ -0,0 +1,195 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_DecodeRuleConfig(t *testing.T) {
+	base := `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+  exclude = ["azurerm_key_vault"]
+
+  exemption {
+    resource = "azurerm_resource_group"
+    expires  = "2022-06-30"
+    owner    = "platform-team"
+  }
+}
+
+rule "azurerm_storage_account_invalid_account_tier" {
+  enforce = false
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected azurermResourceTagsRuleConfig
+	}{
+		{
+			Name: "Base config only",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+}`,
+			Expected: azurermResourceTagsRuleConfig{
+				Tags:       []string{"Owner", "Environment"},
+				Exclude:    []string{"azurerm_key_vault"},
+				Exemptions: []ruleExemption{{Resource: "azurerm_resource_group", Expires: "2022-06-30", Owner: "platform-team"}},
+			},
+		},
+		{
+			Name: "Attributes overridden",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner"]
+}`,
+			Expected: azurermResourceTagsRuleConfig{
+				Tags:       []string{"Owner"},
+				Exclude:    []string{"azurerm_key_vault"},
+				Exemptions: []ruleExemption{{Resource: "azurerm_resource_group", Expires: "2022-06-30", Owner: "platform-team"}},
+			},
+		},
+		{
+			Name: "Blocks replaced",
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+
+  exemption {
+    resource = "azurerm_key_vault"
+    expires  = "2022-12-31"
+    owner    = "security-team"
+  }
+}`,
+			Expected: azurermResourceTagsRuleConfig{
+				Tags:       []string{"Owner", "Environment"},
+				Exclude:    []string{"azurerm_key_vault"},
+				Exemptions: []ruleExemption{{Resource: "azurerm_key_vault", Expires: "2022-12-31", Owner: "security-team"}},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "base.hcl")
+	if err := os.WriteFile(path, []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(baseConfigEnv, path)
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"module.tf": "", ".tflint.hcl": tc.Config})
+
+			config := azurermResourceTagsRuleConfig{}
+			if err := decodeRuleConfig(runner, "azurerm_resource_missing_tags", &config); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if !reflect.DeepEqual(config, tc.Expected) {
+				t.Fatalf("Expected %#v, got %#v", tc.Expected, config)
+			}
+		})
+	}
+}
+
+func Test_DecodeRuleConfig_BaseConfigSources(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	t.Setenv(baseConfigEnv, "")
+	sources, err := baseConfigSources()
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(sources) != 1 {
+		t.Fatalf("Expected only the embedded base config without a file at the well-known path, got %d sources", len(sources))
+	}
+
+	t.Setenv(baseConfigEnv, filepath.Join(t.TempDir(), "missing.hcl"))
+	if _, err := baseConfigSources(); err == nil {
+		t.Fatal("Expected an error for a missing base config named by the environment variable")
+	}
+}
+
+// configFileRunner serves .tflint.hcl with GetFile, which the test runner only decodes as the config
+type configFileRunner struct {
+	*helper.Runner
+
+	config string
+}
+
+func (r *configFileRunner) GetFile(filename string) (*hcl.File, error) {
+	if filename != tflintConfigFile {
+		return r.Runner.GetFile(filename)
+	}
+	file, diags := hclsyntax.ParseConfig([]byte(r.config), filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return file, nil
+}
+
+func Test_RuleConfigBlockRange(t *testing.T) {
+	base := `
+rule "azurerm_resource_missing_tags" {
+  exemption {
+    resource = "azurerm_key_vault"
+  }
+}`
+	config := `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+
+  exemption {
+    resource = "azurerm_resource_group"
+  }
+}`
+	path := filepath.Join(t.TempDir(), "base.hcl")
+	if err := os.WriteFile(path, []byte(base), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(baseConfigEnv, path)
+
+	cases := []struct {
+		Name     string
+		Resource string
+		Expected hcl.Range
+	}{
+		{
+			Name:     "Block in .tflint.hcl",
+			Resource: "azurerm_resource_group",
+			Expected: hcl.Range{Filename: ".tflint.hcl", Start: hcl.Pos{Line: 5, Column: 3, Byte: 60}, End: hcl.Pos{Line: 5, Column: 12, Byte: 69}},
+		},
+		{
+			Name:     "Block in the base config",
+			Resource: "azurerm_key_vault",
+			Expected: hcl.Range{Filename: path, Start: hcl.Pos{Line: 3, Column: 3, Byte: 42}, End: hcl.Pos{Line: 3, Column: 12, Byte: 51}},
+		},
+		{
+			Name:     "No block",
+			Resource: "azurerm_subnet",
+			Expected: hcl.Range{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := &configFileRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": "", ".tflint.hcl": config}), config: config}
+
+			got, err := ruleConfigBlockRange(runner, "azurerm_resource_missing_tags", "exemption", "resource", tc.Resource)
+			if err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if got != tc.Expected {
+				t.Fatalf("Expected %#v, got %#v", tc.Expected, got)
+			}
+		})
+	}
+}
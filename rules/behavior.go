@@ -0,0 +1,95 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Behavior changes that can be held back by pinning `ruleset_version` in the plugin block
+const (
+	// tagKeySuggestions points out present tag keys that nearly match a missing tag
+	tagKeySuggestions = "tag_key_suggestions"
+	// overrideFilesMerged merges override files into resources before checking their tags
+	overrideFilesMerged = "override_files_merged"
+	// nestedBlockTags reads tags set in nested blocks of resources without top-level tags
+	nestedBlockTags = "nested_block_tags"
+)
+
+// behaviorFlags maps each behavior change to the ruleset version that introduced it
+var behaviorFlags = map[string]string{
+	tagKeySuggestions:   "0.2.0",
+	overrideFilesMerged: "0.2.0",
+	nestedBlockTags:     "0.2.0",
+}
+
+// rulesetVersion holds the version whose behavior the rules follow, or empty for the latest.
+// It is pinned when the config is applied and read by rules checking in parallel, so it is only accessed atomically.
+var rulesetVersion atomic.Value
+
+// behaviorEnabled returns whether the behavior change is enabled by the pinned ruleset version
+func behaviorEnabled(flag string) bool {
+	version, _ := rulesetVersion.Load().(string)
+	if version == "" {
+		return true
+	}
+	// Versions are validated when pinned, so comparing cannot fail
+	cmp, _ := compareVersions(behaviorFlags[flag], version)
+	return cmp <= 0
+}
+
+// pinRulesetVersion pins the behavior of the rules to the version, which must not be newer than the plugin
+func pinRulesetVersion(version string, pluginVersion string) error {
+	if version == "" {
+		rulesetVersion.Store("")
+		return nil
+	}
+
+	cmp, err := compareVersions(version, pluginVersion)
+	if err != nil {
+		return fmt.Errorf("ruleset_version: %s", err)
+	}
+	if cmp > 0 {
+		return fmt.Errorf("ruleset_version: %s is newer than the installed plugin version %s", version, pluginVersion)
+	}
+	rulesetVersion.Store(version)
+	return nil
+}
+
+// compareVersions compares two MAJOR.MINOR.PATCH versions, returning -1, 0 or 1
+func compareVersions(a string, b string) (int, error) {
+	parse := func(version string) ([3]int, error) {
+		var parts [3]int
+		segments := strings.Split(strings.TrimPrefix(version, "v"), ".")
+		if len(segments) != 3 {
+			return parts, fmt.Errorf("invalid version %q, want MAJOR.MINOR.PATCH", version)
+		}
+		for i, segment := range segments {
+			n, err := strconv.Atoi(segment)
+			if err != nil || n < 0 {
+				return parts, fmt.Errorf("invalid version %q, want MAJOR.MINOR.PATCH", version)
+			}
+			parts[i] = n
+		}
+		return parts, nil
+	}
+
+	x, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	y, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range x {
+		if x[i] < y[i] {
+			return -1, nil
+		}
+		if x[i] > y[i] {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,79 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_CompareVersions(t *testing.T) {
+	cases := []struct {
+		A        string
+		B        string
+		Expected int
+	}{
+		{A: "0.1.0", B: "0.2.0", Expected: -1},
+		{A: "0.10.0", B: "0.9.1", Expected: 1},
+		{A: "v1.2.3", B: "1.2.3", Expected: 0},
+	}
+
+	for _, tc := range cases {
+		cmp, err := compareVersions(tc.A, tc.B)
+		if err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if cmp != tc.Expected {
+			t.Fatalf("Expected %s compared to %s to be %d, got %d", tc.A, tc.B, tc.Expected, cmp)
+		}
+	}
+
+	if _, err := compareVersions("0.2", "0.2.0"); err == nil {
+		t.Fatal("Expected an error for an incomplete version")
+	}
+}
+
+func Test_RuleSet_ApplyConfig(t *testing.T) {
+	defer pinRulesetVersion("", "0.2.0")
+
+	ruleset := &RuleSet{BuiltinRuleSet: tflint.BuiltinRuleSet{Version: "0.2.0"}}
+	apply := func(version string) error {
+		content := &hclext.BodyContent{Attributes: hclext.Attributes{}}
+		if version != "" {
+			expr, diags := hclsyntax.ParseExpression([]byte(version), "", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			content.Attributes["ruleset_version"] = &hclext.Attribute{Name: "ruleset_version", Expr: expr}
+		}
+		return ruleset.ApplyConfig(content)
+	}
+
+	if err := apply(""); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if !behaviorEnabled(tagKeySuggestions) {
+		t.Fatal("Expected the latest behavior without a pinned version")
+	}
+
+	if err := apply(`"0.1.0"`); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if behaviorEnabled(tagKeySuggestions) || behaviorEnabled(overrideFilesMerged) {
+		t.Fatal("Expected behavior introduced in 0.2.0 to be disabled when pinned to 0.1.0")
+	}
+
+	if err := apply(`"0.2.0"`); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if !behaviorEnabled(tagKeySuggestions) {
+		t.Fatal("Expected behavior introduced in 0.2.0 to be enabled when pinned to 0.2.0")
+	}
+
+	err := apply(`"0.3.0"`)
+	if expected := "ruleset_version: 0.3.0 is newer than the installed plugin version 0.2.0"; err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
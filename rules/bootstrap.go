@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/sku"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+// BootstrapEnv names a module directory to scan for a suggested .tflint.hcl instead of serving the ruleset
+const BootstrapEnv = "TFLINT_MATT_CUSTOM_BOOTSTRAP"
+
+// bootstrapSkus locates the SKUs of the resource types the minimum tier rule supports
+var bootstrapSkus = NewAzurermNotificationHubAndIothubSkuTierRule().messagingSkus
+
+// moduleUsage is what a module uses today, as far as it can be read from literal values
+type moduleUsage struct {
+	taggedResources int
+	tagKeys         map[string]int
+	locations       map[string]bool
+	storageAccounts int
+	// Lowest SKU in use per resource type
+	minimumSkus map[string]string
+}
+
+// Bootstrap scans the Terraform files of the module directory and writes a suggested .tflint.hcl to out.
+// Suggested rules start with `enforce = false` so that they can be previewed before being enforced.
+func Bootstrap(dir string, out io.Writer) error {
+	filenames, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		return err
+	}
+	if len(filenames) == 0 {
+		return fmt.Errorf("no Terraform files found in %s", dir)
+	}
+	sort.Strings(filenames)
+
+	usage := &moduleUsage{tagKeys: map[string]int{}, locations: map[string]bool{}, minimumSkus: map[string]string{}}
+	for _, filename := range filenames {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return err
+		}
+		file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+		if diags.HasErrors() {
+			return diags
+		}
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if block.Type == "resource" && len(block.Labels) == 2 && strings.HasPrefix(block.Labels[0], "azurerm_") {
+				usage.collect(block)
+			}
+		}
+	}
+
+	return usage.write(dir, out)
+}
+
+// collect records the tags, location and SKU of the resource
+func (u *moduleUsage) collect(block *hclsyntax.Block) {
+	resourceType := block.Labels[0]
+	attributes := block.Body.Attributes
+
+	if attribute, exists := attributes[tagsAttributeName]; exists {
+		if pairs, diags := hcl.ExprMap(attribute.Expr); !diags.HasErrors() {
+			u.taggedResources++
+			for _, pair := range pairs {
+				if key := settingKey(pair.Key); key != "" {
+					u.tagKeys[key]++
+				}
+			}
+		}
+	}
+
+	if attribute, exists := attributes["location"]; exists {
+		if location, ok := literalString(attribute.Expr); ok {
+			u.locations[location] = true
+		}
+	}
+
+	if resourceType == "azurerm_storage_account" {
+		u.storageAccounts++
+	}
+
+	target, supported := bootstrapSkus[resourceType]
+	if !supported {
+		return
+	}
+	body := block.Body
+	if target.block != "" {
+		body = nil
+		for _, nested := range block.Body.Blocks {
+			if nested.Type == target.block {
+				body = nested.Body
+			}
+		}
+	}
+	if body == nil {
+		return
+	}
+	if attribute, exists := body.Attributes[target.attribute]; exists {
+		name, ok := literalString(attribute.Expr)
+		if !ok || sku.Rank(target.service, name) < 0 {
+			return
+		}
+		if current, exists := u.minimumSkus[resourceType]; !exists || sku.Rank(target.service, name) < sku.Rank(target.service, current) {
+			u.minimumSkus[resourceType] = name
+		}
+	}
+}
+
+// write renders the suggested configuration
+func (u *moduleUsage) write(dir string, out io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Suggested by %s from %s. Review every rule before setting `enforce = true`.\n", BootstrapEnv, dir)
+	b.WriteString("plugin \"matt-custom\" {\n  enabled = true\n}\n")
+
+	if u.taggedResources > 0 {
+		common, other := []string{}, []string{}
+		for key, count := range u.tagKeys {
+			if count == u.taggedResources {
+				common = append(common, key)
+			} else {
+				other = append(other, key)
+			}
+		}
+		sort.Strings(common)
+		sort.Strings(other)
+
+		b.WriteString("\nrule \"azurerm_resource_missing_tags\" {\n  enabled = true\n  enforce = false\n")
+		fmt.Fprintf(&b, "  # Tag keys used on all %d resources with literal tags\n", u.taggedResources)
+		fmt.Fprintf(&b, "  tags = [%s]\n", quoteAll(common))
+		if len(other) > 0 {
+			fmt.Fprintf(&b, "  # Also used on some resources: %s\n", quoteAll(other))
+		}
+		b.WriteString("}\n")
+	}
+
+	if u.storageAccounts > 0 {
+		b.WriteString("\nrule \"azurerm_storage_account_invalid_account_tier\" {\n  enabled = true\n  enforce = false\n}\n")
+	}
+
+	if len(u.minimumSkus) > 0 {
+		b.WriteString("\nrule \"azurerm_notification_hub_and_iothub_sku_tier\" {\n  enabled = true\n  enforce = false\n")
+		b.WriteString("  # Lowest SKUs in use, raise them to the tiers production requires\n  minimum_skus = {\n")
+		for _, resourceType := range sortedKeys(u.minimumSkus) {
+			fmt.Fprintf(&b, "    %s = \"%s\"\n", resourceType, u.minimumSkus[resourceType])
+		}
+		b.WriteString("  }\n}\n")
+	}
+
+	if len(u.locations) > 0 {
+		locations := make([]string, 0, len(u.locations))
+		for location := range u.locations {
+			locations = append(locations, location)
+		}
+		sort.Strings(locations)
+		fmt.Fprintf(&b, "\n# Locations in use: %s\n", quoteAll(locations))
+	}
+
+	_, err := io.WriteString(out, b.String())
+	return err
+}
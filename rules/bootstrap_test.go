@@ -0,0 +1,84 @@
+package rules
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func Test_Bootstrap(t *testing.T) {
+	dir := t.TempDir()
+	module := `
+resource "azurerm_resource_group" "main" {
+  location = "westeurope"
+  tags = {
+    Owner       = "platform"
+    Environment = "dev"
+  }
+}
+
+resource "azurerm_storage_account" "main" {
+  location = var.location
+  tags = {
+    Owner       = "platform"
+    Environment = "dev"
+    CostCenter  = "1234"
+  }
+}
+
+resource "azurerm_servicebus_namespace" "main" {
+  sku = "Standard"
+}
+
+resource "azurerm_servicebus_namespace" "events" {
+  sku = "Premium"
+}`
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(module), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := &bytes.Buffer{}
+	if err := Bootstrap(dir, out); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := `# Suggested by TFLINT_MATT_CUSTOM_BOOTSTRAP from ` + dir + `. Review every rule before setting ` + "`enforce = true`" + `.
+plugin "matt-custom" {
+  enabled = true
+}
+
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  enforce = false
+  # Tag keys used on all 2 resources with literal tags
+  tags = ["Environment", "Owner"]
+  # Also used on some resources: "CostCenter"
+}
+
+rule "azurerm_storage_account_invalid_account_tier" {
+  enabled = true
+  enforce = false
+}
+
+rule "azurerm_notification_hub_and_iothub_sku_tier" {
+  enabled = true
+  enforce = false
+  # Lowest SKUs in use, raise them to the tiers production requires
+  minimum_skus = {
+    azurerm_servicebus_namespace = "Standard"
+  }
+}
+
+# Locations in use: "westeurope"
+`
+	if out.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, out)
+	}
+}
+
+func Test_Bootstrap_EmptyDirectory(t *testing.T) {
+	if err := Bootstrap(t.TempDir(), &bytes.Buffer{}); err == nil {
+		t.Fatal("Expected an error for a directory without Terraform files")
+	}
+}
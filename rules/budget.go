@@ -0,0 +1,101 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// applyBudgets decodes the `budget` blocks of the plugin block
+func (r *RuleSet) applyBudgets(blocks hclext.Blocks) error {
+	r.budgets = map[string]int{}
+
+	for _, block := range blocks {
+		if block.Type != "budget" {
+			continue
+		}
+
+		var name string
+		if diags := gohcl.DecodeExpression(block.Body.Attributes["rule"].Expr, nil, &name); diags.HasErrors() {
+			return diags
+		}
+		var maxIssues int
+		if diags := gohcl.DecodeExpression(block.Body.Attributes["max_issues"].Expr, nil, &maxIssues); diags.HasErrors() {
+			return diags
+		}
+
+		names := r.RuleNames()
+		if !stringInSlice(name, names) {
+			return fmt.Errorf("budget: `%s` is not a rule of this ruleset.%s", name, didYouMean(name, names))
+		}
+		if maxIssues < 0 {
+			return fmt.Errorf("budget: max_issues of `%s` must not be negative", name)
+		}
+		if _, exists := r.budgets[name]; exists {
+			return fmt.Errorf("budget: `%s` has more than one budget", name)
+		}
+		r.budgets[name] = maxIssues
+	}
+	return nil
+}
+
+// Check runs the enabled rules. Rules with a budget report a single summary issue
+// when they find more issues than allowed, and nothing otherwise.
+func (r *RuleSet) Check(runner tflint.Runner) error {
+	for _, rule := range r.EnabledRules {
+		maxIssues, budgeted := r.budgets[rule.Name()]
+		if !budgeted {
+			if err := rule.Check(runner); err != nil {
+				return fmt.Errorf("Failed to check `%s` rule: %s", rule.Name(), err)
+			}
+			continue
+		}
+
+		counter := &budgetRunner{Runner: runner}
+		if err := rule.Check(counter); err != nil {
+			return fmt.Errorf("Failed to check `%s` rule: %s", rule.Name(), err)
+		}
+		if len(counter.issues) <= maxIssues {
+			continue
+		}
+
+		// The summary stands in for all the issues, so the fix of the first one is not attached to it
+		first := counter.issues[0]
+		if err := runner.EmitIssue(
+			first.rule,
+			fmt.Sprintf("%d issues exceed the budget of %d allowed in this module, the first one is: %s", len(counter.issues), maxIssues, first.message),
+			first.issueRange,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// budgetIssue is an issue held back until the rule has been checked
+type budgetIssue struct {
+	rule       tflint.Rule
+	message    string
+	issueRange hcl.Range
+}
+
+// budgetRunner collects the issues of a rule instead of emitting them
+type budgetRunner struct {
+	tflint.Runner
+
+	issues []budgetIssue
+}
+
+// EmitIssue collects the issue
+func (r *budgetRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	r.issues = append(r.issues, budgetIssue{rule: rule, message: message, issueRange: issueRange})
+	return nil
+}
+
+// EmitIssueWithFix collects the issue. Issues within the budget are not reported, so neither are their fixes.
+func (r *budgetRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, _ *issueFix) error {
+	return r.EmitIssue(rule, message, issueRange)
+}
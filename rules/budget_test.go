@@ -0,0 +1,124 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func applyPluginConfig(t *testing.T, ruleset *RuleSet, src string) error {
+	file, diags := hclsyntax.ParseConfig([]byte(src), "plugin.hcl", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	content, diags := hclext.Content(file.Body, ruleset.ConfigSchema())
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	return ruleset.ApplyConfig(content)
+}
+
+func Test_RuleSet_Budget(t *testing.T) {
+	content := `
+resource "azurerm_resource_group" "a" {
+  name = "a"
+}
+
+resource "azurerm_resource_group" "b" {
+  name = "b"
+}
+
+resource "azurerm_resource_group" "c" {
+  name = "c"
+}`
+
+	cases := []struct {
+		Name     string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Within budget",
+			Config: `
+budget {
+  rule       = "azurerm_resource_group_not_empty"
+  max_issues = 3
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Over budget",
+			Config: `
+budget {
+  rule       = "azurerm_resource_group_not_empty"
+  max_issues = 2
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceGroupNotEmptyRule(),
+					Message: "3 issues exceed the budget of 2 allowed in this module, the first one is: `azurerm_resource_group.a` is not referenced by the `resource_group_name` of any resource in the module, it may be a leftover from a refactor",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 38},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		ruleset := &RuleSet{
+			BuiltinRuleSet: tflint.BuiltinRuleSet{
+				Version: "0.2.0",
+				Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+			},
+		}
+		if err := applyPluginConfig(t, ruleset, tc.Config); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+		if err := ruleset.ApplyGlobalConfig(&tflint.Config{
+			Rules: map[string]*tflint.RuleConfig{
+				"azurerm_resource_group_not_empty": {Name: "azurerm_resource_group_not_empty", Enabled: true},
+			},
+		}); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		runner := helper.TestRunner(t, map[string]string{
+			"module.tf": content,
+			".tflint.hcl": `
+rule "azurerm_resource_group_not_empty" {
+  enabled = true
+}`,
+		})
+		if err := ruleset.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_RuleSet_BudgetUnknownRule(t *testing.T) {
+	ruleset := &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Version: "0.2.0",
+			Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+		},
+	}
+
+	err := applyPluginConfig(t, ruleset, `
+budget {
+  rule       = "azurerm_resource_group_not_emtpy"
+  max_issues = 2
+}`)
+	expected := "budget: `azurerm_resource_group_not_emtpy` is not a rule of this ruleset. Did you mean \"azurerm_resource_group_not_empty\"?"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,8 @@
+package rules
+
+// RenamedRules lists rules that were renamed. Configs using an old name keep working with a deprecation notice.
+// Add an entry whenever a rule is renamed, e.g. {OldName: "azurerm_resource_group_unused", NewName: "azurerm_resource_group_not_empty"}.
+var RenamedRules = []RenamedRule{}
+
+// RemovedRules lists rules that were removed. Configs enabling them fail with an error naming the replacement.
+var RemovedRules = []RemovedRule{}
@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// severityRule reports issues of the wrapped rule with a different severity
+type severityRule struct {
+	tflint.Rule
+
+	severity tflint.Severity
+}
+
+// Severity returns the overridden severity
+func (r *severityRule) Severity() tflint.Severity {
+	return r.severity
+}
+
+// severityNames maps the values of `severity` in rule configs to severities
+var severityNames = map[string]tflint.Severity{
+	"ERROR":   tflint.ERROR,
+	"WARNING": tflint.WARNING,
+	"NOTICE":  tflint.NOTICE,
+}
+
+// severityRunner emits the issues of a rule with the configured severity
+type severityRunner struct {
+	tflint.Runner
+
+	rule     tflint.Rule
+	severity tflint.Severity
+}
+
+// EmitIssue emits issues of the rule with the configured severity, leaving issues with their own severity as is
+func (r *severityRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.EmitIssueWithFix(rule, message, issueRange, nil)
+}
+
+// EmitIssueWithFix emits the issue like EmitIssue, passing the fix on to the wrapped runner
+func (r *severityRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	if rule == r.rule {
+		rule = &severityRule{Rule: rule, severity: r.severity}
+	}
+	return emitIssueWithFix(r.Runner, rule, message, issueRange, fix)
+}
+
+// withSeverity returns a runner that emits the issues of the rule with the severity configured by name, if any
+func withSeverity(runner tflint.Runner, rule tflint.Rule, name string) (tflint.Runner, error) {
+	if name == "" {
+		return runner, nil
+	}
+	severity, valid := severityNames[name]
+	if !valid {
+		return nil, fmt.Errorf("severity: \"%s\" is not a valid severity, expected one of \"ERROR\", \"WARNING\", \"NOTICE\"", name)
+	}
+	return &severityRunner{Runner: runner, rule: rule, severity: severity}, nil
+}
+
+// dryRunRunner emits every issue as a NOTICE describing what the rule would enforce,
+// so that a rule can be previewed without failing checks
+type dryRunRunner struct {
+	tflint.Runner
+}
+
+// EmitIssue emits the issue as a NOTICE preview
+func (r *dryRunRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.EmitIssueWithFix(rule, message, issueRange, nil)
+}
+
+// EmitIssueWithFix emits the issue like EmitIssue, passing the fix on to the wrapped runner
+func (r *dryRunRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	return emitIssueWithFix(
+		r.Runner,
+		&severityRule{Rule: rule, severity: tflint.NOTICE},
+		fmt.Sprintf("Dry run (enforce = false), this rule would report: %s", message),
+		issueRange,
+		fix,
+	)
+}
+
+// withEnforcement returns a runner that only previews issues when `enforce = false` is configured
+func withEnforcement(runner tflint.Runner, enforce *bool) tflint.Runner {
+	if enforce == nil || *enforce {
+		return runner
+	}
+	return &dryRunRunner{Runner: runner}
+}
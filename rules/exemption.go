@@ -0,0 +1,56 @@
+package rules
+
+import (
+	"fmt"
+	"time"
+)
+
+// ruleExemption temporarily exempts a resource type from a rule until the expiry date
+type ruleExemption struct {
+	Resource string `hclext:"resource"`
+	Expires  string `hclext:"expires"`
+	Owner    string `hclext:"owner"`
+}
+
+const exemptionDateLayout = "2006-01-02"
+
+// now is replaced in tests to pin the current date
+var now = time.Now
+
+// expired reports whether the exemption is past its expiry date.
+// An exemption is still valid on the day it expires.
+func (e ruleExemption) expired() (bool, error) {
+	expires, err := time.Parse(exemptionDateLayout, e.Expires)
+	if err != nil {
+		return false, fmt.Errorf("exemption for `%s` has an invalid expires date %q, want YYYY-MM-DD", e.Resource, e.Expires)
+	}
+	return !now().Before(expires.AddDate(0, 0, 1)), nil
+}
+
+// message returns the issue message reporting the stale exemption
+func (e ruleExemption) message() string {
+	return fmt.Sprintf("The exemption for `%s` owned by %s expired on %s and is no longer applied.", e.Resource, e.Owner, e.Expires)
+}
+
+// validateExemptions ensures all configured expiry dates can be parsed
+func validateExemptions(exemptions []ruleExemption) error {
+	for _, exemption := range exemptions {
+		if _, err := exemption.expired(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findExemption returns the exemption configured for the resource and whether it is still active.
+// Exemptions must be validated with validateExemptions beforehand.
+func findExemption(exemptions []ruleExemption, resource string) (*ruleExemption, bool) {
+	for i, exemption := range exemptions {
+		if exemption.Resource != resource {
+			continue
+		}
+		expired, _ := exemption.expired()
+		return &exemptions[i], !expired
+	}
+	return nil, false
+}
@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// issueFix is a suggested edit that replaces the text in Range with Replacement
+type issueFix struct {
+	Range       hcl.Range
+	Replacement string
+}
+
+// String renders the fix in a machine-readable form
+func (f *issueFix) String() string {
+	return fmt.Sprintf(
+		"Suggested fix: replace %d:%d-%d:%d with %s",
+		f.Range.Start.Line, f.Range.Start.Column, f.Range.End.Line, f.Range.End.Column, strconv.Quote(f.Replacement),
+	)
+}
+
+// fixRunner is a runner that can attach fixes to issues.
+// The runners of this ruleset wrapping another runner implement it and pass fixes on to the wrapped runner.
+// The plugin protocol of tflint-plugin-sdk v0.11 has no field for fixes, so the runners TFLint passes to rules
+// do not implement it. Attaching fixes for `tflint --fix` needs the SDK upgraded to a version with EmitIssueWithFix.
+type fixRunner interface {
+	tflint.Runner
+	EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error
+}
+
+// emitIssueWithFix emits the issue, attaching the fix when the runner supports fixes.
+// The message is the same either way.
+func emitIssueWithFix(runner tflint.Runner, rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	if fix == nil {
+		return runner.EmitIssue(rule, message, issueRange)
+	}
+	if fixer, ok := runner.(fixRunner); ok {
+		return fixer.EmitIssueWithFix(rule, message, issueRange, fix)
+	}
+	logger.Debug("%s: %s", issueRange, fix)
+	return runner.EmitIssue(rule, message, issueRange)
+}
@@ -0,0 +1,50 @@
+package rules
+
+import (
+	"fmt"
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// fixTestRunner is a test runner that supports fixes. It records each fix in the message of its issue,
+// so that tests can assert fixes with helper.AssertIssues.
+type fixTestRunner struct {
+	*helper.Runner
+}
+
+// EmitIssueWithFix emits the issue with the fix appended to the message
+func (r *fixTestRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	return r.EmitIssue(rule, fmt.Sprintf("%s %s", message, fix), issueRange)
+}
+
+func Test_EmitIssueWithFix_Wrappers(t *testing.T) {
+	rule := NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule()
+	fix := &issueFix{
+		Range:       hcl.Range{Filename: "module.tf", Start: hcl.Pos{Line: 3, Column: 26}, End: hcl.Pos{Line: 3, Column: 32}},
+		Replacement: `"prod"`,
+	}
+	enforce := false
+
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": ""})}
+	wrapped, err := withSeverity(withEnforcement(runner, &enforce), rule, "WARNING")
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	err = forEachParallel(wrapped, []string{"a", "b"}, 2, func(runner tflint.Runner, item string) error {
+		return emitIssueWithFix(runner, rule, "The value differs only by case.", fix.Range, fix)
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	// The dry run previews the issue as a NOTICE, wrapping the rule with the configured severity
+	emitted := &severityRule{Rule: &severityRule{Rule: rule, severity: tflint.WARNING}, severity: tflint.NOTICE}
+	message := "Dry run (enforce = false), this rule would report: The value differs only by case. Suggested fix: replace 3:26-3:32 with \"\\\"prod\\\"\""
+	helper.AssertIssues(t, helper.Issues{
+		{Rule: emitted, Message: message, Range: fix.Range},
+		{Rule: emitted, Message: message, Range: fix.Range},
+	}, runner.Issues)
+}
@@ -0,0 +1,46 @@
+package rules
+
+import (
+	"encoding/json"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// evaluateJSON evaluates the expression as a JSON string and passes the decoded document to proc.
+// When the string is not valid JSON, proc receives a nil document and the parse error.
+// proc is not called for unknown values.
+func evaluateJSON(runner tflint.Runner, expr hcl.Expression, proc func(document interface{}, err error) error) error {
+	var raw string
+	err := runner.EvaluateExpr(expr, &raw, nil)
+	return runner.EnsureNoError(err, func() error {
+		var document interface{}
+		if err := json.Unmarshal([]byte(raw), &document); err != nil {
+			return proc(nil, err)
+		}
+		return proc(document, nil)
+	})
+}
+
+// walkJSONStrings calls fn with the key path and value of every string in the document.
+// Object keys are visited in sorted order so that issues are reported deterministically.
+func walkJSONStrings(document interface{}, path []string, fn func(path []string, value string)) {
+	switch value := document.(type) {
+	case string:
+		fn(path, value)
+	case []interface{}:
+		for _, item := range value {
+			walkJSONStrings(item, path, fn)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			walkJSONStrings(value[key], append(append([]string{}, path...), key), fn)
+		}
+	}
+}
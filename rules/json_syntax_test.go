@@ -0,0 +1,213 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// jsonTestRunner returns a test runner with the files parsed as JSON syntax,
+// since helper.TestRunner parses every file as native syntax
+func jsonTestRunner(t *testing.T, config string, files map[string]string) *helper.Runner {
+	runner := helper.TestRunner(t, map[string]string{".tflint.hcl": config})
+	parser := hclparse.NewParser()
+	for name, src := range files {
+		file, diags := parser.ParseJSON([]byte(src), name)
+		if diags.HasErrors() {
+			t.Fatal(diags)
+		}
+		runner.AddLocalFile(name, file)
+	}
+	return runner
+}
+
+func Test_JSONSyntax(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Rule     tflint.Rule
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Missing tags",
+			Rule: NewAzurermResourceMissingTagsRule(),
+			Content: `{
+  "resource": {
+    "azurerm_resource_group": {
+      "main": {
+        "tags": {"Owner": "platform"}
+      },
+      "untagged": {
+        "name": "untagged"
+      }
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_resource_missing_tags" {
+  enabled = true
+  tags    = ["Owner", "Environment"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.main` is missing the following tags: \"Environment\".",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 5, Column: 17},
+						End:      hcl.Pos{Line: 5, Column: 38},
+					},
+				},
+				{
+					Rule:    NewAzurermResourceMissingTagsRule(),
+					Message: "`azurerm_resource_group.untagged` has no tags, so it is missing the following tags: \"Environment\", \"Owner\".",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 7, Column: 19},
+						End:      hcl.Pos{Line: 7, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "Resource group referenced by a module call",
+			Rule: NewAzurermResourceGroupNotEmptyRule(),
+			Content: `{
+  "resource": {
+    "azurerm_resource_group": {
+      "main": {"name": "main"},
+      "unused": {"name": "unused"}
+    }
+  },
+  "module": {
+    "network": {
+      "source": "./network",
+      "resource_group_name": "${azurerm_resource_group.main.name}"
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_resource_group_not_empty" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermResourceGroupNotEmptyRule(),
+					Message: "`azurerm_resource_group.unused` is not referenced by the `resource_group_name` of any resource in the module, it may be a leftover from a refactor",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 5, Column: 17},
+						End:      hcl.Pos{Line: 5, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "Variable feeding a nested secret attribute",
+			Rule: NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+			Content: `{
+  "variable": {
+    "admin_password": {"type": "string"}
+  },
+  "resource": {
+    "azurerm_container_group": {
+      "main": {
+        "container": [
+          {"secure_environment_variables": {"PASSWORD": "${var.admin_password}"}}
+        ]
+      }
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_ephemeral_and_sensitive_variable_usage" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+					Message: "`var.admin_password` feeds the secret attribute `azurerm_container_group.main.container.secure_environment_variables` but is not declared `sensitive = true`",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 3, Column: 23},
+						End:      hcl.Pos{Line: 3, Column: 24},
+					},
+				},
+			},
+		},
+		{
+			Name: "Literal secret in app settings",
+			Rule: NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+			Content: `{
+  "resource": {
+    "azurerm_linux_web_app": {
+      "main": {
+        "app_settings": {
+          "DB_PASSWORD": "hunter2",
+          "API_KEY": "@Microsoft.KeyVault(SecretUri=https://example.vault.azure.net/secrets/api-key)"
+        }
+      }
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_keyvault_secret_reference_over_literal_in_app_settings" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+					Message: "`azurerm_linux_web_app.main` sets the `DB_PASSWORD` app setting to a literal value, store it in Key Vault and use \"@Microsoft.KeyVault(SecretUri=...)\" instead",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 6, Column: 26},
+						End:      hcl.Pos{Line: 6, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "Count on a named resource",
+			Rule: NewAzurermCountVsForEachForNamedResourcesRule(),
+			Content: `{
+  "resource": {
+    "azurerm_key_vault": {
+      "main": {"count": 3},
+      "optional": {"count": 1}
+    }
+  }
+}`,
+			Config: `
+rule "azurerm_count_vs_for_each_for_named_resources" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewAzurermCountVsForEachForNamedResourcesRule(),
+					Message: "`azurerm_key_vault.main` uses `count`, use `for_each` with stable keys instead so that removing an element does not destroy and recreate the following resources",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 4, Column: 25},
+						End:      hcl.Pos{Line: 4, Column: 26},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := jsonTestRunner(t, tc.Config, map[string]string{"main.tf.json": tc.Content})
+
+			if err := tc.Rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Package rules implements the matt-custom TFLint ruleset. Besides serving the plugin, the rule constructors,
+// AllRules, TaggableResources and EvaluateTags are a stable API for embedding the rules in other rulesets.
+package rules
+
+import (
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/project"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// NewRuleSet returns the ruleset served by the plugin
+func NewRuleSet() *RuleSet {
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    "matt-custom",
+			Version: project.Version,
+			Rules:   AllRules(),
+		},
+		Renamed: RenamedRules,
+		Removed: RemovedRules,
+		Presets: Presets,
+	}
+}
+
+// AllRules returns new instances of all rules in the ruleset, so that other rulesets can embed some of them
+func AllRules() []tflint.Rule {
+	return []tflint.Rule{
+		NewAzurermResourceMissingTagsRule(),
+		NewAzurermStorageAccountInvalidAccountTierRule(),
+		NewAzurermStorageBlobVersioningAndSoftDeleteRule(),
+		NewAzurermKeyvaultKeyRotationPolicyRule(),
+		NewAzurermSQLFirewallNoAllowAllRule(),
+		NewAzurermAppConfigurationPurgeProtectionRule(),
+		NewAzurermStaticSiteAndCdnCustomDomainHTTPSRule(),
+		NewAzurermVMExtensionAllowlistRule(),
+		NewAzurermCustomScriptExtensionNoInlineSecretsRule(),
+		NewAzurermImageSourceAllowlistRule(),
+		NewAzurermDiskExportAndSharedAccessDisabledRule(),
+		NewAzurermPeeringConfigurationSanityRule(),
+		NewAzurermPrivatednsZoneLinksRequiredRule(),
+		NewAzurermPrivateEndpointDNSZoneGroupRule(),
+		NewAzurermTrafficManagerAndLbProbeRequiredRule(),
+		NewAzurermApplicationSecurityGroupsPreferredRule(),
+		NewAzurermPolicyAssignmentIdentityAndRemediationRule(),
+		NewAzurermSentinelAndDefenderPlanCoverageRule(),
+		NewAzurermResourceGroupNotEmptyRule(),
+		NewAzurermDataSourceFilterSpecificityRule(),
+		NewAzurermKeyvaultSecretReferenceOverLiteralInAppSettingsRule(),
+		NewAzurermLogicAppAndAutomationScheduleTimezoneRule(),
+		NewAzurermStorageQueueAndTableLoggingRule(),
+		NewAzurermAcrRetentionAndTrustPolicyRule(),
+		NewAzurermBatchAndHpcPoolAutoscaleRule(),
+		NewAzurermNotificationHubAndIothubSkuTierRule(),
+		NewAzurermProviderFeaturesBlockHardeningRule(),
+		NewAzurermEphemeralAndSensitiveVariableUsageRule(),
+		NewTerraformFmtStyleForTagsBlocksRule(),
+		NewAzurermCountVsForEachForNamedResourcesRule(),
+		NewAzurermDependsOnModulesDiscouragedRule(),
+		NewAzurermTagValueNoTrailingWhitespaceOrCaseDriftRule(),
+		NewAzurermTagsNotSetViaIgnoreChangesRule(),
+		NewAzurermResourceHasDescriptionOrCommentRule(),
+		NewAzurermPolicyDefinitionParametersSchemaCheckRule(),
+		NewAzurermRoleDefinitionActionsValidationRule(),
+		NewAzurermDashboardAndWorkbookJSONValidRule(),
+		NewAzurermAutomationRunbookContentSourcePinnedRule(),
+		NewAzurermResourceTagLimitsRule(),
+		NewAzurermGithubOidcFederatedCredentialsOverSecretsRule(),
+		NewAzurermModuleMissingTagsRule(),
+		NewAzurermLocationShortCodeConsistencyRule(),
+		NewAzurermPairedRegionForDrResourcesRule(),
+		NewAzurermZoneRedundantSkuInSupportedRegionsRule(),
+		NewAzurermApiVersionPinsInAzapiResourcesRule(),
+		NewAzurermTagsPropagatedToAzapiResourcesRule(),
+		NewAzurermResourceNamingConventionRule(),
+		NewAzurermResourceAttributeRequirementsRule(),
+		NewAzurermResourceRestrictedLocationsRule(),
+		NewAzurermForbiddenResourcesRule(),
+		NewAzurermStorageAccountInsecureSettingsRule(),
+		NewAzurermRequiredCompanionResourcesRule(),
+		NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+		NewTerraformExpressionComplexityRule(),
+		NewAzurermKeyVaultMissingProtectionRule(),
+		NewAzurermConditionalCreationPatternRule(),
+		NewAzurermCriticalResourceMissingPreventDestroyRule(),
+		NewAzurermForEachKeyStabilityRule(),
+		NewAzurermDeprecatedResourcesRule(),
+		NewAzurermSensitiveOutputOfKeysAndConnectionStringsRule(),
+		NewAzurermKubeConfigNotWrittenToLocalFileRule(),
+		NewAzurermNullResourceAndLocalExecDiscouragedRule(),
+	}
+}
+
+// TaggableResources returns the resource types checked by the `azurerm_resource_missing_tags` rule
+func TaggableResources() []string {
+	return append([]string(nil), taggableResources()...)
+}
+
+// EvaluateTags returns the tags set by the expression, the way the `azurerm_resource_missing_tags` rule sees them.
+// Tags merged from local values keep their keys even when values are unknown, which are returned as empty strings.
+// Tags that cannot be resolved at all return tflint.ErrUnknownValue.
+func EvaluateTags(runner tflint.Runner, expr hcl.Expression) (map[string]string, error) {
+	locals, err := moduleLocals(runner)
+	if err != nil {
+		return nil, err
+	}
+	tags, _, err := evaluateTags(runner, expr, locals)
+	return tags, err
+}
+
+// evaluateTags returns the tags set by the expression and the subset of them with literal values.
+// Tags merged from locals such as `merge(local.common_tags, var.tags)` are resolved part by part,
+// so that keys are known even when some values depend on unknown variables.
+func evaluateTags(runner tflint.Runner, expr hcl.Expression, locals map[string]*hcl.Attribute) (map[string]string, map[string]string, error) {
+	if referencesLocals(expr) {
+		if items, resolved := resolveMapItems(runner, expr, locals); resolved {
+			tags := map[string]string{}
+			literals := map[string]string{}
+			for key, value := range items {
+				literal, ok := literalString(value)
+				tags[key] = literal
+				if ok {
+					literals[key] = literal
+				}
+			}
+			return tags, literals, nil
+		}
+	}
+
+	tags := map[string]string{}
+	wantType := cty.Map(cty.String)
+	if err := runner.EvaluateExpr(expr, &tags, &tflint.EvaluateExprOption{WantType: &wantType}); err != nil {
+		return nil, nil, err
+	}
+	return tags, tags, nil
+}
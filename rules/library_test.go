@@ -0,0 +1,85 @@
+package rules
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_NewRuleSet(t *testing.T) {
+	var out bytes.Buffer
+	if !SelfTest(NewRuleSet(), &out) {
+		t.Fatalf("Expected the ruleset to pass the self-test, got:\n%s", out.String())
+	}
+}
+
+func Test_EvaluateTags(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected map[string]string
+		Err      error
+	}{
+		{
+			Name: "literal map",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform"
+  }
+}`,
+			Expected: map[string]string{"Owner": "platform"},
+		},
+		{
+			Name: "merged with locals",
+			Content: `
+locals {
+  common_tags = {
+    Owner = "platform"
+  }
+}
+
+variable "environment" {}
+
+resource "azurerm_resource_group" "main" {
+  tags = merge(local.common_tags, { Environment = var.environment })
+}`,
+			Expected: map[string]string{"Owner": "platform", "Environment": ""},
+		},
+		{
+			Name: "unknown",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = data.azurerm_resource_group.shared.tags
+}`,
+			Err: tflint.ErrUnknownValue,
+		},
+	}
+
+	for _, tc := range cases {
+		runner := &unknownValueRunner{Runner: helper.TestRunner(t, map[string]string{"module.tf": tc.Content})}
+		files, err := runner.GetFiles()
+		if err != nil {
+			t.Fatalf("Unexpected error occurred in %s: %s", tc.Name, err)
+		}
+		var tags map[string]string
+		for _, resource := range fileBlocks(files["module.tf"], "resource", "type", "name") {
+			tags, err = EvaluateTags(runner, blockAttributes(resource.Body)["tags"].Expr)
+		}
+
+		if !errors.Is(err, tc.Err) {
+			t.Fatalf("Expected error %v in %s, got %v", tc.Err, tc.Name, err)
+		}
+		if len(tags) != len(tc.Expected) {
+			t.Fatalf("Expected %v in %s, got %v", tc.Expected, tc.Name, tags)
+		}
+		for key, value := range tc.Expected {
+			if tags[key] != value {
+				t.Fatalf("Expected %v in %s, got %v", tc.Expected, tc.Name, tags)
+			}
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package rules
+
+import (
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// maxLocalDepth bounds how many local values are followed when resolving an expression, so that cycles terminate
+const maxLocalDepth = 10
+
+// moduleLocals returns the local values declared in the module by name
+func moduleLocals(runner tflint.Runner) (map[string]*hcl.Attribute, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	locals := map[string]*hcl.Attribute{}
+	for _, file := range files {
+		for _, block := range fileBlocks(file, "locals") {
+			for name, attribute := range blockAttributes(block.Body) {
+				locals[name] = attribute
+			}
+		}
+	}
+	return locals, nil
+}
+
+// resolveMapItems resolves the items of a map built from object literals, `merge()` calls and local values,
+// like `merge(local.common_tags, var.tags)`. Native syntax expressions are followed in the source so that the keys
+// are known even when some values are not, and other parts are evaluated by the runner, which resolves
+// variable defaults and tfvars. It returns false if any part of the map cannot be determined at lint time.
+func resolveMapItems(runner tflint.Runner, expr hcl.Expression, locals map[string]*hcl.Attribute) (map[string]hcl.Expression, bool) {
+	return resolveMapItemsDepth(runner, expr, locals, 0)
+}
+
+func resolveMapItemsDepth(runner tflint.Runner, expr hcl.Expression, locals map[string]*hcl.Attribute, depth int) (map[string]hcl.Expression, bool) {
+	switch expr := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		items := map[string]hcl.Expression{}
+		for _, item := range expr.Items {
+			key := settingKey(item.KeyExpr)
+			if key == "" {
+				return nil, false
+			}
+			items[key] = item.ValueExpr
+		}
+		return items, true
+	case *hclsyntax.FunctionCallExpr:
+		if expr.Name != "merge" || expr.ExpandFinal {
+			return nil, false
+		}
+		// Later arguments take precedence, as with merge()
+		items := map[string]hcl.Expression{}
+		for _, arg := range expr.Args {
+			argItems, ok := resolveMapItemsDepth(runner, arg, locals, depth)
+			if !ok {
+				return nil, false
+			}
+			for key, value := range argItems {
+				items[key] = value
+			}
+		}
+		return items, true
+	case *hclsyntax.ScopeTraversalExpr:
+		if expr.Traversal.RootName() != "local" {
+			return evaluatedMapItems(runner, expr)
+		}
+		if depth >= maxLocalDepth {
+			return nil, false
+		}
+		local, ok := localValue(expr.Traversal, locals)
+		if !ok {
+			return evaluatedMapItems(runner, expr)
+		}
+		return resolveMapItemsDepth(runner, local, locals, depth+1)
+	case *hclsyntax.ParenthesesExpr:
+		return resolveMapItemsDepth(runner, expr.Expression, locals, depth)
+	}
+	return evaluatedMapItems(runner, expr)
+}
+
+// localValue returns the expression of a local value such as `local.tags`, following attributes and keys
+// into object literals for traversals such as `local.tags.web` or `local.tags["web"]`
+func localValue(traversal hcl.Traversal, locals map[string]*hcl.Attribute) (hcl.Expression, bool) {
+	if len(traversal) < 2 {
+		return nil, false
+	}
+	name, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return nil, false
+	}
+	local, exists := locals[name.Name]
+	if !exists {
+		return nil, false
+	}
+
+	expr := local.Expr
+	for _, step := range traversal[2:] {
+		var key string
+		switch step := step.(type) {
+		case hcl.TraverseAttr:
+			key = step.Name
+		case hcl.TraverseIndex:
+			if step.Key.Type() != cty.String || !step.Key.IsKnown() {
+				return nil, false
+			}
+			key = step.Key.AsString()
+		default:
+			return nil, false
+		}
+
+		for {
+			parens, ok := expr.(*hclsyntax.ParenthesesExpr)
+			if !ok {
+				break
+			}
+			expr = parens.Expression
+		}
+		object, ok := expr.(*hclsyntax.ObjectConsExpr)
+		if !ok {
+			return nil, false
+		}
+		var value hcl.Expression
+		for _, item := range object.Items {
+			if settingKey(item.KeyExpr) == key {
+				value = item.ValueExpr
+			}
+		}
+		if value == nil {
+			return nil, false
+		}
+		expr = value
+	}
+	return expr, true
+}
+
+// mapLiterals returns the object literals that a map built like in resolveMapItems is made of, in source order,
+// so that issues can point at each of them. Each object literal is returned once, even if referenced repeatedly.
+func mapLiterals(expr hcl.Expression, locals map[string]*hcl.Attribute) []*hclsyntax.ObjectConsExpr {
+	seen := map[hcl.Range]bool{}
+	var literals []*hclsyntax.ObjectConsExpr
+	var walk func(expr hcl.Expression, depth int)
+	walk = func(expr hcl.Expression, depth int) {
+		switch expr := expr.(type) {
+		case *hclsyntax.ObjectConsExpr:
+			if !seen[expr.Range()] {
+				seen[expr.Range()] = true
+				literals = append(literals, expr)
+			}
+		case *hclsyntax.FunctionCallExpr:
+			if expr.Name == "merge" {
+				for _, arg := range expr.Args {
+					walk(arg, depth)
+				}
+			}
+		case *hclsyntax.ScopeTraversalExpr:
+			if depth >= maxLocalDepth || expr.Traversal.RootName() != "local" {
+				return
+			}
+			if local, ok := localValue(expr.Traversal, locals); ok {
+				walk(local, depth+1)
+			}
+		case *hclsyntax.ParenthesesExpr:
+			walk(expr.Expression, depth)
+		}
+	}
+	walk(expr, 0)
+	return literals
+}
+
+// nestingDepth returns how many `merge()` calls and local values are nested in a map built like in resolveMapItems,
+// e.g. 2 for `merge(local.common_tags, {})`. Walking stops past maxLocalDepth, so that cycles between locals terminate.
+func nestingDepth(expr hcl.Expression, locals map[string]*hcl.Attribute) int {
+	var walk func(expr hcl.Expression, depth int) int
+	walk = func(expr hcl.Expression, depth int) int {
+		if depth > maxLocalDepth {
+			return depth
+		}
+		switch expr := expr.(type) {
+		case *hclsyntax.FunctionCallExpr:
+			if expr.Name != "merge" {
+				return depth
+			}
+			deepest := depth + 1
+			for _, arg := range expr.Args {
+				if d := walk(arg, depth+1); d > deepest {
+					deepest = d
+				}
+			}
+			return deepest
+		case *hclsyntax.ScopeTraversalExpr:
+			if expr.Traversal.RootName() != "local" {
+				return depth
+			}
+			if local, ok := localValue(expr.Traversal, locals); ok {
+				return walk(local, depth+1)
+			}
+		case *hclsyntax.ParenthesesExpr:
+			return walk(expr.Expression, depth)
+		}
+		return depth
+	}
+	return walk(expr, 0)
+}
+
+// evaluatedMapItems evaluates a map of strings with the runner, returning false if its value is unknown or invalid
+func evaluatedMapItems(runner tflint.Runner, expr hcl.Expression) (map[string]hcl.Expression, bool) {
+	values := map[string]string{}
+	wantType := cty.Map(cty.String)
+	if err := runner.EvaluateExpr(expr, &values, &tflint.EvaluateExprOption{WantType: &wantType}); err != nil {
+		return nil, false
+	}
+
+	items := make(map[string]hcl.Expression, len(values))
+	for key, value := range values {
+		items[key] = hcl.StaticExpr(cty.StringVal(value), expr.Range())
+	}
+	return items, true
+}
+
+// referencesLocals returns whether the expression references any local value
+func referencesLocals(expr hcl.Expression) bool {
+	return len(referencedValues(expr, "local")) > 0
+}
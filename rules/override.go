@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+)
+
+// isOverrideFile returns whether the file is a Terraform override file (override.tf or *_override.tf)
+func isOverrideFile(filename string) bool {
+	name := strings.TrimSuffix(filepath.Base(filename), ".json")
+	if !strings.HasSuffix(name, ".tf") {
+		return false
+	}
+	name = strings.TrimSuffix(name, ".tf")
+	return name == "override" || strings.HasSuffix(name, "_override")
+}
+
+// mergeOverrides merges blocks declared in override files into the blocks they override,
+// following Terraform's override semantics: every attribute and nested block type set in
+// an override replaces the original one. Override files are applied in lexical order.
+// The merged block keeps the declaration range of the original block.
+func mergeOverrides(blocks hclext.Blocks) hclext.Blocks {
+	merged := hclext.Blocks{}
+	byAddress := map[string]*hclext.Block{}
+	overrides := hclext.Blocks{}
+
+	for _, block := range blocks {
+		if isOverrideFile(block.DefRange.Filename) {
+			overrides = append(overrides, block)
+			continue
+		}
+		block = copyBlock(block)
+		byAddress[strings.Join(block.Labels, ".")] = block
+		merged = append(merged, block)
+	}
+
+	sort.SliceStable(overrides, func(i, j int) bool {
+		return overrides[i].DefRange.Filename < overrides[j].DefRange.Filename
+	})
+	for _, override := range overrides {
+		base, exists := byAddress[strings.Join(override.Labels, ".")]
+		if !exists {
+			// Terraform rejects overrides without an original block, but the content should still be inspected
+			merged = append(merged, override)
+			continue
+		}
+
+		for name, attribute := range override.Body.Attributes {
+			base.Body.Attributes[name] = attribute
+		}
+		overridden := override.Body.Blocks.ByType()
+		nested := hclext.Blocks{}
+		for _, block := range base.Body.Blocks {
+			if _, exists := overridden[block.Type]; !exists {
+				nested = append(nested, block)
+			}
+		}
+		base.Body.Blocks = append(nested, override.Body.Blocks...)
+	}
+
+	return merged
+}
+
+// copyBlock returns a copy of the block whose body can be modified without affecting the original
+func copyBlock(block *hclext.Block) *hclext.Block {
+	body := &hclext.BodyContent{
+		Attributes: hclext.Attributes{},
+		Blocks:     hclext.Blocks{},
+	}
+	if block.Body != nil {
+		for name, attribute := range block.Body.Attributes {
+			body.Attributes[name] = attribute
+		}
+		body.Blocks = append(body.Blocks, block.Body.Blocks...)
+	}
+
+	copied := *block
+	copied.Body = body
+	return &copied
+}
@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"sync"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// maxWorkers bounds the goroutines of a rule, since every worker makes requests to TFLint at the same time
+const maxWorkers = 64
+
+// recordedIssue is an issue emitted by a worker
+type recordedIssue struct {
+	rule       tflint.Rule
+	message    string
+	issueRange hcl.Range
+	fix        *issueFix
+}
+
+// recordingRunner holds back the issues emitted by a worker, so that they are emitted in a deterministic order
+type recordingRunner struct {
+	tflint.Runner
+
+	issues []recordedIssue
+}
+
+// EmitIssue records the issue
+func (r *recordingRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.EmitIssueWithFix(rule, message, issueRange, nil)
+}
+
+// EmitIssueWithFix records the issue with its fix
+func (r *recordingRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	r.issues = append(r.issues, recordedIssue{rule: rule, message: message, issueRange: issueRange, fix: fix})
+	return nil
+}
+
+// forEachParallel calls check for every item, with up to the given number of workers running at once.
+// The issues of each item are emitted in the order of the items once all are checked, as one worker would emit them,
+// and the error of the first item that failed is returned.
+func forEachParallel(runner tflint.Runner, items []string, workers int, check func(runner tflint.Runner, item string) error) error {
+	if workers <= 1 {
+		for _, item := range items {
+			if err := check(runner, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	recorders := make([]*recordingRunner, len(items))
+	errs := make([]error, len(items))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(items); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				recorders[i] = &recordingRunner{Runner: runner}
+				errs[i] = check(recorders[i], items[i])
+			}
+		}()
+	}
+	for i := range items {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for i, recorder := range recorders {
+		for _, issue := range recorder.issues {
+			if err := emitIssueWithFix(runner, issue.rule, issue.message, issue.issueRange, issue.fix); err != nil {
+				return err
+			}
+		}
+		if errs[i] != nil {
+			return errs[i]
+		}
+	}
+	return nil
+}
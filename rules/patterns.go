@@ -0,0 +1,44 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// patternCache holds compiled regular expressions by source.
+// Rules are checked once per module and every module shares the same config, so each pattern is compiled once.
+var patternCache = struct {
+	sync.Mutex
+	patterns map[string]*regexp.Regexp
+}{patterns: map[string]*regexp.Regexp{}}
+
+// compilePattern returns the compiled regular expression, reusing it from the cache when it was compiled before
+func compilePattern(source string) (*regexp.Regexp, error) {
+	patternCache.Lock()
+	defer patternCache.Unlock()
+
+	if pattern, cached := patternCache.patterns[source]; cached {
+		return pattern, nil
+	}
+	pattern, err := regexp.Compile(source)
+	if err != nil {
+		return nil, err
+	}
+	patternCache.patterns[source] = pattern
+	return pattern, nil
+}
+
+// compilePatterns compiles the patterns of a config attribute right after the config is decoded,
+// so that invalid patterns are reported as config errors before any resource is checked
+func compilePatterns(attribute string, sources []string) ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, len(sources))
+	for i, source := range sources {
+		pattern, err := compilePattern(source)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q: %s", attribute, source, err)
+		}
+		patterns[i] = pattern
+	}
+	return patterns, nil
+}
@@ -0,0 +1,26 @@
+package rules
+
+import "testing"
+
+func Test_compilePatterns(t *testing.T) {
+	first, err := compilePatterns("patterns", []string{`^CC-[0-9]{4}$`})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	second, err := compilePatterns("patterns", []string{`^CC-[0-9]{4}$`})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if first[0] != second[0] {
+		t.Error("Expected the compiled pattern to be reused")
+	}
+
+	_, err = compilePatterns("key_patterns", []string{`(?i)secret`, `[`})
+	if err == nil {
+		t.Fatal("Expected an error for an invalid pattern")
+	}
+	want := "key_patterns: invalid pattern \"[\": error parsing regexp: missing closing ]: `[`"
+	if err.Error() != want {
+		t.Errorf("Unexpected error: got %q, want %q", err, want)
+	}
+}
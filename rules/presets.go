@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+)
+
+// allPreset is the preset enabling every rule of the ruleset
+const allPreset = "all"
+
+// Presets maps the names of the presets that can be set with `preset` in the plugin block to the rules they enable.
+// Add new rules to the presets they belong to; the "all" preset enables every rule without being listed here.
+var Presets = map[string][]string{
+	"security": {
+		"azurerm_app_configuration_purge_protection",
+		"azurerm_custom_script_extension_no_inline_secrets",
+		"azurerm_disk_export_and_shared_access_disabled",
+		"azurerm_ephemeral_and_sensitive_variable_usage",
+		"azurerm_github_oidc_federated_credentials_over_secrets",
+		"azurerm_key_vault_missing_protection",
+		"azurerm_keyvault_key_rotation_policy",
+		"azurerm_keyvault_secret_reference_over_literal_in_app_settings",
+		"azurerm_kube_config_not_written_to_local_file",
+		"azurerm_network_security_rule_open_to_internet",
+		"azurerm_provider_features_block_hardening",
+		"azurerm_sensitive_output_of_keys_and_connection_strings",
+		"azurerm_sentinel_and_defender_plan_coverage",
+		"azurerm_sql_firewall_no_allow_all",
+		"azurerm_static_site_and_cdn_custom_domain_https",
+		"azurerm_storage_account_insecure_settings",
+	},
+	"governance": {
+		"azurerm_critical_resource_missing_prevent_destroy",
+		"azurerm_deprecated_resources",
+		"azurerm_location_short_code_consistency",
+		"azurerm_module_missing_tags",
+		"azurerm_null_resource_and_local_exec_discouraged",
+		"azurerm_policy_assignment_identity_and_remediation",
+		"azurerm_resource_has_description_or_comment",
+		"azurerm_resource_missing_tags",
+		"azurerm_resource_naming_convention",
+		"azurerm_resource_tag_limits",
+		"azurerm_tag_value_no_trailing_whitespace_or_case_drift",
+		"azurerm_tags_not_set_via_ignore_changes",
+		"azurerm_tags_propagated_to_azapi_resources",
+	},
+}
+
+// applyPreset validates the preset set in the plugin block and enables its rules once both the plugin block
+// and the global config have been applied. Rules with a rule block keep the `enabled` of the block,
+// and presets enable nothing when TFLint runs only the rules given with --only.
+// Rules with required settings, such as the `tags` of azurerm_resource_missing_tags, are only enabled
+// when the base config sets them, since they would fail the whole run otherwise.
+func (r *RuleSet) applyPreset() error {
+	if r.preset == "" {
+		return nil
+	}
+	names := append([]string{allPreset}, sortedPresetNames(r.Presets)...)
+	if !stringInSlice(r.preset, names) {
+		return fmt.Errorf("preset: unknown preset %q, expected one of %s.%s", r.preset, quoteAll(names), didYouMean(r.preset, names))
+	}
+	if r.globalConfig == nil || r.globalConfig.DisabledByDefault {
+		return nil
+	}
+
+	enabled := map[string]bool{}
+	for _, rule := range r.EnabledRules {
+		enabled[rule.Name()] = true
+	}
+	for _, rule := range r.Rules {
+		name := rule.Name()
+		if enabled[name] || r.globalConfig.Rules[name] != nil {
+			continue
+		}
+		if r.preset != allPreset && !stringInSlice(name, r.Presets[r.preset]) {
+			continue
+		}
+		if _, err := smokeCheck(rule); err != nil {
+			logger.Info("The %q preset does not enable `%s`, which needs config: %s", r.preset, name, err)
+			continue
+		}
+		r.EnabledRules = append(r.EnabledRules, rule)
+	}
+	return nil
+}
+
+// sortedPresetNames returns the names of the presets in sorted order
+func sortedPresetNames(presets map[string][]string) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_RuleSet_Preset(t *testing.T) {
+	cases := []struct {
+		Name       string
+		Config     string
+		Global     *tflint.Config
+		BaseConfig string
+		Expected   []string
+	}{
+		{
+			Name:     "Security preset",
+			Config:   `preset = "security"`,
+			Global:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: []string{"azurerm_network_security_rule_open_to_internet", "azurerm_key_vault_missing_protection"},
+		},
+		{
+			Name:   "Rule blocks override the preset",
+			Config: `preset = "security"`,
+			Global: &tflint.Config{
+				Rules: map[string]*tflint.RuleConfig{
+					"azurerm_key_vault_missing_protection": {Name: "azurerm_key_vault_missing_protection", Enabled: false},
+					"azurerm_resource_group_not_empty":     {Name: "azurerm_resource_group_not_empty", Enabled: true},
+				},
+			},
+			Expected: []string{"azurerm_resource_group_not_empty", "azurerm_network_security_rule_open_to_internet"},
+		},
+		{
+			Name:     "All preset without config",
+			Config:   `preset = "all"`,
+			Global:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: []string{"azurerm_network_security_rule_open_to_internet", "azurerm_resource_group_not_empty", "azurerm_key_vault_missing_protection"},
+		},
+		{
+			Name:     "Rules needing config are not enabled without it",
+			Config:   `preset = "governance"`,
+			Global:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: []string{},
+		},
+		{
+			Name:   "Rules needing config are enabled by their rule block",
+			Config: `preset = "governance"`,
+			Global: &tflint.Config{
+				Rules: map[string]*tflint.RuleConfig{
+					"azurerm_resource_missing_tags": {Name: "azurerm_resource_missing_tags", Enabled: true},
+				},
+			},
+			Expected: []string{"azurerm_resource_missing_tags"},
+		},
+		{
+			Name:   "Rules needing config are enabled when the base config sets it",
+			Config: `preset = "governance"`,
+			Global: &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			BaseConfig: `
+rule "azurerm_resource_missing_tags" {
+  tags = ["Owner"]
+}`,
+			Expected: []string{"azurerm_resource_missing_tags"},
+		},
+		{
+			Name:   "Only mode",
+			Config: `preset = "all"`,
+			Global: &tflint.Config{
+				DisabledByDefault: true,
+				Rules: map[string]*tflint.RuleConfig{
+					"azurerm_resource_group_not_empty": {Name: "azurerm_resource_group_not_empty", Enabled: true},
+				},
+			},
+			Expected: []string{"azurerm_resource_group_not_empty"},
+		},
+		{
+			Name:     "No preset",
+			Global:   &tflint.Config{Rules: map[string]*tflint.RuleConfig{}},
+			Expected: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv(baseConfigEnv, "")
+			if tc.BaseConfig != "" {
+				path := filepath.Join(t.TempDir(), "matt-custom.hcl")
+				if err := os.WriteFile(path, []byte(tc.BaseConfig), 0o600); err != nil {
+					t.Fatalf("Unexpected error occurred: %s", err)
+				}
+				t.Setenv(baseConfigEnv, path)
+			}
+
+			ruleset := &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Version: "0.2.0",
+					Rules: []tflint.Rule{
+						NewAzurermNetworkSecurityRuleOpenToInternetRule(),
+						NewAzurermResourceGroupNotEmptyRule(),
+						NewAzurermKeyVaultMissingProtectionRule(),
+						NewAzurermResourceMissingTagsRule(),
+					},
+				},
+				Presets: Presets,
+			}
+			// TFLint applies the global config before the plugin block
+			if err := ruleset.ApplyGlobalConfig(tc.Global); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+			if err := applyPluginConfig(t, ruleset, tc.Config); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			names := []string{}
+			for _, rule := range ruleset.EnabledRules {
+				names = append(names, rule.Name())
+			}
+			if diff := cmp.Diff(tc.Expected, names); diff != "" {
+				t.Fatalf("Unexpected enabled rules:\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_RuleSet_UnknownPreset(t *testing.T) {
+	ruleset := &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Version: "0.2.0",
+			Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+		},
+		Presets: Presets,
+	}
+
+	err := applyPluginConfig(t, ruleset, `preset = "securty"`)
+	expected := "preset: unknown preset \"securty\", expected one of \"all\", \"governance\", \"security\". Did you mean \"security\"?"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,35 @@
+package rules
+
+// providerDeprecation is a resource type or argument deprecated by the azurerm provider
+type providerDeprecation struct {
+	// Resource is the deprecated resource type, or the type whose Argument is deprecated
+	Resource string
+	// Argument is the deprecated argument, or empty if the whole resource type is deprecated
+	Argument string
+	// Replacements are the resource types or arguments to use instead
+	Replacements []string
+	// Version is the azurerm provider version that deprecated the resource type or argument
+	Version string
+}
+
+// providerDeprecations lists the deprecations reported by azurerm_deprecated_resources.
+// Add an entry when a provider release deprecates a resource type or argument, with the version of the release.
+var providerDeprecations = []providerDeprecation{
+	{Resource: "azurerm_virtual_machine", Replacements: []string{"azurerm_linux_virtual_machine", "azurerm_windows_virtual_machine"}, Version: "2.0.0"},
+	{Resource: "azurerm_virtual_machine_scale_set", Replacements: []string{"azurerm_linux_virtual_machine_scale_set", "azurerm_windows_virtual_machine_scale_set", "azurerm_orchestrated_virtual_machine_scale_set"}, Version: "2.0.0"},
+	{Resource: "azurerm_app_service", Replacements: []string{"azurerm_linux_web_app", "azurerm_windows_web_app"}, Version: "3.0.0"},
+	{Resource: "azurerm_app_service_plan", Replacements: []string{"azurerm_service_plan"}, Version: "3.0.0"},
+	{Resource: "azurerm_app_service_slot", Replacements: []string{"azurerm_linux_web_app_slot", "azurerm_windows_web_app_slot"}, Version: "3.0.0"},
+	{Resource: "azurerm_function_app", Replacements: []string{"azurerm_linux_function_app", "azurerm_windows_function_app"}, Version: "3.0.0"},
+	{Resource: "azurerm_function_app_slot", Replacements: []string{"azurerm_linux_function_app_slot", "azurerm_windows_function_app_slot"}, Version: "3.0.0"},
+	{Resource: "azurerm_sql_server", Replacements: []string{"azurerm_mssql_server"}, Version: "3.0.0"},
+	{Resource: "azurerm_sql_database", Replacements: []string{"azurerm_mssql_database"}, Version: "3.0.0"},
+	{Resource: "azurerm_sql_elasticpool", Replacements: []string{"azurerm_mssql_elasticpool"}, Version: "3.0.0"},
+	{Resource: "azurerm_sql_firewall_rule", Replacements: []string{"azurerm_mssql_firewall_rule"}, Version: "3.0.0"},
+	{Resource: "azurerm_mysql_server", Replacements: []string{"azurerm_mysql_flexible_server"}, Version: "3.0.0"},
+	{Resource: "azurerm_postgresql_server", Replacements: []string{"azurerm_postgresql_flexible_server"}, Version: "3.0.0"},
+	{Resource: "azurerm_storage_account", Argument: "allow_blob_public_access", Replacements: []string{"allow_nested_items_to_be_public"}, Version: "3.0.0"},
+	{Resource: "azurerm_storage_account", Argument: "enable_https_traffic_only", Replacements: []string{"https_traffic_only_enabled"}, Version: "4.0.0"},
+	{Resource: "azurerm_kubernetes_cluster", Argument: "api_server_authorized_ip_ranges", Replacements: []string{"api_server_access_profile.authorized_ip_ranges"}, Version: "4.0.0"},
+	{Resource: "azurerm_key_vault", Argument: "enable_rbac_authorization", Replacements: []string{"rbac_authorization_enabled"}, Version: "4.0.0"},
+}
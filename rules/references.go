@@ -0,0 +1,131 @@
+package rules
+
+import (
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// referencedResources returns the addresses of the resources and data sources referenced by the expression,
+// e.g. "azurerm_storage_account.main" or "data.azurerm_client_config.current"
+func referencedResources(expr hcl.Expression) []string {
+	addresses := []string{}
+	for _, traversal := range expr.Variables() {
+		if address := resourceAddress(traversal); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// referencesResource returns whether the expression references the resource at the address
+func referencesResource(expr hcl.Expression, address string) bool {
+	return stringInSlice(address, referencedResources(expr))
+}
+
+// resourceAddress returns the address of the resource or data source the traversal starts with,
+// or an empty string if it refers to something else such as a variable or local value
+func resourceAddress(traversal hcl.Traversal) string {
+	if len(traversal) < 2 {
+		return ""
+	}
+
+	switch root := traversal.RootName(); root {
+	case "var", "local", "module", "count", "each", "path", "terraform", "self":
+		return ""
+	case "data":
+		if len(traversal) < 3 {
+			return ""
+		}
+		dataType, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return ""
+		}
+		name, ok := traversal[2].(hcl.TraverseAttr)
+		if !ok {
+			return ""
+		}
+		return "data." + dataType.Name + "." + name.Name
+	default:
+		name, ok := traversal[1].(hcl.TraverseAttr)
+		if !ok {
+			return ""
+		}
+		return root + "." + name.Name
+	}
+}
+
+// referencedByResources returns the addresses referenced by the given attributes of each resource type
+func referencedByResources(runner tflint.Runner, attributes map[string][]string) (map[string]bool, error) {
+	addresses := map[string]bool{}
+
+	for resourceType, attributeNames := range attributes {
+		schema := &hclext.BodySchema{}
+		for _, name := range attributeNames {
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+		}
+
+		resources, err := runner.GetResourceContent(resourceType, schema, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, resource := range resources.Blocks {
+			for _, attribute := range resource.Body.Attributes {
+				for _, address := range referencedResources(attribute.Expr) {
+					addresses[address] = true
+				}
+			}
+		}
+	}
+
+	return addresses, nil
+}
+
+// referencedValues returns the names of the values under the root referenced by the expression,
+// e.g. "admin_password" for `var.admin_password` with the root "var"
+func referencedValues(expr hcl.Expression, root string) []string {
+	names := []string{}
+	for _, traversal := range expr.Variables() {
+		if len(traversal) < 2 || traversal.RootName() != root {
+			continue
+		}
+		if name, ok := traversal[1].(hcl.TraverseAttr); ok && !stringInSlice(name.Name, names) {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// referencedAttribute returns the address of the resource or data source the traversal starts with and the name
+// of the attribute it refers to, e.g. "azurerm_storage_account.main" and "primary_access_key"
+// for `azurerm_storage_account.main[0].primary_access_key`
+func referencedAttribute(traversal hcl.Traversal) (string, string) {
+	address := resourceAddress(traversal)
+	if address == "" {
+		return "", ""
+	}
+
+	steps := traversal[2:]
+	if traversal.RootName() == "data" {
+		steps = traversal[3:]
+	}
+	for _, step := range steps {
+		// Instance keys of resources with count or for_each come before the attribute
+		if _, ok := step.(hcl.TraverseIndex); ok {
+			continue
+		}
+		if attribute, ok := step.(hcl.TraverseAttr); ok {
+			return address, attribute.Name
+		}
+		break
+	}
+	return address, ""
+}
+
+// addressType returns the resource or data source type of an address returned by resourceAddress
+func addressType(address string) string {
+	return strings.Split(strings.TrimPrefix(address, "data."), ".")[0]
+}
@@ -0,0 +1,190 @@
+package rules
+
+import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// RuleSet is the ruleset served by the plugin. It extends the builtin ruleset so that
+// renamed rules keep working under their old names and removed rules fail with a clear error.
+type RuleSet struct {
+	tflint.BuiltinRuleSet
+
+	Renamed []RenamedRule
+	Removed []RemovedRule
+	Presets map[string][]string
+
+	// globalConfig is the config applied by ApplyGlobalConfig, which presets need to respect rule blocks
+	globalConfig *tflint.Config
+	// preset is the name of the preset set in the plugin block, if any
+	preset string
+	// budgets maps rule names to the number of issues allowed per module
+	budgets map[string]int
+}
+
+// RenamedRule maps the old name of a rule to its current name
+type RenamedRule struct {
+	OldName string
+	NewName string
+}
+
+// RemovedRule records a rule that no longer exists and what replaces it, if anything
+type RemovedRule struct {
+	Name        string
+	Version     string
+	Replacement string
+}
+
+// RuleNames returns the names of the rules, including old names of renamed rules and removed rules
+// so that configs referring to them reach ApplyGlobalConfig instead of failing as unknown rules
+func (r *RuleSet) RuleNames() []string {
+	names := r.BuiltinRuleSet.RuleNames()
+	for _, renamed := range r.Renamed {
+		names = append(names, renamed.OldName)
+	}
+	for _, removed := range r.Removed {
+		names = append(names, removed.Name)
+	}
+	return names
+}
+
+// ApplyGlobalConfig enables rules like the builtin ruleset, then enables renamed rules configured under their old names
+// and the rules of the preset
+func (r *RuleSet) ApplyGlobalConfig(config *tflint.Config) error {
+	r.globalConfig = config
+
+	for _, removed := range r.Removed {
+		if cfg := config.Rules[removed.Name]; cfg != nil && cfg.Enabled {
+			message := fmt.Sprintf("the `%s` rule was removed in %s", removed.Name, removed.Version)
+			if removed.Replacement != "" {
+				return fmt.Errorf("%s, use the `%s` rule instead", message, removed.Replacement)
+			}
+			return fmt.Errorf("%s, remove its rule block from .tflint.hcl", message)
+		}
+	}
+
+	if err := r.BuiltinRuleSet.ApplyGlobalConfig(config); err != nil {
+		return err
+	}
+
+	for _, renamed := range r.Renamed {
+		cfg := config.Rules[renamed.OldName]
+		if cfg == nil || !cfg.Enabled {
+			continue
+		}
+		if config.Rules[renamed.NewName] != nil {
+			return fmt.Errorf("both `%s` and its new name `%s` are configured, remove the `%s` rule block", renamed.OldName, renamed.NewName, renamed.OldName)
+		}
+
+		for _, rule := range r.Rules {
+			if rule.Name() == renamed.NewName {
+				r.EnabledRules = append(r.EnabledRules, &renamedRule{Rule: rule, name: renamed.OldName})
+			}
+		}
+	}
+	return r.applyPreset()
+}
+
+// ConfigSchema returns the schema of the plugin block
+func (r *RuleSet) ConfigSchema() *hclext.BodySchema {
+	return &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{{Name: "ruleset_version"}, {Name: "preset"}},
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "budget",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "rule", Required: true},
+						{Name: "max_issues", Required: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ApplyConfig pins the behavior of the rules to `ruleset_version`, if set, and applies the issue budgets and the preset
+func (r *RuleSet) ApplyConfig(content *hclext.BodyContent) error {
+	if err := r.applyBudgets(content.Blocks); err != nil {
+		return err
+	}
+
+	r.preset = ""
+	if attribute, exists := content.Attributes["preset"]; exists {
+		if diags := gohcl.DecodeExpression(attribute.Expr, nil, &r.preset); diags.HasErrors() {
+			return diags
+		}
+	}
+	if err := r.applyPreset(); err != nil {
+		return err
+	}
+
+	version := ""
+	if attribute, exists := content.Attributes["ruleset_version"]; exists {
+		if diags := gohcl.DecodeExpression(attribute.Expr, nil, &version); diags.HasErrors() {
+			return diags
+		}
+	}
+	return pinRulesetVersion(version, r.Version)
+}
+
+// renamedRule runs a rule under its old name, reading the config from the old rule block
+type renamedRule struct {
+	tflint.Rule
+
+	name string
+}
+
+// Name returns the old rule name
+func (r *renamedRule) Name() string {
+	return r.name
+}
+
+// Check reports the deprecation and checks the renamed rule
+func (r *renamedRule) Check(runner tflint.Runner) error {
+	message := fmt.Sprintf("The `%s` rule has been renamed to `%s` and its old name is deprecated, rename the rule block in .tflint.hcl", r.name, r.Rule.Name())
+	blocks, err := ruleConfigBlocks(runner, r.name)
+	if err != nil {
+		return err
+	}
+	// Without a rule block to point at, an issue would have no location, so the notice is logged instead
+	if len(blocks) == 0 {
+		logger.Warn("%s", message)
+	} else if err := runner.EmitIssue(&severityRule{Rule: r, severity: tflint.NOTICE}, message, blocks[0].DefRange); err != nil {
+		return err
+	}
+	return r.Rule.Check(&renamedRuleRunner{Runner: runner, rule: r})
+}
+
+// renamedRuleRunner translates between the current and the old name of a renamed rule
+type renamedRuleRunner struct {
+	tflint.Runner
+
+	rule *renamedRule
+}
+
+// DecodeRuleConfig decodes the config of the old rule block
+func (r *renamedRuleRunner) DecodeRuleConfig(name string, ret interface{}) error {
+	if name == r.rule.Rule.Name() {
+		name = r.rule.name
+	}
+	return r.Runner.DecodeRuleConfig(name, ret)
+}
+
+// EmitIssue emits the issue under the old rule name
+func (r *renamedRuleRunner) EmitIssue(rule tflint.Rule, message string, issueRange hcl.Range) error {
+	return r.EmitIssueWithFix(rule, message, issueRange, nil)
+}
+
+// EmitIssueWithFix emits the issue like EmitIssue, passing the fix on to the wrapped runner
+func (r *renamedRuleRunner) EmitIssueWithFix(rule tflint.Rule, message string, issueRange hcl.Range, fix *issueFix) error {
+	if rule.Name() == r.rule.Rule.Name() {
+		rule = &renamedRule{Rule: rule, name: r.rule.name}
+	}
+	return emitIssueWithFix(r.Runner, rule, message, issueRange, fix)
+}
@@ -0,0 +1,145 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func testRuleSet() *RuleSet {
+	return &RuleSet{
+		BuiltinRuleSet: tflint.BuiltinRuleSet{
+			Name:    "matt-custom",
+			Version: "0.1.0",
+			Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+		},
+		Renamed: []RenamedRule{{OldName: "azurerm_resource_group_unused", NewName: "azurerm_resource_group_not_empty"}},
+		Removed: []RemovedRule{
+			{Name: "azurerm_resource_group_empty", Version: "0.1.0", Replacement: "azurerm_resource_group_not_empty"},
+			{Name: "azurerm_legacy_check", Version: "0.1.0"},
+		},
+	}
+}
+
+func Test_RuleSet_RuleNames(t *testing.T) {
+	names := testRuleSet().RuleNames()
+	expected := []string{"azurerm_resource_group_not_empty", "azurerm_resource_group_unused", "azurerm_resource_group_empty", "azurerm_legacy_check"}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i, name := range expected {
+		if names[i] != name {
+			t.Fatalf("Expected %v, got %v", expected, names)
+		}
+	}
+}
+
+func Test_RuleSet_RenamedRule(t *testing.T) {
+	ruleset := testRuleSet()
+	err := ruleset.ApplyGlobalConfig(&tflint.Config{
+		Rules: map[string]*tflint.RuleConfig{
+			"azurerm_resource_group_unused": {Name: "azurerm_resource_group_unused", Enabled: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+	if len(ruleset.EnabledRules) != 1 || ruleset.EnabledRules[0].Name() != "azurerm_resource_group_unused" {
+		t.Fatalf("Expected the renamed rule to be enabled under its old name, got %v", ruleset.EnabledRules)
+	}
+
+	config := `
+rule "azurerm_resource_group_unused" {
+  enabled = true
+  enforce = false
+}`
+	runner := &configFileRunner{
+		Runner: helper.TestRunner(t, map[string]string{
+			"module.tf": `
+resource "azurerm_resource_group" "leftover" {
+  name = "leftover"
+}`,
+			".tflint.hcl": config,
+		}),
+		config: config,
+	}
+	if err := ruleset.Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := []struct {
+		name       string
+		severity   tflint.Severity
+		message    string
+		issueRange hcl.Range
+	}{
+		{
+			name:     "azurerm_resource_group_unused",
+			severity: tflint.NOTICE,
+			message:  "The `azurerm_resource_group_unused` rule has been renamed to `azurerm_resource_group_not_empty` and its old name is deprecated, rename the rule block in .tflint.hcl",
+			issueRange: hcl.Range{
+				Filename: ".tflint.hcl",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+				End:      hcl.Pos{Line: 2, Column: 37, Byte: 37},
+			},
+		},
+		{
+			name:     "azurerm_resource_group_unused",
+			severity: tflint.NOTICE,
+			message:  "Dry run (enforce = false), this rule would report: `azurerm_resource_group.leftover` is not referenced by the `resource_group_name` of any resource in the module, it may be a leftover from a refactor",
+			issueRange: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 2, Column: 1, Byte: 1},
+				End:      hcl.Pos{Line: 2, Column: 45, Byte: 45},
+			},
+		},
+	}
+	if len(runner.Issues) != len(expected) {
+		t.Fatalf("Expected %d issues, got %d", len(expected), len(runner.Issues))
+	}
+	for i, issue := range runner.Issues {
+		if issue.Rule.Name() != expected[i].name || issue.Rule.Severity() != expected[i].severity || issue.Message != expected[i].message || issue.Range != expected[i].issueRange {
+			t.Fatalf("Expected issue %d to be %+v, got %s %s %q %v", i, expected[i], issue.Rule.Name(), issue.Rule.Severity(), issue.Message, issue.Range)
+		}
+	}
+}
+
+func Test_RuleSet_RenamedRuleConfiguredTwice(t *testing.T) {
+	err := testRuleSet().ApplyGlobalConfig(&tflint.Config{
+		Rules: map[string]*tflint.RuleConfig{
+			"azurerm_resource_group_unused":    {Name: "azurerm_resource_group_unused", Enabled: true},
+			"azurerm_resource_group_not_empty": {Name: "azurerm_resource_group_not_empty", Enabled: true},
+		},
+	})
+	expected := "both `azurerm_resource_group_unused` and its new name `azurerm_resource_group_not_empty` are configured, remove the `azurerm_resource_group_unused` rule block"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error %q, got %v", expected, err)
+	}
+}
+
+func Test_RuleSet_RemovedRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Expected string
+	}{
+		{
+			Name:     "azurerm_resource_group_empty",
+			Expected: "the `azurerm_resource_group_empty` rule was removed in 0.1.0, use the `azurerm_resource_group_not_empty` rule instead",
+		},
+		{
+			Name:     "azurerm_legacy_check",
+			Expected: "the `azurerm_legacy_check` rule was removed in 0.1.0, remove its rule block from .tflint.hcl",
+		},
+	}
+
+	for _, tc := range cases {
+		err := testRuleSet().ApplyGlobalConfig(&tflint.Config{
+			Rules: map[string]*tflint.RuleConfig{tc.Name: {Name: tc.Name, Enabled: true}},
+		})
+		if err == nil || err.Error() != tc.Expected {
+			t.Fatalf("Expected error %q, got %v", tc.Expected, err)
+		}
+	}
+}
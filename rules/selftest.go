@@ -0,0 +1,254 @@
+package rules
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ecsd-matthew-song/tflint-ruleset-matt-custom/sku"
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// SelfTestEnv makes the plugin binary validate itself and exit instead of serving the ruleset
+const SelfTestEnv = "TFLINT_MATT_CUSTOM_SELF_TEST"
+
+// rulePrefixes are the prefixes rule names start with, by provider or "terraform_" for language style rules
+var rulePrefixes = []string{"azurerm_", "terraform_"}
+
+// selfTest collects the results of the self-test checks
+type selfTest struct {
+	out    io.Writer
+	failed bool
+}
+
+func (s *selfTest) pass(format string, args ...interface{}) {
+	fmt.Fprintf(s.out, "ok   %s\n", fmt.Sprintf(format, args...))
+}
+
+func (s *selfTest) fail(format string, args ...interface{}) {
+	s.failed = true
+	fmt.Fprintf(s.out, "FAIL %s\n", fmt.Sprintf(format, args...))
+}
+
+// SelfTest validates the rule registry, rule config schemas and data tables of the ruleset,
+// printing a report to out. It returns whether every check passed.
+func SelfTest(ruleset *RuleSet, out io.Writer) bool {
+	s := &selfTest{out: out}
+	s.checkRegistry(ruleset)
+	for _, rule := range ruleset.Rules {
+		s.checkRule(rule)
+	}
+	s.checkDataTables(ruleset)
+
+	if s.failed {
+		fmt.Fprintln(out, "Self-test failed")
+	} else {
+		fmt.Fprintf(out, "Self-test passed for %s %s\n", ruleset.RuleSetName(), ruleset.RuleSetVersion())
+	}
+	return !s.failed
+}
+
+// checkRegistry checks rule names are unique, prefixed, and consistent with the renamed and removed rules and the presets
+func (s *selfTest) checkRegistry(ruleset *RuleSet) {
+	active := map[string]bool{}
+	for _, rule := range ruleset.Rules {
+		active[rule.Name()] = true
+	}
+
+	failed := s.failed
+	seen := map[string]bool{}
+	for _, name := range ruleset.RuleNames() {
+		if seen[name] {
+			s.fail("registry: `%s` is registered more than once", name)
+		}
+		seen[name] = true
+		if !hasAnyPrefix(name, rulePrefixes) {
+			s.fail("registry: `%s` does not start with any of %s", name, quoteAll(rulePrefixes))
+		}
+	}
+	for _, renamed := range ruleset.Renamed {
+		if !active[renamed.NewName] {
+			s.fail("registry: `%s` is renamed to `%s`, which is not registered", renamed.OldName, renamed.NewName)
+		}
+	}
+	for _, removed := range ruleset.Removed {
+		if removed.Replacement != "" && !active[removed.Replacement] {
+			s.fail("registry: `%s` is replaced by `%s`, which is not registered", removed.Name, removed.Replacement)
+		}
+	}
+	for _, preset := range sortedPresetNames(ruleset.Presets) {
+		for _, name := range ruleset.Presets[preset] {
+			if !active[name] {
+				s.fail("registry: the %q preset enables `%s`, which is not registered", preset, name)
+			}
+		}
+	}
+
+	if s.failed == failed {
+		s.pass("registry: %d rules, %d renamed, %d removed", len(ruleset.Rules), len(ruleset.Renamed), len(ruleset.Removed))
+	}
+}
+
+// smokeCheck checks the rule against an empty module without a rule block, turning panics into errors.
+// Rules with required settings that are not set in the base config return an error.
+func smokeCheck(rule tflint.Rule) (runner *selfTestRunner, err error) {
+	runner = &selfTestRunner{}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return runner, rule.Check(runner)
+}
+
+// checkRule smoke-runs the rule against an empty module to validate its config schema
+func (s *selfTest) checkRule(rule tflint.Rule) {
+	runner, err := smokeCheck(rule)
+
+	switch {
+	case err != nil && strings.HasPrefix(err.Error(), "panic: "):
+		s.fail("%s: %s", rule.Name(), err)
+		return
+	case runner.schema == nil:
+		s.fail("%s: the rule does not decode its config", rule.Name())
+		return
+	case !hasAttribute(runner.schema, "enforce"):
+		s.fail("%s: the config has no `enforce` attribute", rule.Name())
+		return
+	case runner.issues > 0:
+		s.fail("%s: %d issues reported for an empty module", rule.Name(), runner.issues)
+		return
+	}
+
+	if err != nil {
+		// Rules with required settings cannot run without config, which is expected
+		s.pass("%s (needs config: %s)", rule.Name(), err)
+		return
+	}
+	s.pass("%s", rule.Name())
+}
+
+// checkDataTables checks the tables shipped with the rules
+func (s *selfTest) checkDataTables(ruleset *RuleSet) {
+	failed := s.failed
+
+	services := sku.Services()
+	sort.Strings(services)
+	for _, service := range services {
+		names := sku.Names(service)
+		if len(names) == 0 {
+			s.fail("sku catalog: `%s` has no SKUs", service)
+		}
+		if duplicate := firstDuplicate(names); duplicate != "" {
+			s.fail("sku catalog: `%s` lists %q more than once", service, duplicate)
+		}
+	}
+
+	if duplicate := firstDuplicate(Resources); duplicate != "" {
+		s.fail("taggable resources: %q is listed more than once", duplicate)
+	}
+	for _, resourceType := range Resources {
+		if resourcesWithoutTags[resourceType] {
+			s.fail("taggable resources: %q has no tags attribute", resourceType)
+		}
+	}
+
+	for _, flag := range sortedKeys(behaviorFlags) {
+		cmp, err := compareVersions(behaviorFlags[flag], ruleset.RuleSetVersion())
+		if err != nil {
+			s.fail("behavior flags: `%s`: %s", flag, err)
+		} else if cmp > 0 {
+			s.fail("behavior flags: `%s` is introduced in %s, after the plugin version %s", flag, behaviorFlags[flag], ruleset.RuleSetVersion())
+		}
+	}
+
+	if _, diags := hclsyntax.ParseConfig(embeddedBaseConfig, "base_config.hcl", hcl.InitialPos); diags.HasErrors() {
+		s.fail("base config: %s", diags.Error())
+	}
+
+	if s.failed == failed {
+		s.pass("data tables: %d SKU services, %d taggable resources, %d behavior flags", len(services), len(Resources), len(behaviorFlags))
+	}
+}
+
+// hasAttribute returns whether the schema declares the attribute
+func hasAttribute(schema *hclext.BodySchema, name string) bool {
+	for _, attribute := range schema.Attributes {
+		if attribute.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// firstDuplicate returns the first value listed more than once, or an empty string
+func firstDuplicate(values []string) string {
+	seen := map[string]bool{}
+	for _, value := range values {
+		if seen[value] {
+			return value
+		}
+		seen[value] = true
+	}
+	return ""
+}
+
+// hasAnyPrefix returns whether the value starts with any of the prefixes
+func hasAnyPrefix(value string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// selfTestRunner is a runner over an empty module that records the config schema of the rule
+type selfTestRunner struct {
+	schema *hclext.BodySchema
+	issues int
+}
+
+var _ tflint.Runner = &selfTestRunner{}
+
+func (r *selfTestRunner) GetResourceContent(string, *hclext.BodySchema, *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	return &hclext.BodyContent{Attributes: hclext.Attributes{}, Blocks: hclext.Blocks{}}, nil
+}
+
+func (r *selfTestRunner) GetModuleContent(*hclext.BodySchema, *tflint.GetModuleContentOption) (*hclext.BodyContent, error) {
+	return &hclext.BodyContent{Attributes: hclext.Attributes{}, Blocks: hclext.Blocks{}}, nil
+}
+
+func (r *selfTestRunner) GetFile(filename string) (*hcl.File, error) {
+	return nil, fmt.Errorf("file `%s` not found", filename)
+}
+
+func (r *selfTestRunner) GetFiles() (map[string]*hcl.File, error) {
+	return map[string]*hcl.File{}, nil
+}
+
+// DecodeRuleConfig records the schema implied by the config struct, which panics on invalid tags
+func (r *selfTestRunner) DecodeRuleConfig(name string, ret interface{}) error {
+	r.schema = hclext.ImpliedBodySchema(ret)
+	return nil
+}
+
+func (r *selfTestRunner) EvaluateExpr(hcl.Expression, interface{}, *tflint.EvaluateExprOption) error {
+	return fmt.Errorf("nothing to evaluate in an empty module")
+}
+
+func (r *selfTestRunner) EmitIssue(tflint.Rule, string, hcl.Range) error {
+	r.issues++
+	return nil
+}
+
+func (r *selfTestRunner) EnsureNoError(err error, proc func() error) error {
+	if err != nil {
+		return err
+	}
+	return proc()
+}
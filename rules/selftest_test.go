@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_SelfTest(t *testing.T) {
+	cases := []struct {
+		Name     string
+		RuleSet  *RuleSet
+		Passed   bool
+		Expected string
+	}{
+		{
+			Name: "Valid ruleset",
+			RuleSet: &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Name:    "matt-custom",
+					Version: "0.2.0",
+					Rules:   []tflint.Rule{NewAzurermResourceMissingTagsRule(), NewAzurermResourceGroupNotEmptyRule()},
+				},
+				Renamed: []RenamedRule{{OldName: "azurerm_resource_group_unused", NewName: "azurerm_resource_group_not_empty"}},
+			},
+			Passed:   true,
+			Expected: "ok   azurerm_resource_missing_tags (needs config: `tags` is not set for the `azurerm_resource_missing_tags` rule in .tflint.hcl or the base config)",
+		},
+		{
+			Name: "Duplicate rule",
+			RuleSet: &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Name:    "matt-custom",
+					Version: "0.2.0",
+					Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule(), NewAzurermResourceGroupNotEmptyRule()},
+				},
+			},
+			Expected: "FAIL registry: `azurerm_resource_group_not_empty` is registered more than once",
+		},
+		{
+			Name: "Renamed to an unknown rule",
+			RuleSet: &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Name:    "matt-custom",
+					Version: "0.2.0",
+					Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+				},
+				Renamed: []RenamedRule{{OldName: "azurerm_tags", NewName: "azurerm_resource_tags"}},
+			},
+			Expected: "FAIL registry: `azurerm_tags` is renamed to `azurerm_resource_tags`, which is not registered",
+		},
+		{
+			Name: "Preset of an unknown rule",
+			RuleSet: &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Name:    "matt-custom",
+					Version: "0.2.0",
+					Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+				},
+				Presets: map[string][]string{"governance": {"azurerm_resource_group_not_empty", "azurerm_resource_tags"}},
+			},
+			Expected: "FAIL registry: the \"governance\" preset enables `azurerm_resource_tags`, which is not registered",
+		},
+		{
+			Name: "Behavior flag newer than the plugin",
+			RuleSet: &RuleSet{
+				BuiltinRuleSet: tflint.BuiltinRuleSet{
+					Name:    "matt-custom",
+					Version: "0.1.0",
+					Rules:   []tflint.Rule{NewAzurermResourceGroupNotEmptyRule()},
+				},
+			},
+			Expected: "FAIL behavior flags: `override_files_merged` is introduced in 0.2.0, after the plugin version 0.1.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if passed := SelfTest(tc.RuleSet, out); passed != tc.Passed {
+				t.Fatalf("Expected the self-test to return %t, got %t:\n%s", tc.Passed, passed, out)
+			}
+			if !strings.Contains(out.String(), tc.Expected+"\n") {
+				t.Fatalf("Expected the report to contain %q:\n%s", tc.Expected, out)
+			}
+		})
+	}
+}
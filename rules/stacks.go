@@ -0,0 +1,112 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// stackFilePatterns match Terraform Stacks files, which TFLint does not load as module files
+var stackFilePatterns = []string{"*.tfstack.hcl", "*.tfdeploy.hcl"}
+
+// stackBlockTypes are the blocks of stack files that pass inputs to modules
+var stackBlockTypes = []string{"component", "deployment"}
+
+// stackFiles parses the Terraform Stacks files next to the module files.
+// Files that cannot be parsed are skipped with a warning, so that stack syntax newer than
+// this plugin does not break linting of the module itself.
+func stackFiles(runner tflint.Runner) ([]*hcl.File, error) {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return nil, err
+	}
+	// The module files share a directory, take it from the first name so the result does not depend on map order
+	dir := "."
+	if names := sortedFileNames(files); len(names) > 0 {
+		dir = filepath.Dir(names[0])
+	}
+
+	filenames := []string{}
+	for _, pattern := range stackFilePatterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		filenames = append(filenames, matches...)
+	}
+	sort.Strings(filenames)
+
+	parser := hclparse.NewParser()
+	stacks := []*hcl.File{}
+	for _, filename := range filenames {
+		file, diags := parser.ParseHCLFile(filename)
+		if diags.HasErrors() {
+			logger.Warn("Skipping stack file %s: %s", filename, diags.Error())
+			continue
+		}
+		stacks = append(stacks, file)
+	}
+	return stacks, nil
+}
+
+// checkStackComponents checks the tags passed to stack components and deployments, where declared
+func (r *AzurermResourceMissingTagsRule) checkStackComponents(runner tflint.Runner, required []string) error {
+	files, err := stackFiles(runner)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+			if !stringInSlice(block.Type, stackBlockTypes) || len(block.Labels) != 1 {
+				continue
+			}
+			attribute, exists := block.Body.Attributes["inputs"]
+			if !exists {
+				continue
+			}
+			inputs, diags := hcl.ExprMap(attribute.Expr)
+			if diags.HasErrors() {
+				continue
+			}
+
+			for _, input := range inputs {
+				if settingKey(input.Key) != tagsAttributeName {
+					continue
+				}
+				// Only literal tag maps can be checked, values are usually variables or references
+				pairs, diags := hcl.ExprMap(input.Value)
+				if diags.HasErrors() {
+					continue
+				}
+				present := []string{}
+				for _, pair := range pairs {
+					present = append(present, settingKey(pair.Key))
+				}
+
+				missing := []string{}
+				for _, tag := range required {
+					if !stringInSlice(tag, present) && !stringInSlice(tag, missing) {
+						missing = append(missing, tag)
+					}
+				}
+				if len(missing) == 0 {
+					continue
+				}
+				sort.Strings(missing)
+				runner.EmitIssue(
+					r,
+					fmt.Sprintf("The %s `%s` is missing the following tags in `inputs.tags`: %s.", block.Type, block.Labels[0], quoteAll(missing)),
+					input.Value.Range(),
+				)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/agext/levenshtein"
+)
+
+// suggestion returns the candidate closest to the value when it looks like a typo of it.
+// Comparison is case-insensitive, so values that only differ by case are always suggested.
+func suggestion(value string, candidates []string) string {
+	limit := 2
+	if l := len(value) / 3; l > limit {
+		limit = l
+	}
+
+	best := ""
+	bestDistance := limit + 1
+	for _, candidate := range candidates {
+		if candidate == value {
+			return ""
+		}
+		distance := levenshtein.Distance(strings.ToLower(value), strings.ToLower(candidate), nil)
+		if distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+// didYouMean returns a sentence proposing the nearest candidate, or an empty string if there is none
+func didYouMean(value string, candidates []string) string {
+	if s := suggestion(value, candidates); s != "" {
+		return fmt.Sprintf(" Did you mean \"%s\"?", s)
+	}
+	return ""
+}
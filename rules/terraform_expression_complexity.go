@@ -0,0 +1,146 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// defaultMaxExpressionDepth is the number of nested function calls and conditionals allowed by default
+const defaultMaxExpressionDepth = 4
+
+// TerraformExpressionComplexityRule checks that expressions do not nest too many function calls and conditionals
+type TerraformExpressionComplexityRule struct {
+	tflint.DefaultRule
+}
+
+type terraformExpressionComplexityRuleConfig struct {
+	// MaxDepth is the number of function calls and conditionals an expression may nest, 4 by default
+	MaxDepth int   `hclext:"max_depth,optional"`
+	Enforce  *bool `hclext:"enforce,optional"`
+}
+
+// NewTerraformExpressionComplexityRule returns new rule with default attributes
+func NewTerraformExpressionComplexityRule() *TerraformExpressionComplexityRule {
+	return &TerraformExpressionComplexityRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformExpressionComplexityRule) Name() string {
+	return "terraform_expression_complexity"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformExpressionComplexityRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformExpressionComplexityRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformExpressionComplexityRule) Link() string {
+	return ""
+}
+
+// Check checks the attributes of every block in the module for expressions nesting function calls and conditionals
+// deeper than the configured depth, which are hard to review and better split into local values
+func (r *TerraformExpressionComplexityRule) Check(runner tflint.Runner) error {
+	config := terraformExpressionComplexityRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.MaxDepth == 0 {
+		config.MaxDepth = defaultMaxExpressionDepth
+	}
+	if config.MaxDepth < 0 {
+		return fmt.Errorf("max_depth: %d is out of range, expected a positive depth", config.MaxDepth)
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range sortedFileNames(files) {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, attribute := range bodyAttributes(body) {
+			depth := expressionDepth(attribute.Expr)
+			if depth <= config.MaxDepth {
+				continue
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("The `%s` expression nests %d function calls and conditionals, more than the `max_depth` of %d. Consider moving parts of it into local values.", attribute.Name, depth, config.MaxDepth),
+				attribute.Expr.Range(),
+			); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// bodyAttributes returns the attributes of the body and its nested blocks in source order
+func bodyAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attributes := []*hclsyntax.Attribute{}
+	for _, attribute := range body.Attributes {
+		attributes = append(attributes, attribute)
+	}
+	for _, block := range body.Blocks {
+		attributes = append(attributes, bodyAttributes(block.Body)...)
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].SrcRange.Start.Byte < attributes[j].SrcRange.Start.Byte
+	})
+	return attributes
+}
+
+// expressionDepth returns the deepest nesting of function calls and conditionals in the expression
+func expressionDepth(expr hclsyntax.Expression) int {
+	walker := &depthWalker{}
+	hclsyntax.Walk(expr, walker)
+	return walker.max
+}
+
+// depthWalker tracks the nesting of function calls and conditionals while walking an expression
+type depthWalker struct {
+	depth int
+	max   int
+}
+
+func (w *depthWalker) Enter(node hclsyntax.Node) hcl.Diagnostics {
+	if nests(node) {
+		w.depth++
+		if w.depth > w.max {
+			w.max = w.depth
+		}
+	}
+	return nil
+}
+
+func (w *depthWalker) Exit(node hclsyntax.Node) hcl.Diagnostics {
+	if nests(node) {
+		w.depth--
+	}
+	return nil
+}
+
+// nests returns whether the node adds a level of nesting
+func nests(node hclsyntax.Node) bool {
+	switch node.(type) {
+	case *hclsyntax.FunctionCallExpr, *hclsyntax.ConditionalExpr:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformExpressionComplexity(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Nested function calls and conditionals",
+			Content: `
+locals {
+  name = lower(replace(var.prefix != "" ? format("%s-%s", var.prefix, var.name) : var.name, "_", "-"))
+  tags = merge(var.tags, { Name = local.name })
+}
+
+resource "azurerm_storage_account" "main" {
+  name = substr(lower(replace(join("", [var.prefix, var.name]), "-", "")), 0, 24)
+
+  network_rules {
+    ip_rules = var.public ? [] : compact(flatten([for rule in var.rules : split(",", trimspace(rule))]))
+  }
+}`,
+			Config: `
+rule "terraform_expression_complexity" {
+  enabled   = true
+  max_depth = 3
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformExpressionComplexityRule(),
+					Message: "The `name` expression nests 4 function calls and conditionals, more than the `max_depth` of 3. Consider moving parts of it into local values.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 103},
+					},
+				},
+				{
+					Rule:    NewTerraformExpressionComplexityRule(),
+					Message: "The `name` expression nests 4 function calls and conditionals, more than the `max_depth` of 3. Consider moving parts of it into local values.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 10},
+						End:      hcl.Pos{Line: 8, Column: 82},
+					},
+				},
+				{
+					Rule:    NewTerraformExpressionComplexityRule(),
+					Message: "The `ip_rules` expression nests 5 function calls and conditionals, more than the `max_depth` of 3. Consider moving parts of it into local values.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 11, Column: 16},
+						End:      hcl.Pos{Line: 11, Column: 105},
+					},
+				},
+			},
+		},
+		{
+			Name: "Default depth",
+			Content: `
+locals {
+  name = lower(replace(var.prefix != "" ? format("%s-%s", var.prefix, var.name) : var.name, "_", "-"))
+}`,
+			Config: `
+rule "terraform_expression_complexity" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformExpressionComplexityRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
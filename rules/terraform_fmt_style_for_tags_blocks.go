@@ -0,0 +1,264 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/logger"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+// TerraformFmtStyleForTagsBlocksRule checks that literal tags maps are written in a canonical style
+type TerraformFmtStyleForTagsBlocksRule struct {
+	tflint.DefaultRule
+
+	quotedKeysPolicies []string
+}
+
+type terraformFmtStyleForTagsBlocksRuleConfig struct {
+	SortKeys    *bool  `hclext:"sort_keys,optional"`
+	AlignEquals *bool  `hclext:"align_equals,optional"`
+	QuotedKeys  string `hclext:"quoted_keys,optional"`
+	Enforce     *bool  `hclext:"enforce,optional"`
+}
+
+// tagsMapItem is a key-value pair of a literal tags map
+type tagsMapItem struct {
+	key    string
+	quoted bool
+	src    string
+	value  string
+	sep    string
+}
+
+// NewTerraformFmtStyleForTagsBlocksRule returns new rule with default attributes
+func NewTerraformFmtStyleForTagsBlocksRule() *TerraformFmtStyleForTagsBlocksRule {
+	return &TerraformFmtStyleForTagsBlocksRule{
+		// "as_needed" only quotes keys that are not valid identifiers, "always" quotes every key
+		// and "preserve" keeps the quoting as written
+		quotedKeysPolicies: []string{"as_needed", "always", "preserve"},
+	}
+}
+
+// Name returns the rule name
+func (r *TerraformFmtStyleForTagsBlocksRule) Name() string {
+	return "terraform_fmt_style_for_tags_blocks"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformFmtStyleForTagsBlocksRule) Enabled() bool {
+	return false
+}
+
+// Severity returns the rule severity
+func (r *TerraformFmtStyleForTagsBlocksRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformFmtStyleForTagsBlocksRule) Link() string {
+	return ""
+}
+
+// Check checks literal tags maps of resources, data sources and module calls, and tags maps in locals,
+// have sorted keys, aligned equals signs and consistently quoted keys
+func (r *TerraformFmtStyleForTagsBlocksRule) Check(runner tflint.Runner) error {
+	config := terraformFmtStyleForTagsBlocksRuleConfig{}
+	if err := decodeRuleConfig(runner, r.Name(), &config); err != nil {
+		return err
+	}
+	if config.QuotedKeys == "" {
+		config.QuotedKeys = r.quotedKeysPolicies[0]
+	}
+	if !stringInSlice(config.QuotedKeys, r.quotedKeysPolicies) {
+		return fmt.Errorf("quoted_keys: \"%s\" is not a valid policy, expected one of %s", config.QuotedKeys, quoteAll(r.quotedKeysPolicies))
+	}
+	runner = withEnforcement(runner, config.Enforce)
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "resource", "data", "module":
+				if attribute, exists := block.Body.Attributes[tagsAttributeName]; exists {
+					r.checkTagsMap(runner, files[name], attribute, config)
+				}
+			case "locals":
+				for localName, attribute := range block.Body.Attributes {
+					if strings.HasSuffix(localName, tagsAttributeName) {
+						r.checkTagsMap(runner, files[name], attribute, config)
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkTagsMap reports a literal tags map that differs from its canonical style, with the canonical map as the fix
+func (r *TerraformFmtStyleForTagsBlocksRule) checkTagsMap(runner tflint.Runner, file *hcl.File, attribute *hclsyntax.Attribute, config terraformFmtStyleForTagsBlocksRuleConfig) {
+	object, ok := attribute.Expr.(*hclsyntax.ObjectConsExpr)
+	if !ok || len(object.Items) == 0 {
+		return
+	}
+	exprRange := object.Range()
+	src := string(exprRange.SliceBytes(file.Bytes))
+	// Comments cannot be kept in place when items are reordered, so such maps are left alone
+	tokens, _ := hclsyntax.LexConfig([]byte(src), exprRange.Filename, exprRange.Start)
+	for _, token := range tokens {
+		if token.Type == hclsyntax.TokenComment {
+			logger.Debug("Skip tags map with comments at %s", exprRange)
+			return
+		}
+	}
+
+	items := make([]tagsMapItem, len(object.Items))
+	for i, pair := range object.Items {
+		key := settingKey(pair.KeyExpr)
+		if key == "" {
+			return
+		}
+		items[i] = tagsMapItem{
+			key:    key,
+			quoted: hcl.ExprAsKeyword(pair.KeyExpr) == "",
+			src:    string(pair.KeyExpr.Range().SliceBytes(file.Bytes)),
+			value:  string(pair.ValueExpr.Range().SliceBytes(file.Bytes)),
+			sep:    string(file.Bytes[pair.KeyExpr.Range().End.Byte:pair.ValueExpr.Range().Start.Byte]),
+		}
+	}
+	multiline := exprRange.Start.Line != exprRange.End.Line
+
+	problems := []string{}
+	if config.SortKeys == nil || *config.SortKeys {
+		if !sort.SliceIsSorted(items, func(i, j int) bool { return tagsKeyLess(items[i].key, items[j].key) }) {
+			problems = append(problems, "keys are not sorted")
+		}
+	}
+	for _, item := range items {
+		if r.keySource(item, config.QuotedKeys) != item.src {
+			problems = append(problems, fmt.Sprintf("keys are not quoted as required by `quoted_keys = \"%s\"`", config.QuotedKeys))
+			break
+		}
+	}
+	align := multiline && (config.AlignEquals == nil || *config.AlignEquals)
+	width := 0
+	for _, item := range items {
+		if align && len(item.src) > width {
+			width = len(item.src)
+		}
+	}
+	for _, item := range items {
+		if item.sep != equalsSeparator(item.src, width) {
+			if align {
+				problems = append(problems, "equals signs are not aligned")
+			} else {
+				problems = append(problems, "equals signs are not surrounded by single spaces")
+			}
+			break
+		}
+	}
+
+	canonical := r.render(file, exprRange, items, multiline, config)
+	if canonical == src {
+		return
+	}
+	if len(problems) == 0 {
+		problems = append(problems, "the layout differs from the canonical style")
+	}
+	emitIssueWithFix(
+		runner,
+		r,
+		fmt.Sprintf("The `%s` map is not in the canonical style: %s.", attribute.Name, strings.Join(problems, ", ")),
+		exprRange,
+		&issueFix{Range: exprRange, Replacement: canonical},
+	)
+}
+
+// render returns the tags map in its canonical style, with one item per line for maps spanning several lines
+func (r *TerraformFmtStyleForTagsBlocksRule) render(file *hcl.File, exprRange hcl.Range, items []tagsMapItem, multiline bool, config terraformFmtStyleForTagsBlocksRuleConfig) string {
+	sorted := append([]tagsMapItem{}, items...)
+	if config.SortKeys == nil || *config.SortKeys {
+		sort.SliceStable(sorted, func(i, j int) bool { return tagsKeyLess(sorted[i].key, sorted[j].key) })
+	}
+	keys := make([]string, len(sorted))
+	width := 0
+	for i, item := range sorted {
+		keys[i] = r.keySource(item, config.QuotedKeys)
+		if len(keys[i]) > width {
+			width = len(keys[i])
+		}
+	}
+	if !multiline || (config.AlignEquals != nil && !*config.AlignEquals) {
+		width = 0
+	}
+
+	lines := make([]string, len(sorted))
+	for i, item := range sorted {
+		lines[i] = keys[i] + equalsSeparator(keys[i], width) + item.value
+	}
+	if !multiline {
+		return fmt.Sprintf("{ %s }", strings.Join(lines, ", "))
+	}
+
+	// Items are indented one level deeper than the line the map starts on
+	indent := lineIndent(file.Bytes, exprRange.Start)
+	eol := lineEnding(exprRange.SliceBytes(file.Bytes))
+
+	var b strings.Builder
+	b.WriteString("{" + eol)
+	for _, line := range lines {
+		b.WriteString(indent + "  " + line + eol)
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+// equalsSeparator returns the text between a key and its value, padding the key to the width to align equals signs
+func equalsSeparator(key string, width int) string {
+	pad := ""
+	if width > len(key) {
+		pad = strings.Repeat(" ", width-len(key))
+	}
+	return pad + " = "
+}
+
+// keySource returns the source of the key as required by the quoted keys policy
+func (r *TerraformFmtStyleForTagsBlocksRule) keySource(item tagsMapItem, policy string) string {
+	quoted := item.quoted
+	switch policy {
+	case "as_needed":
+		quoted = false
+	case "always":
+		quoted = true
+	}
+	if quoted || !hclsyntax.ValidIdentifier(item.key) {
+		return strconv.Quote(item.key)
+	}
+	return item.key
+}
+
+// tagsKeyLess orders tag keys case-insensitively, so that "CostCenter" sorts next to "costcenter"
+func tagsKeyLess(a, b string) bool {
+	if strings.ToLower(a) == strings.ToLower(b) {
+		return a < b
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
@@ -0,0 +1,175 @@
+package rules
+
+import (
+	"testing"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformFmtStyleForTagsBlocks(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "Canonical style",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    CostCenter   = "1234"
+    Environment  = "Prod"
+    "team/owner" = "platform"
+  }
+}
+
+module "network" {
+  tags = { Environment = "Prod", Owner = "platform" }
+}`,
+			Config: `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "Unsorted and unaligned",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform"
+    "Environment" = "Prod"
+  }
+}`,
+			Config: `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFmtStyleForTagsBlocksRule(),
+					Message: "The `tags` map is not in the canonical style: keys are not sorted, keys are not quoted as required by `quoted_keys = \"as_needed\"`, equals signs are not aligned.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 6, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "Always quoted without alignment",
+			Content: `
+locals {
+  common_tags = {
+    "Environment" = "Prod"
+    Owner = "platform"
+  }
+}`,
+			Config: `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled      = true
+  align_equals = false
+  quoted_keys  = "always"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFmtStyleForTagsBlocksRule(),
+					Message: "The `common_tags` map is not in the canonical style: keys are not quoted as required by `quoted_keys = \"always\"`.",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 4},
+					},
+				},
+			},
+		},
+		{
+			Name: "Comments are left alone",
+			Content: `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform" # team alias
+    Environment = "Prod"
+  }
+}`,
+			Config: `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled = true
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformFmtStyleForTagsBlocksRule()
+
+	for _, tc := range cases {
+		runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+		if err := rule.Check(runner); err != nil {
+			t.Fatalf("Unexpected error occurred: %s", err)
+		}
+
+		helper.AssertIssues(t, tc.Expected, runner.Issues)
+	}
+}
+
+func Test_TerraformFmtStyleForTagsBlocks_SuggestedFix(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": `
+resource "azurerm_resource_group" "main" {
+  tags = {
+    Owner = "platform"
+    "Environment" = "Prod"
+  }
+}`,
+		".tflint.hcl": `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled = true
+}`,
+	})}
+
+	if err := NewTerraformFmtStyleForTagsBlocksRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformFmtStyleForTagsBlocksRule(),
+			Message: "The `tags` map is not in the canonical style: keys are not sorted, keys are not quoted as required by `quoted_keys = \"as_needed\"`, equals signs are not aligned. Suggested fix: replace 3:10-6:4 with \"{\\n    Environment = \\\"Prod\\\"\\n    Owner       = \\\"platform\\\"\\n  }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 3, Column: 10},
+				End:      hcl.Pos{Line: 6, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
+
+func Test_TerraformFmtStyleForTagsBlocks_LineEndings(t *testing.T) {
+	runner := &fixTestRunner{Runner: helper.TestRunner(t, map[string]string{
+		"module.tf": "\ufefflocals {\r\n  common_tags = {\r\n    Owner       = \"platform\"\r\n    Environment = \"Prod\"\r\n  }\r\n}\r\n\r\nmodule \"network\" {\r\n  tags = {\r\n    Environment = \"Prod\"\r\n    Owner       = \"platform\"\r\n  }\r\n}\r\n",
+		".tflint.hcl": `
+rule "terraform_fmt_style_for_tags_blocks" {
+  enabled = true
+}`,
+	})}
+
+	if err := NewTerraformFmtStyleForTagsBlocksRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformFmtStyleForTagsBlocksRule(),
+			Message: "The `common_tags` map is not in the canonical style: keys are not sorted. Suggested fix: replace 2:17-5:4 with \"{\\r\\n    Environment = \\\"Prod\\\"\\r\\n    Owner       = \\\"platform\\\"\\r\\n  }\"",
+			Range: hcl.Range{
+				Filename: "module.tf",
+				Start:    hcl.Pos{Line: 2, Column: 17},
+				End:      hcl.Pos{Line: 5, Column: 4},
+			},
+		},
+	}, runner.Issues)
+}
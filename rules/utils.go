@@ -1,14 +1,237 @@
 package rules
 
-// Used for the Storage Account
-var validAccountTier = []string{
-	"Standard",
-	"Premium",
-}
-
-// Used for checking tags
-// It may be possible to generate the resources list dynamically by further investigating the code here: https://github.com/terraform-linters/tflint-ruleset-aws/tree/master/rules/tags
-var Resources = []string{
-	"azurerm_resource_group",
-	"azurerm_key_vault",
-}
\ No newline at end of file
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var (
+	taggableResourcesOnce sync.Once
+	taggableResourceTypes []string
+)
+
+// taggableResources returns the types in Resources that have a `tags` attribute, filtered once on first use
+func taggableResources() []string {
+	taggableResourcesOnce.Do(func() {
+		for _, resourceType := range Resources {
+			if !resourcesWithoutTags[resourceType] {
+				taggableResourceTypes = append(taggableResourceTypes, resourceType)
+			}
+		}
+	})
+	return taggableResourceTypes
+}
+
+// discoveredResources returns the taggable resources followed by the azurerm resource types declared in the module
+// that are missing from the generated resource list, since they were added to the provider after it was generated.
+// Without a schema, a type is only known to support tags when a resource of that type sets `tags`, so types whose
+// resources set no tags are not returned, as they may not have a `tags` attribute at all.
+func discoveredResources(declared map[string]hclext.Blocks) []string {
+	known := map[string]bool{}
+	for _, resourceType := range Resources {
+		known[resourceType] = true
+	}
+
+	discovered := []string{}
+	for resourceType, resources := range declared {
+		if !strings.HasPrefix(resourceType, "azurerm_") || known[resourceType] || resourcesWithoutTags[resourceType] {
+			continue
+		}
+		for _, resource := range resources {
+			if _, exists := resource.Body.Attributes[tagsAttributeName]; exists {
+				discovered = append(discovered, resourceType)
+				break
+			}
+		}
+	}
+	sort.Strings(discovered)
+	return append(append([]string{}, taggableResources()...), discovered...)
+}
+
+// declaredResourceTypes returns the resource types declared in the module with a single content call,
+// so that rules checking many types only request the content of the declared ones
+func declaredResourceTypes(runner tflint.Runner) (map[string]bool, error) {
+	resources, err := resourcesByType(runner, &hclext.BodySchema{})
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool, len(resources))
+	for resourceType := range resources {
+		declared[resourceType] = true
+	}
+	return declared, nil
+}
+
+// resourcesByType returns the resources declared in the module, decoded with the schema and grouped by type,
+// with a single content call instead of one GetResourceContent call per type
+func resourcesByType(runner tflint.Runner, schema *hclext.BodySchema) (map[string]hclext.Blocks, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: schema},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := map[string]hclext.Blocks{}
+	for _, resource := range content.Blocks {
+		resources[resource.Labels[0]] = append(resources[resource.Labels[0]], resource)
+	}
+	return resources, nil
+}
+
+// evaluateBool evaluates the expression as a bool
+func evaluateBool(runner tflint.Runner, expr hcl.Expression, ret *bool) error {
+	wantType := cty.Bool
+	return runner.EvaluateExpr(expr, ret, &tflint.EvaluateExprOption{WantType: &wantType})
+}
+
+// iso8601DurationDays converts an ISO 8601 duration such as "P90D" or "P1Y6M" to an approximate number of days.
+// Years count as 365 days and months as 30 days; the time part (e.g. "T12H") is ignored.
+func iso8601DurationDays(duration string) (int, error) {
+	if !strings.HasPrefix(duration, "P") || len(duration) < 3 {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", duration)
+	}
+	if i := strings.Index(duration, "T"); i >= 0 {
+		duration = duration[:i]
+	}
+
+	days := 0
+	number := ""
+	for _, c := range duration[1:] {
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", duration)
+		}
+		switch c {
+		case 'Y':
+			days += n * 365
+		case 'M':
+			days += n * 30
+		case 'W':
+			days += n * 7
+		case 'D':
+			days += n
+		default:
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", duration)
+		}
+		number = ""
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", duration)
+	}
+	return days, nil
+}
+
+// evaluateOptionalBool evaluates the bool attribute of the body and passes it to proc,
+// passing the default value when the attribute is not set. proc is not called for unknown values.
+func evaluateOptionalBool(runner tflint.Runner, body *hclext.BodyContent, name string, defaultValue bool, proc func(bool) error) error {
+	attribute, exists := body.Attributes[name]
+	if !exists {
+		return proc(defaultValue)
+	}
+
+	var val bool
+	err := evaluateBool(runner, attribute.Expr, &val)
+	return runner.EnsureNoError(err, func() error {
+		return proc(val)
+	})
+}
+
+// sortedKeys returns the keys of the map in sorted order, so that checks iterate deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getDataSourceContent retrieves the content of data sources of the given type, like runner.GetResourceContent for resources
+func getDataSourceContent(runner tflint.Runner, dataSourceType string, schema *hclext.BodySchema) (hclext.Blocks, error) {
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "data", LabelNames: []string{"type", "name"}, Body: schema},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := hclext.Blocks{}
+	for _, block := range content.Blocks {
+		if block.Labels[0] == dataSourceType {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks, nil
+}
+
+// quoteAll returns the values as a comma separated list of quoted strings
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("\"%s\"", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// fileBlocks returns the top-level blocks of the type declared in the file, in native or JSON syntax
+func fileBlocks(file *hcl.File, blockType string, labelNames ...string) hcl.Blocks {
+	content, _, _ := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: blockType, LabelNames: labelNames}},
+	})
+	if content == nil {
+		return nil
+	}
+	return content.Blocks
+}
+
+// blockAttributes returns the attributes of a body with arbitrary attribute names, in native or JSON syntax.
+// Nested blocks are omitted in native syntax and are returned as object attributes in JSON syntax.
+func blockAttributes(body hcl.Body) hcl.Attributes {
+	attributes, _ := body.JustAttributes()
+	return attributes
+}
+
+// sortedFileNames returns the names of the files in sorted order, so that checks iterate deterministically
+func sortedFileNames(files map[string]*hcl.File) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lineIndent returns the leading whitespace of the line containing the position.
+// The line start is found in the bytes, since columns count characters and a UTF-8 BOM is not counted.
+func lineIndent(src []byte, pos hcl.Pos) string {
+	lineStart := bytes.LastIndexByte(src[:pos.Byte], '\n') + 1
+	line := strings.TrimPrefix(string(src[lineStart:pos.Byte]), "\ufeff")
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// lineEnding returns the line ending used in the source, so that fixes keep CRLF line endings of Windows checkouts
+func lineEnding(src []byte) string {
+	if bytes.Contains(src, []byte("\r\n")) {
+		return "\r\n"
+	}
+	return "\n"
+}
@@ -0,0 +1,77 @@
+// Package sku is a catalog of Azure SKU names per service, loaded from skus.json.
+// Update the data file when Azure introduces new SKUs; the rules validating SKUs pick them up automatically.
+package sku
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+// Services that have a SKU catalog
+const (
+	StorageAccountTier       = "storage_account_tier"
+	StorageReplicationType   = "storage_replication_type"
+	AppServicePlan           = "app_service_plan"
+	IoTHub                   = "iothub"
+	NotificationHubNamespace = "notification_hub_namespace"
+	EventHubNamespace        = "eventhub_namespace"
+	ServiceBusNamespace      = "servicebus_namespace"
+)
+
+// Tiered services list their SKUs from the lowest to the highest tier
+var tiered = map[string]bool{
+	IoTHub:                   true,
+	NotificationHubNamespace: true,
+	EventHubNamespace:        true,
+	ServiceBusNamespace:      true,
+}
+
+//go:embed skus.json
+var data []byte
+
+var catalog map[string][]string
+
+func init() {
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		panic(fmt.Sprintf("failed to load SKU catalog: %s", err))
+	}
+}
+
+// Services returns the names of all services in the catalog
+func Services() []string {
+	services := make([]string, 0, len(catalog))
+	for service := range catalog {
+		services = append(services, service)
+	}
+	return services
+}
+
+// Names returns the valid SKU names of the service
+func Names(service string) []string {
+	return catalog[service]
+}
+
+// Valid returns whether the name is a known SKU of the service
+func Valid(service string, name string) bool {
+	for _, sku := range catalog[service] {
+		if sku == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank returns the position of the SKU within the tiers of the service, lowest first,
+// or -1 if the service is not tiered or the name is unknown
+func Rank(service string, name string) int {
+	if !tiered[service] {
+		return -1
+	}
+	for i, sku := range catalog[service] {
+		if sku == name {
+			return i
+		}
+	}
+	return -1
+}
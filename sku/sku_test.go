@@ -0,0 +1,44 @@
+package sku
+
+import "testing"
+
+func Test_Catalog(t *testing.T) {
+	for _, service := range []string{StorageAccountTier, StorageReplicationType, AppServicePlan, IoTHub, NotificationHubNamespace, EventHubNamespace, ServiceBusNamespace} {
+		names := Names(service)
+		if len(names) == 0 {
+			t.Fatalf("Expected SKUs for `%s`, got none", service)
+		}
+
+		seen := map[string]bool{}
+		for _, name := range names {
+			if seen[name] {
+				t.Fatalf("Duplicate SKU `%s` for `%s`", name, service)
+			}
+			seen[name] = true
+		}
+	}
+}
+
+func Test_Valid(t *testing.T) {
+	if !Valid(StorageAccountTier, "Standard") {
+		t.Fatal("Expected `Standard` to be a valid storage account tier")
+	}
+	if Valid(StorageAccountTier, "standard") {
+		t.Fatal("Expected `standard` to be an invalid storage account tier")
+	}
+	if Valid("unknown_service", "Standard") {
+		t.Fatal("Expected SKUs of an unknown service to be invalid")
+	}
+}
+
+func Test_Rank(t *testing.T) {
+	if Rank(IoTHub, "B3") >= Rank(IoTHub, "S1") {
+		t.Fatal("Expected `B3` to rank below `S1`")
+	}
+	if Rank(ServiceBusNamespace, "premium") != -1 {
+		t.Fatal("Expected an unknown SKU to have no rank")
+	}
+	if Rank(AppServicePlan, "S1") != -1 {
+		t.Fatal("Expected SKUs of a service without tiers to have no rank")
+	}
+}
@@ -0,0 +1,202 @@
+// Command generate-resources regenerates rules/resources.go, the azurerm resource types with and without tags,
+// from the azurerm provider schema.
+//
+// The schema is read from the file given with -schema, or from `terraform providers schema -json` run in a
+// temporary configuration pinning the azurerm provider to -provider-version, which needs terraform and network
+// access to install the provider. The provider version is recorded in the snapshot and the generated file, so
+// -provider-version must match the version a schema given with -schema was read from.
+// Set -snapshot to also write the trimmed schema that the list is tested against:
+//
+//	go run ./tools/generate-resources -snapshot tools/generate-resources/testdata/schema.json
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// providerAddress is the source address of the azurerm provider in the schema
+	providerAddress = "registry.terraform.io/hashicorp/azurerm"
+	// providerVersion is the azurerm provider version the resource list is generated from by default
+	providerVersion = "3.116.0"
+)
+
+// providerSchemas is the subset of the `terraform providers schema -json` output read by the generator.
+// ProviderVersion is not part of the output, it is recorded in the snapshot by the generator.
+type providerSchemas struct {
+	FormatVersion   string                    `json:"format_version"`
+	ProviderVersion string                    `json:"provider_version,omitempty"`
+	ProviderSchemas map[string]providerSchema `json:"provider_schemas"`
+}
+
+type providerSchema struct {
+	ResourceSchemas map[string]resourceSchema `json:"resource_schemas"`
+}
+
+type resourceSchema struct {
+	Block struct {
+		Attributes map[string]json.RawMessage `json:"attributes"`
+	} `json:"block"`
+}
+
+func main() {
+	schemaPath := flag.String("schema", "", "provider schema JSON file, instead of running `terraform providers schema -json`")
+	snapshotPath := flag.String("snapshot", "", "file to write the trimmed provider schema to")
+	outputPath := flag.String("output", "rules/resources.go", "Go source file to generate")
+	version := flag.String("provider-version", providerVersion, "azurerm provider version the schema is read from")
+	flag.Parse()
+
+	var data []byte
+	var err error
+	if *schemaPath != "" {
+		data, err = os.ReadFile(*schemaPath)
+	} else {
+		data, err = readProviderSchema(*version)
+	}
+	if err != nil {
+		log.Fatalf("failed to read the provider schema: %s", err)
+	}
+
+	schemas := providerSchemas{}
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		log.Fatalf("failed to parse the provider schema: %s", err)
+	}
+	schemas.ProviderVersion = *version
+	resourceTypes, untaggable, err := taggableResources(schemas)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *snapshotPath != "" {
+		snapshot, err := trimSchema(schemas)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*snapshotPath, snapshot, 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	source, err := generate(schemas.ProviderVersion, resourceTypes, untaggable)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*outputPath, source, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readProviderSchema returns the output of `terraform providers schema -json` for the azurerm provider
+// of the given version, installed into a temporary configuration
+func readProviderSchema(version string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "generate-resources")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	config := fmt.Sprintf(`terraform {
+  required_providers {
+    azurerm = {
+      source  = "hashicorp/azurerm"
+      version = "= %s"
+    }
+  }
+}
+`, version)
+	if err := os.WriteFile(filepath.Join(dir, "main.tf"), []byte(config), 0o644); err != nil {
+		return nil, err
+	}
+
+	install := exec.Command("terraform", "init", "-backend=false", "-input=false")
+	install.Dir = dir
+	if output, err := install.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("terraform init: %s\n%s", err, output)
+	}
+	schema := exec.Command("terraform", "providers", "schema", "-json")
+	schema.Dir = dir
+	return schema.Output()
+}
+
+// taggableResources returns the sorted resource types of the azurerm provider that have a `tags` attribute,
+// and those that do not
+func taggableResources(schemas providerSchemas) ([]string, []string, error) {
+	provider, exists := schemas.ProviderSchemas[providerAddress]
+	if !exists {
+		return nil, nil, fmt.Errorf("the provider schema has no `%s` provider", providerAddress)
+	}
+
+	taggable := []string{}
+	untaggable := []string{}
+	for resourceType, schema := range provider.ResourceSchemas {
+		if _, exists := schema.Block.Attributes["tags"]; exists {
+			taggable = append(taggable, resourceType)
+		} else {
+			untaggable = append(untaggable, resourceType)
+		}
+	}
+	sort.Strings(taggable)
+	sort.Strings(untaggable)
+	return taggable, untaggable, nil
+}
+
+// trimSchema returns the schema of the azurerm provider without the attributes other than `tags`,
+// which keeps the snapshot small while it still lists every resource type
+func trimSchema(schemas providerSchemas) ([]byte, error) {
+	provider := schemas.ProviderSchemas[providerAddress]
+	trimmed := providerSchema{ResourceSchemas: make(map[string]resourceSchema, len(provider.ResourceSchemas))}
+	for resourceType, schema := range provider.ResourceSchemas {
+		resource := resourceSchema{}
+		resource.Block.Attributes = map[string]json.RawMessage{}
+		if tags, exists := schema.Block.Attributes["tags"]; exists {
+			resource.Block.Attributes["tags"] = tags
+		}
+		trimmed.ResourceSchemas[resourceType] = resource
+	}
+
+	snapshot, err := json.MarshalIndent(providerSchemas{
+		FormatVersion:   schemas.FormatVersion,
+		ProviderVersion: schemas.ProviderVersion,
+		ProviderSchemas: map[string]providerSchema{providerAddress: trimmed},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(snapshot, '\n'), nil
+}
+
+// generate returns the formatted source of rules/resources.go listing the resource types
+// of the given provider version
+func generate(version string, resourceTypes []string, untaggable []string) ([]byte, error) {
+	source := "an unversioned schema snapshot"
+	if version != "" {
+		source = fmt.Sprintf("the hashicorp/azurerm v%s schema", version)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by tools/generate-resources from %s; DO NOT EDIT.\n\n", source)
+	buf.WriteString("package rules\n\n")
+	buf.WriteString("// Resources are the azurerm resource types with a `tags` attribute, which are checked for tags\n")
+	buf.WriteString("var Resources = []string{\n")
+	for _, resourceType := range resourceTypes {
+		fmt.Fprintf(&buf, "\t%q,\n", resourceType)
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("// resourcesWithoutTags are the azurerm resource types whose provider schema has no `tags` attribute.\n")
+	buf.WriteString("// Plugins cannot load the provider schema, so this table stands in for it when resource types are discovered.\n")
+	buf.WriteString("var resourcesWithoutTags = map[string]bool{\n")
+	for _, resourceType := range untaggable {
+		fmt.Fprintf(&buf, "\t%q: true,\n", resourceType)
+	}
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ResourcesMatchSnapshot(t *testing.T) {
+	data, err := os.ReadFile("testdata/schema.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas := providerSchemas{}
+	if err := json.Unmarshal(data, &schemas); err != nil {
+		t.Fatal(err)
+	}
+	resourceTypes, untaggable, err := taggableResources(schemas)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := generate(schemas.ProviderVersion, resourceTypes, untaggable)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("../../rules/resources.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(string(expected), string(got)); diff != "" {
+		t.Fatalf("rules/resources.go does not match the schema snapshot, run tools/generate-resources:\n%s", diff)
+	}
+}
+
+func Test_GenerateRecordsProviderVersion(t *testing.T) {
+	source, err := generate("3.116.0", []string{"azurerm_resource_group"}, []string{"azurerm_subnet"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "// Code generated by tools/generate-resources from the hashicorp/azurerm v3.116.0 schema; DO NOT EDIT.\n"
+	if !strings.HasPrefix(string(source), expected) {
+		t.Fatalf("expected the generated source to start with %q, got:\n%s", expected, source)
+	}
+}